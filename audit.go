@@ -0,0 +1,237 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AuditSheetName is the name of the hidden sheet written with WriteConfig.Audit.
+const AuditSheetName = "_audit"
+
+// ErrNoAudit is returned by ReadAudit for files without audit sheet.
+var ErrNoAudit = errors.New("exl: no audit sheet")
+
+// modulePath identifies exl in the build info of the program
+const modulePath = "github.com/nullcache/exl"
+
+type (
+	// Audit describes how an export was written, see WriteConfig.Audit.
+	Audit struct {
+		// The exl version writing the file, like "exl v1.2.3".
+		Generator string
+		// When the file was written, in UTC.
+		Time time.Time
+		// The hex SHA-256 hash of the WriteConfig, telling exports written with other options apart.
+		// Functions are only hashed by whether they are set, WriteConfig.AuditUser and SigningKey are left out.
+		ConfigHash string
+		// See WriteConfig.AuditUser.
+		User string
+		// The sheets of records in order of creation.
+		Sheets []AuditSheet
+	}
+	// AuditSheet records the number of records written to a sheet.
+	AuditSheet struct {
+		Name    string
+		Records int
+	}
+)
+
+// The labels in column A of the audit sheet
+const (
+	auditGenerator  = "Generator"
+	auditTime       = "Time"
+	auditConfigHash = "ConfigHash"
+	auditUser       = "User"
+	auditSheet      = "Sheet"
+)
+
+// ReadAudit returns the audit sheet of the file of reader, see WriteConfig.Audit.
+func ReadAudit(reader io.Reader) (*Audit, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	book, err := XLSXBackend{}.Open(bs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBook(book)
+	sheet := -1
+	for i, name := range book.Sheets() {
+		if name == AuditSheetName {
+			sheet = i
+		}
+	}
+	if sheet < 0 {
+		return nil, ErrNoAudit
+	}
+	audit := &Audit{}
+	err = book.Rows(sheet, func(row *Row) error {
+		value := row.Cell(1).Value
+		switch row.Cell(0).Value {
+		case auditGenerator:
+			audit.Generator = value
+		case auditTime:
+			t, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return fmt.Errorf("exl: invalid audit time %q: %w", value, err)
+			}
+			audit.Time = t
+		case auditConfigHash:
+			audit.ConfigHash = value
+		case auditUser:
+			audit.User = value
+		case auditSheet:
+			records, err := strconv.Atoi(row.Cell(2).Value)
+			if err != nil {
+				return fmt.Errorf("exl: invalid audit record count of sheet %q: %w", value, err)
+			}
+			audit.Sheets = append(audit.Sheets, AuditSheet{Name: value, Records: records})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+// newAudit returns the audit of an export with wc written now, without sheets
+func newAudit(wc *WriteConfig) *Audit {
+	return &Audit{Generator: generator(), Time: time.Now().UTC(), ConfigHash: configHash(wc), User: wc.AuditUser}
+}
+
+// writeAudit appends the hidden audit sheet to book
+func writeAudit(book Spreadsheet, audit *Audit) error {
+	hider, ok := book.(SheetHider)
+	if !ok {
+		return ErrUnsupported
+	}
+	// Reading needs the exact name
+	name, err := newSheetName(book, AuditSheetName, true)
+	if err != nil {
+		return err
+	}
+	sheet, err := book.AddSheet(name)
+	if err != nil {
+		return err
+	}
+	rows := [][]Cell{
+		{StringCell(auditGenerator), StringCell(audit.Generator)},
+		{StringCell(auditTime), StringCell(audit.Time.Format(time.RFC3339Nano))},
+		{StringCell(auditConfigHash), StringCell(audit.ConfigHash)},
+		{StringCell(auditUser), StringCell(audit.User)},
+	}
+	for _, s := range audit.Sheets {
+		rows = append(rows, []Cell{StringCell(auditSheet), StringCell(s.Name), NewCell(s.Records)})
+	}
+	for i, cells := range rows {
+		if err = book.AppendRow(sheet, &Row{Index: i, Cells: cells}); err != nil {
+			return err
+		}
+	}
+	return hider.HideSheet(sheet)
+}
+
+// generator returns the name and version of the exl module of the program
+func generator() string {
+	version := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+			}
+		}
+	}
+	return "exl " + version
+}
+
+// configHash returns the hex SHA-256 hash of the options of wc
+func configHash(wc *WriteConfig) string {
+	h := sha256.New()
+	v := reflect.ValueOf(*wc)
+	for i := 0; i < v.NumField(); i++ {
+		if name := v.Type().Field(i).Name; name != "AuditUser" && name != "SigningKey" {
+			_, _ = io.WriteString(h, name+"=")
+			hashValue(h, v.Field(i))
+			_, _ = io.WriteString(h, ";")
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashValue writes a stable representation of v to h,
+// of which functions are only hashed by whether they are set and interfaces by their dynamic type,
+// e.g. the Backend and Metrics
+func hashValue(h hash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Func:
+		_, _ = fmt.Fprint(h, !v.IsNil())
+	case reflect.Interface:
+		if v.IsNil() {
+			_, _ = io.WriteString(h, "nil")
+			return
+		}
+		_, _ = io.WriteString(h, v.Elem().Type().String())
+	case reflect.Ptr:
+		if v.IsNil() {
+			_, _ = io.WriteString(h, "nil")
+			return
+		}
+		hashValue(h, v.Elem())
+	case reflect.Struct:
+		_, _ = io.WriteString(h, "{")
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+			_, _ = io.WriteString(h, ",")
+		}
+		_, _ = io.WriteString(h, "}")
+	case reflect.Slice, reflect.Array:
+		_, _ = io.WriteString(h, "[")
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+			_, _ = io.WriteString(h, ",")
+		}
+		_, _ = io.WriteString(h, "]")
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key := fmt.Sprint(iter.Key())
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+		_, _ = io.WriteString(h, "map[")
+		for _, key := range keys {
+			_, _ = io.WriteString(h, strconv.Quote(key)+":")
+			hashValue(h, values[key])
+			_, _ = io.WriteString(h, ",")
+		}
+		_, _ = io.WriteString(h, "]")
+	case reflect.String:
+		_, _ = io.WriteString(h, strconv.Quote(v.String()))
+	default:
+		_, _ = fmt.Fprint(h, v)
+	}
+}
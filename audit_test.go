@@ -0,0 +1,78 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type auditTmp struct {
+	Region string `excel:"Region"`
+	Amount int    `excel:"Amount"`
+}
+
+func (*auditTmp) ReadConfigure(*ReadConfig) {}
+func (*auditTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Audit = true
+	wc.AuditUser = "jdoe"
+	wc.SheetNameFunc = func(record any) string { return record.(*auditTmp).Region }
+}
+
+func TestWriteAudit(t *testing.T) {
+	before := time.Now().UTC()
+	ts := []*auditTmp{{"North", 1}, {"South", 2}, {"North", 3}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+
+	audit, err := ReadAudit(bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	equal(t, "exl (devel)", audit.Generator)
+	equal(t, false, audit.Time.Before(before) || audit.Time.After(time.Now()))
+	equal(t, configHash(newWriteConfig[*auditTmp]()), audit.ConfigHash)
+	equal(t, 64, len(audit.ConfigHash))
+	equal(t, "jdoe", audit.User)
+	equal(t, []AuditSheet{{"North", 2}, {"South", 1}}, audit.Sheets)
+
+	// The audit sheet is hidden and doesn't affect reading the records
+	if !strings.Contains(zipParts(t, buf.Bytes())["xl/workbook.xml"], `name="_audit" sheetId="3" r:id="rId3" state="hidden"`) {
+		t.Error("test failed: audit sheet isn't hidden")
+	}
+	north, err := ReadBinary[*auditTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*auditTmp{ts[0], ts[2]}, north)
+
+	// The hash changes with the options, but not with the user
+	wc := newWriteConfig[*auditTmp]()
+	wc.AuditUser = "other"
+	equal(t, audit.ConfigHash, configHash(wc))
+	wc.Redactors = map[string]Redactor{"x": MaskAll}
+	equal(t, false, audit.ConfigHash == configHash(wc))
+
+	plan, err := PlanWrite(ts, nil)
+	equal(t, nil, err)
+	equal(t, true, plan.Sheet(AuditSheetName).Hidden)
+
+	buf.Reset()
+	_ = WriteTo(buf, []*pageTmp{{1, "a"}})
+	_, err = ReadAudit(buf)
+	equal(t, ErrNoAudit, err)
+
+	// A sheet of records can't take the name of the audit sheet
+	var sne *SheetNameError
+	err = WriteTo(&bytes.Buffer{}, []*auditTmp{{AuditSheetName, 1}})
+	equal(t, true, errors.As(err, &sne))
+	equal(t, AuditSheetName, sne.Name)
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+type (
+	// SpreadsheetBackend opens and creates spreadsheets of one file format.
+	// The binding engine only talks to backends,
+	// so new formats can be supported by implementing this interface.
+	SpreadsheetBackend interface {
+		// Open parses the binary content of a spreadsheet file.
+		Open(data []byte) (Spreadsheet, error)
+		// Create returns a new spreadsheet without any sheet.
+		Create() Spreadsheet
+	}
+	// Spreadsheet is a workbook opened or created by a SpreadsheetBackend.
+	// Sheets are addressed by their 0-based index.
+	Spreadsheet interface {
+		// Sheets returns the names of all sheets, in workbook order.
+		Sheets() []string
+		// Date1904 reports whether the workbook uses the 1904 date system.
+		Date1904() bool
+		// Dimension returns the number of rows and columns of a sheet.
+		Dimension(sheet int) (rows, cols int, err error)
+		// Rows calls fn for every row of a sheet in order.
+		// Iteration stops at the first error returned by fn, which is passed on.
+		Rows(sheet int, fn func(row *Row) error) error
+		// AddSheet appends a new, empty sheet and returns its index.
+		AddSheet(name string) (int, error)
+		// AppendRow writes the row below the last row of a sheet.
+		AppendRow(sheet int, row *Row) error
+		// Save writes the spreadsheet file.
+		Save(w io.Writer) error
+	}
+	// DropListValidator is implemented by spreadsheets which support
+	// restricting the values of a column to a drop-down list.
+	DropListValidator interface {
+		// AddDropList restricts the cells of column col, starting at row firstRow, to values.
+		AddDropList(sheet, col, firstRow int, values []string, allowBlank bool, errMsg string) error
+	}
+	// SheetArranger is implemented by spreadsheets which support
+	// coloring, ordering and activating sheet tabs.
+	SheetArranger interface {
+		// SetTabColor colors the tab of a sheet, rgb is a hex color like "FF0000".
+		SetTabColor(sheet int, rgb string) error
+		// MoveSheet moves a sheet to a new 0-based position,
+		// shifting the indexes of the sheets in between.
+		MoveSheet(sheet, position int) error
+		// SetActiveSheet selects the sheet shown when the file is opened.
+		SetActiveSheet(sheet int) error
+	}
+	// SheetHider is implemented by spreadsheets which support hidden sheets.
+	SheetHider interface {
+		// HideSheet hides a sheet, which can still be shown by the user.
+		HideSheet(sheet int) error
+	}
+	// ColumnWidthSetter is implemented by spreadsheets which support column widths.
+	ColumnWidthSetter interface {
+		// SetColumnWidth sets the width of the 0-based column col of a sheet in characters.
+		SetColumnWidth(sheet, col int, width float64) error
+	}
+	// DateSystemSetter is implemented by spreadsheets which support the 1904 date system.
+	DateSystemSetter interface {
+		// SetDate1904 switches between the 1900 and 1904 date systems, before any sheet is added.
+		SetDate1904(date1904 bool) error
+	}
+	// RowGrouper is implemented by spreadsheets which support
+	// collapsible row groups, see Row.OutlineLevel.
+	RowGrouper interface {
+		// SetGroupSummaryBelow places the summary row of groups below (Excel default) or above the group.
+		SetGroupSummaryBelow(sheet int, below bool) error
+	}
+	// Compressor is implemented by spreadsheets saved as zip package.
+	Compressor interface {
+		// SetCompression sets the deflate level used by Save, 0 for the default level,
+		// and the path.Match patterns of the parts stored without compression.
+		SetCompression(level int, storedParts []string) error
+	}
+	// ValuesOpener is implemented by backends which can open a file faster
+	// by skipping its cell formatting, see ReadConfig.ValuesOnly.
+	ValuesOpener interface {
+		// OpenValues parses data like SpreadsheetBackend.Open,
+		// but without styles, themes and number formats.
+		OpenValues(data []byte) (Spreadsheet, error)
+	}
+	// RowSeeker is implemented by spreadsheets which can read a row without reading the rows above it.
+	RowSeeker interface {
+		// RowAt returns the row at the 0-based index n of a sheet, which is below the sheet Dimension.
+		RowAt(sheet, n int) (*Row, error)
+	}
+	// ColumnReader is implemented by spreadsheets which can skip the cells of columns nobody reads,
+	// speeding up reading wide sheets of which only a few columns are bound.
+	ColumnReader interface {
+		// RowsColumns calls fn for every row of a sheet in order like Spreadsheet.Rows,
+		// but only the cells of the columns cols are read, all other cells are empty.
+		RowsColumns(sheet int, cols []int, fn func(row *Row) error) error
+	}
+)
+
+var (
+	// ErrSheetNotFound is returned by backends for sheet indexes they don't know.
+	ErrSheetNotFound = errors.New("exl: sheet not found")
+	// ErrUnsupported is returned if a feature is not supported by the configured backend.
+	ErrUnsupported = errors.New("exl: not supported by backend")
+	// ErrDateSystemMismatch is returned when writing a sheet in another date system than the sheets before,
+	// see WriteConfig.Date1904.
+	ErrDateSystemMismatch = errors.New("exl: date system mismatch")
+	// ErrInvalidColor is returned for colors which are not hex RGB values.
+	ErrInvalidColor = errors.New("exl: invalid color")
+	// errStopRows is used to end a Spreadsheet.Rows iteration early
+	errStopRows = errors.New("exl: stop rows")
+)
+
+// readRow returns the row at index of a sheet, or nil if there is no such row.
+func readRow(book Spreadsheet, sheet, index int) (*Row, error) {
+	var found *Row
+	err := book.Rows(sheet, func(row *Row) error {
+		if row.Index < index {
+			return nil
+		}
+		if row.Index == index {
+			found = row
+		}
+		return errStopRows
+	})
+	if err != nil && err != errStopRows {
+		return nil, err
+	}
+	return found, nil
+}
+
+// argbColor normalizes a hex RGB color like "#ff0000" to the ARGB form "FFFF0000".
+func argbColor(rgb string) (string, error) {
+	c := strings.ToUpper(strings.TrimPrefix(rgb, "#"))
+	if len(c) == 6 {
+		c = "FF" + c
+	}
+	if len(c) != 8 || strings.Trim(c, "0123456789ABCDEF") != "" {
+		return "", ErrInvalidColor
+	}
+	return c, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// memBackend is a minimal line based backend,
+// one row per line and cells separated by ";".
+type memBackend struct{}
+
+type memSpreadsheet struct {
+	names  []string
+	sheets [][]*Row
+}
+
+func (memBackend) Open(data []byte) (Spreadsheet, error) {
+	s := &memSpreadsheet{names: []string{"Sheet1"}, sheets: [][]*Row{nil}}
+	for i, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		row := &Row{Index: i}
+		for _, v := range strings.Split(line, ";") {
+			row.Cells = append(row.Cells, StringCell(v))
+		}
+		s.sheets[0] = append(s.sheets[0], row)
+	}
+	return s, nil
+}
+
+func (memBackend) Create() Spreadsheet { return &memSpreadsheet{} }
+
+func (s *memSpreadsheet) Sheets() []string { return s.names }
+func (s *memSpreadsheet) Date1904() bool   { return false }
+
+func (s *memSpreadsheet) Dimension(sheet int) (rows, cols int, err error) {
+	if sheet >= len(s.sheets) {
+		return 0, 0, ErrSheetNotFound
+	}
+	for _, r := range s.sheets[sheet] {
+		if len(r.Cells) > cols {
+			cols = len(r.Cells)
+		}
+	}
+	return len(s.sheets[sheet]), cols, nil
+}
+
+func (s *memSpreadsheet) Rows(sheet int, fn func(row *Row) error) error {
+	if sheet >= len(s.sheets) {
+		return ErrSheetNotFound
+	}
+	for _, r := range s.sheets[sheet] {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memSpreadsheet) AddSheet(name string) (int, error) {
+	s.names = append(s.names, name)
+	s.sheets = append(s.sheets, nil)
+	return len(s.sheets) - 1, nil
+}
+
+func (s *memSpreadsheet) AppendRow(sheet int, row *Row) error {
+	if sheet >= len(s.sheets) {
+		return ErrSheetNotFound
+	}
+	row.Index = len(s.sheets[sheet])
+	s.sheets[sheet] = append(s.sheets[sheet], row)
+	return nil
+}
+
+func (s *memSpreadsheet) Save(w io.Writer) error {
+	for _, r := range s.sheets[0] {
+		if _, err := fmt.Fprintln(w, strings.Join(r.Strings(), ";")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type memModel struct {
+	ID   int    `excel:"ID"`
+	Name string `excel:"Name"`
+}
+
+func (*memModel) ReadConfigure(rc *ReadConfig)   { rc.Backend = memBackend{} }
+func (*memModel) WriteConfigure(wc *WriteConfig) { wc.Backend = memBackend{} }
+
+func TestCustomBackend(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*memModel{{1, "apple"}, {2, "pear"}}); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "ID;Name\n1;apple\n2;pear\n", buf.String())
+
+	models, err := Read[*memModel](buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []*memModel{{1, "apple"}, {2, "pear"}}, models)
+}
+
+func TestXLSXBackend(t *testing.T) {
+	book := XLSXBackend{}.Create()
+	sheet, err := book.AddSheet("Data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = book.AddSheet("Data"); err == nil {
+		t.Error("expected duplicate sheet error")
+	}
+	_ = book.AppendRow(sheet, NewRow("a", "b"))
+	_ = book.AppendRow(sheet, NewRow(1, true))
+	equal(t, ErrSheetNotFound, book.AppendRow(5, NewRow()))
+	equal(t, []string{"Data"}, book.Sheets())
+
+	buf := &bytes.Buffer{}
+	if err = book.Save(buf); err != nil {
+		t.Fatal(err)
+	}
+	opened, err := XLSXBackend{}.Open(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, cols, _ := opened.Dimension(0)
+	equal(t, 2, rows)
+	equal(t, 2, cols)
+	row, _ := readRow(opened, 0, 1)
+	equal(t, []string{"1", "1"}, row.Strings())
+	equal(t, CellTypeBool, row.Cell(1).Type)
+	_, _, err = opened.Dimension(1)
+	equal(t, ErrSheetNotFound, err)
+}
+
+func TestXLSXRowsColumns(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"a", "b", "c", "d"}, {"1", "2", "3", "4"}})
+	book, _ := XLSXBackend{}.Open(buf.Bytes())
+	var rows [][]string
+	err := book.(ColumnReader).RowsColumns(0, []int{1, 3, 9}, func(row *Row) error {
+		rows = append(rows, row.Strings())
+		return nil
+	})
+	equal(t, nil, err)
+	equal(t, [][]string{{"", "b", "", "d"}, {"", "2", "", "4"}}, rows)
+	equal(t, ErrSheetNotFound, book.(ColumnReader).RowsColumns(1, nil, nil))
+}
+
+type wideTmp struct {
+	B    string            `excel:"b"`
+	Rest map[string]string `excel:",rest"`
+}
+
+func (*wideTmp) ReadConfigure(*ReadConfig) {}
+
+type narrowTmp struct {
+	D int `excel:"d"`
+}
+
+func (*narrowTmp) ReadConfigure(*ReadConfig) {}
+
+func TestReadBoundColumnsOnly(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"a", "b", "c", "d"}, {"1", "2", "3", "4"}})
+	narrow, err := ReadBinary[*narrowTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*narrowTmp{{4}}, narrow)
+	wide, err := ReadBinary[*wideTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*wideTmp{{"2", map[string]string{"a": "1", "c": "3", "d": "4"}}}, wide)
+}
@@ -0,0 +1,454 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// XLSXBackend is the default SpreadsheetBackend, reading and writing
+// Office Open XML files with github.com/tealeg/xlsx.
+type XLSXBackend struct {
+	// Options passed to xlsx when opening or creating a file.
+	Options []xlsx.FileOption
+	// Keep the rows of the sheets of created files in temporary files instead of in memory,
+	// each row encrypted with AES-GCM under a random key per sheet, which is never written,
+	// so the files are unreadable to other processes and after a crash, e.g. for exports of personal data
+	// on shared hosts. The files are removed when the sheets are closed, e.g. by StreamWriter.Close.
+	EncryptSpill bool
+}
+
+type xlsxSpreadsheet struct {
+	file *xlsx.File
+	// Sheet tab colors in ARGB form
+	tabColors map[*xlsx.Sheet]string
+	// The sheet shown when opening the file, nil for the first one
+	active *xlsx.Sheet
+	// Sheets with the summary row above row groups
+	summaryAbove map[*xlsx.Sheet]bool
+	zip          zipOptions
+	// Sheets keep their rows in encrypted temporary files, see XLSXBackend.EncryptSpill
+	encryptSpill bool
+}
+
+// zipOptions configures the zip package written by Save, see Compressor
+type zipOptions struct {
+	// The deflate level, 0 for the default level
+	level  int
+	stored []string
+}
+
+// partPatch rewrites the XML content of a part of the saved package
+type partPatch func(content string) string
+
+var (
+	// Ensure XLSXBackend implements the backend interfaces
+	_ SpreadsheetBackend = XLSXBackend{}
+	_ ValuesOpener       = XLSXBackend{}
+	_ Spreadsheet        = (*xlsxSpreadsheet)(nil)
+	_ DropListValidator  = (*xlsxSpreadsheet)(nil)
+	_ SheetArranger      = (*xlsxSpreadsheet)(nil)
+	_ RowGrouper         = (*xlsxSpreadsheet)(nil)
+	_ ColumnReader       = (*xlsxSpreadsheet)(nil)
+	_ RowSeeker          = (*xlsxSpreadsheet)(nil)
+	_ Compressor         = (*xlsxSpreadsheet)(nil)
+	_ DateSystemSetter   = (*xlsxSpreadsheet)(nil)
+
+	sheetPrPattern = regexp.MustCompile(`<sheetPr[^>]*?(/?)>`)
+)
+
+// Open implements SpreadsheetBackend.
+func (b XLSXBackend) Open(data []byte) (Spreadsheet, error) {
+	f, err := xlsx.OpenBinary(data, b.Options...)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxSpreadsheet{file: f}, nil
+}
+
+// OpenValues implements ValuesOpener,
+// hiding the styles and theme parts of the package from xlsx.
+func (b XLSXBackend) OpenValues(data []byte) (Spreadsheet, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := r.File[:0:0]
+	for _, f := range r.File {
+		if !formattingPart(f.Name) {
+			files = append(files, f)
+		}
+	}
+	r.File = files
+	f, err := xlsx.ReadZipReader(r, b.Options...)
+	if err != nil {
+		return nil, err
+	}
+	return &xlsxSpreadsheet{file: f}, nil
+}
+
+// formattingPart reports whether the part of the package holds only cell formatting
+func formattingPart(name string) bool {
+	return name == "xl/styles.xml" || strings.HasPrefix(name, "xl/theme/")
+}
+
+// Create implements SpreadsheetBackend.
+func (b XLSXBackend) Create() Spreadsheet {
+	return &xlsxSpreadsheet{file: xlsx.NewFile(b.Options...), encryptSpill: b.EncryptSpill}
+}
+
+func (s *xlsxSpreadsheet) sheet(index int) (*xlsx.Sheet, error) {
+	if index < 0 || index >= len(s.file.Sheets) {
+		return nil, ErrSheetNotFound
+	}
+	return s.file.Sheets[index], nil
+}
+
+func (s *xlsxSpreadsheet) Sheets() []string {
+	names := make([]string, len(s.file.Sheets))
+	for i, sheet := range s.file.Sheets {
+		names[i] = sheet.Name
+	}
+	return names
+}
+
+func (s *xlsxSpreadsheet) Date1904() bool { return s.file.Date1904 }
+
+func (s *xlsxSpreadsheet) SetDate1904(date1904 bool) error {
+	if len(s.file.Sheets) > 0 {
+		return ErrDateSystemMismatch
+	}
+	s.file.Date1904 = date1904
+	return nil
+}
+
+func (s *xlsxSpreadsheet) Dimension(index int) (rows, cols int, err error) {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sheet.MaxRow, sheet.MaxCol, nil
+}
+
+func (s *xlsxSpreadsheet) Rows(index int, fn func(row *Row) error) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < sheet.MaxRow; i++ {
+		if row, _ := sheet.Row(i); row != nil {
+			if err := fn(rowFromXLSX(i, sheet.MaxCol, row)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *xlsxSpreadsheet) RowsColumns(index int, cols []int, fn func(row *Row) error) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < sheet.MaxRow; i++ {
+		if row, _ := sheet.Row(i); row != nil {
+			r := &Row{Index: i, Cells: make([]Cell, sheet.MaxCol), OutlineLevel: row.GetOutlineLevel(), src: make([]*xlsx.Cell, sheet.MaxCol)}
+			for _, col := range cols {
+				if col < sheet.MaxCol {
+					r.src[col] = row.GetCell(col)
+					r.Cells[col] = cellFromXLSX(r.src[col])
+				}
+			}
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *xlsxSpreadsheet) RowAt(index, n int) (*Row, error) {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return nil, err
+	}
+	row, err := sheet.Row(n)
+	if err != nil {
+		return nil, err
+	}
+	return rowFromXLSX(n, sheet.MaxCol, row), nil
+}
+
+func (s *xlsxSpreadsheet) AddSheet(name string) (int, error) {
+	var err error
+	if s.encryptSpill {
+		_, err = s.file.AddSheetWithCellStore(name, newSealedCellStore)
+	} else {
+		_, err = s.file.AddSheet(name)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(s.file.Sheets) - 1, nil
+}
+
+func (s *xlsxSpreadsheet) AppendRow(index int, row *Row) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	appendXLSXRow(sheet, row)
+	return nil
+}
+
+func (s *xlsxSpreadsheet) Save(w io.Writer) error {
+	if s.active != nil {
+		for _, sheet := range s.file.Sheets {
+			sheet.Selected = sheet == s.active
+		}
+	}
+	patches := s.patches()
+	if len(patches) == 0 && len(s.zip.stored) == 0 {
+		zw := s.zip.writer(w)
+		if err := s.file.MarshallParts(zw); err != nil {
+			return err
+		}
+		return zw.Close()
+	}
+	// Compressed once by rewriteParts
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, flate.NoCompression)
+	})
+	if err := s.file.MarshallParts(zw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return rewriteParts(buf.Bytes(), w, patches, s.zip)
+}
+
+func (s *xlsxSpreadsheet) SetCompression(level int, storedParts []string) error {
+	if level < 0 || level > flate.BestCompression {
+		return fmt.Errorf("exl: invalid compression level %d", level)
+	}
+	s.zip = zipOptions{level: level, stored: storedParts}
+	return nil
+}
+
+// writer returns a zip writer deflating at the level of o
+func (o zipOptions) writer(w io.Writer) *zip.Writer {
+	zw := zip.NewWriter(w)
+	if o.level != 0 {
+		level := o.level
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+	}
+	return zw
+}
+
+// method returns the compression method of the part named name
+func (o zipOptions) method(name string) uint16 {
+	for _, pattern := range o.stored {
+		if matched, _ := path.Match(pattern, name); matched {
+			return zip.Store
+		}
+	}
+	return zip.Deflate
+}
+
+// Close releases the cell stores of the sheets, removing their temporary files if stored on disk.
+func (s *xlsxSpreadsheet) Close() error {
+	for _, sheet := range s.file.Sheets {
+		sheet.Close()
+	}
+	return nil
+}
+
+// patches collects the changes xlsx can't express, by part name
+func (s *xlsxSpreadsheet) patches() map[string][]partPatch {
+	patches := make(map[string][]partPatch)
+	if s.file.Date1904 {
+		// xlsx always writes the 1900 date system
+		patches["xl/workbook.xml"] = append(patches["xl/workbook.xml"], func(content string) string {
+			return strings.Replace(content, `date1904="false"`, `date1904="true"`, 1)
+		})
+	}
+	for i, sheet := range s.file.Sheets {
+		part := fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)
+		sheetPr := ""
+		if color, ok := s.tabColors[sheet]; ok {
+			sheetPr += `<tabColor rgb="` + color + `"/>`
+		}
+		if s.summaryAbove[sheet] {
+			sheetPr += `<outlinePr summaryBelow="0"/>`
+		}
+		if sheetPr != "" {
+			patches[part] = append(patches[part], func(content string) string {
+				return insertSheetPr(content, sheetPr)
+			})
+		}
+		if sheet == s.active && i > 0 {
+			activeTab := fmt.Sprintf(`<workbookView activeTab="%d"`, i)
+			patches["xl/workbook.xml"] = append(patches["xl/workbook.xml"], func(content string) string {
+				return strings.Replace(content, "<workbookView", activeTab, 1)
+			})
+		}
+	}
+	return patches
+}
+
+// insertSheetPr adds child elements to the sheetPr element of a worksheet part
+func insertSheetPr(content, children string) string {
+	loc := sheetPrPattern.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return strings.Replace(content, "<sheetData", "<sheetPr>"+children+"</sheetPr><sheetData", 1)
+	}
+	if loc[2] != loc[3] {
+		// Self closing element, open it up
+		open := content[loc[0]:loc[2]] + ">"
+		return content[:loc[0]] + open + children + "</sheetPr>" + content[loc[1]:]
+	}
+	return content[:loc[1]] + children + content[loc[1]:]
+}
+
+// rewriteParts copies the zip package data to w, applying patches to its parts
+// and compressing them as configured by opts
+func rewriteParts(data []byte, w io.Writer, patches map[string][]partPatch, opts zipOptions) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	zw := opts.writer(w)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+		if ps, ok := patches[f.Name]; ok {
+			str := string(content)
+			for _, p := range ps {
+				str = p(str)
+			}
+			content = []byte(str)
+		}
+		pw, err := zw.CreateHeader(&zip.FileHeader{Name: f.Name, Method: opts.method(f.Name)})
+		if err != nil {
+			return err
+		}
+		if _, err = pw.Write(content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (s *xlsxSpreadsheet) SetTabColor(index int, rgb string) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	color, err := argbColor(rgb)
+	if err != nil {
+		return err
+	}
+	if s.tabColors == nil {
+		s.tabColors = make(map[*xlsx.Sheet]string)
+	}
+	s.tabColors[sheet] = color
+	return nil
+}
+
+func (s *xlsxSpreadsheet) MoveSheet(index, position int) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	if _, err = s.sheet(position); err != nil {
+		return err
+	}
+	sheets := append(s.file.Sheets[:index:index], s.file.Sheets[index+1:]...)
+	sheets = append(sheets[:position], append([]*xlsx.Sheet{sheet}, sheets[position:]...)...)
+	s.file.Sheets = sheets
+	return nil
+}
+
+func (s *xlsxSpreadsheet) SetActiveSheet(index int) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	s.active = sheet
+	return nil
+}
+
+func (s *xlsxSpreadsheet) HideSheet(index int) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	sheet.Hidden = true
+	return nil
+}
+
+func (s *xlsxSpreadsheet) SetColumnWidth(index, col int, width float64) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	sheet.SetColWidth(col+1, col+1, width)
+	return nil
+}
+
+func (s *xlsxSpreadsheet) SetGroupSummaryBelow(index int, below bool) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	if s.summaryAbove == nil {
+		s.summaryAbove = make(map[*xlsx.Sheet]bool)
+	}
+	s.summaryAbove[sheet] = !below
+	return nil
+}
+
+func (s *xlsxSpreadsheet) AddDropList(index, col, firstRow int, values []string, allowBlank bool, errMsg string) error {
+	sheet, err := s.sheet(index)
+	if err != nil {
+		return err
+	}
+	dd := xlsx.NewDataValidation(firstRow, col, xlsx.Excel2006MaxRowIndex, col, allowBlank)
+	if err = dd.SetDropList(values); err != nil {
+		return err
+	}
+	errTitle := ""
+	dd.SetError(xlsx.StyleStop, &errTitle, &errMsg)
+	sheet.AddDataValidation(dd)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCellRef is returned for malformed column letters and A1 style references.
+var ErrInvalidCellRef = errors.New("exl: invalid cell reference")
+
+// ColumnLetter returns the letters of the 0-based column index, e.g. "A" for 0 and "AA" for 26.
+func ColumnLetter(col int) string {
+	if col < 0 {
+		return ""
+	}
+	var b []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		b = append([]byte{byte('A' + (col-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+// ColumnIndex returns the 0-based column index of column letters like "AA", case-insensitive.
+func ColumnIndex(letters string) (int, error) {
+	if letters == "" || len(letters) > 3 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, letters)
+	}
+	col := 0
+	for _, c := range strings.ToUpper(letters) {
+		if c < 'A' || c > 'Z' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, letters)
+		}
+		col = col*26 + int(c-'A') + 1
+	}
+	return col - 1, nil
+}
+
+// CellRef returns the A1 style reference of the cell at the 0-based row and column index,
+// e.g. "B3" for row 2 and column 1.
+func CellRef(row, col int) string {
+	return ColumnLetter(col) + strconv.Itoa(row+1)
+}
+
+// ParseCellRef returns the 0-based row and column index of an A1 style reference.
+// Absolute references like "$B$3" are accepted.
+func ParseCellRef(ref string) (row, col int, err error) {
+	s := strings.ReplaceAll(ref, "$", "")
+	i := strings.IndexAny(s, "0123456789")
+	if i <= 0 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	if col, err = ColumnIndex(s[:i]); err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	row, err = strconv.Atoi(s[i:])
+	if err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	return row - 1, col, nil
+}
+
+// HeaderColumn returns the column letters of the column with the given header
+// in sheets written for T, e.g. for composing formulas against the written data.
+func HeaderColumn[T WriteConfigurator](header string) (string, bool) {
+	typ := reflect.TypeOf(new(T)).Elem().Elem()
+	for i, h := range newSheetLayout(typ, newWriteConfig[T]()).header() {
+		if h == header {
+			return ColumnLetter(i), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestColumnLetter(t *testing.T) {
+	for col, letters := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA", 16383: "XFD", -1: ""} {
+		equal(t, letters, ColumnLetter(col))
+		if col >= 0 {
+			index, err := ColumnIndex(letters)
+			equal(t, nil, err)
+			equal(t, col, index)
+		}
+	}
+	index, _ := ColumnIndex("xfd")
+	equal(t, 16383, index)
+	for _, letters := range []string{"", "A1", "ABCD"} {
+		if _, err := ColumnIndex(letters); !errors.Is(err, ErrInvalidCellRef) {
+			t.Errorf("expected ErrInvalidCellRef for %q, got %v", letters, err)
+		}
+	}
+}
+
+func TestCellRef(t *testing.T) {
+	equal(t, "B3", CellRef(2, 1))
+	row, col, err := ParseCellRef("$AA$10")
+	equal(t, nil, err)
+	equal(t, 9, row)
+	equal(t, 26, col)
+	for _, ref := range []string{"", "3", "B", "B0", "1B", "B3C"} {
+		if _, _, err = ParseCellRef(ref); !errors.Is(err, ErrInvalidCellRef) {
+			t.Errorf("expected ErrInvalidCellRef for %q, got %v", ref, err)
+		}
+	}
+}
+
+func TestHeaderColumn(t *testing.T) {
+	letters, ok := HeaderColumn[*writeTmp]("Name3")
+	equal(t, true, ok)
+	equal(t, "C", letters)
+	// generated key column comes first
+	letters, _ = HeaderColumn[*masterTmp]("Customer")
+	equal(t, "B", letters)
+	_, ok = HeaderColumn[*writeTmp]("Missing")
+	equal(t, false, ok)
+}
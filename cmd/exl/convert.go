@@ -0,0 +1,157 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nullcache/exl"
+)
+
+func runSheets(fs *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer) error {
+	file, err := fileArg(fs, args)
+	if err != nil {
+		return err
+	}
+	input, err := readInput(file, stdin)
+	if err != nil {
+		return err
+	}
+	info, err := exl.Inspect(input)
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "INDEX\tNAME\tDIMENSION\tROWS\tCOLUMNS")
+	for i, s := range info.Sheets {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\n", i, s.Name, s.Dimension, s.Rows, s.Columns)
+	}
+	return tw.Flush()
+}
+
+func runConvert(fs *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer) error {
+	sheet := fs.String("sheet", "0", "name or 0-based index of the sheet")
+	format := fs.String("format", "csv", "output format, csv or json")
+	header := fs.Int("header", 0, "0-based index of the header row naming the json fields")
+	file, err := fileArg(fs, args)
+	if err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	input, err := readInput(file, stdin)
+	if err != nil {
+		return err
+	}
+	index, info, err := sheetIndex(input, *sheet)
+	if err != nil {
+		return err
+	}
+	if *format == "csv" {
+		w := csv.NewWriter(stdout)
+		err = exl.ReadExcelFrom(input, index, func(_ int, row *exl.Row) error {
+			return w.Write(displayValues(row, info.Date1904))
+		})
+		if err != nil {
+			return err
+		}
+		w.Flush()
+		return w.Error()
+	}
+	return writeJSON(stdout, input, index, *header, info.Date1904)
+}
+
+// writeJSON writes the rows below the header row as an array of objects,
+// keeping the column order for the fields, date1904 as in displayValues
+func writeJSON(w io.Writer, input io.Reader, sheet, header int, date1904 bool) error {
+	var headers []string
+	records := 0
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	err := exl.ReadExcelFrom(input, sheet, func(index int, row *exl.Row) error {
+		if index < header || row.IsEmpty() {
+			return nil
+		}
+		if index == header {
+			headers = row.Strings()
+			return nil
+		}
+		sep := ",\n "
+		if records == 0 {
+			sep = "\n "
+		}
+		records++
+		if _, err := io.WriteString(w, sep+"{"); err != nil {
+			return err
+		}
+		values := displayValues(row, date1904)
+		fields := 0
+		for i, h := range headers {
+			if h == "" {
+				continue
+			}
+			key, _ := json.Marshal(h)
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			val, _ := json.Marshal(value)
+			field := string(key) + ":" + string(val)
+			if fields > 0 {
+				field = "," + field
+			}
+			fields++
+			if _, err := io.WriteString(w, field); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, "}")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n]\n")
+	return err
+}
+
+// displayValues returns the cell values of row as shown by spreadsheet applications for dates,
+// of the 1904 date system if date1904 is set, and as stored for all other cells
+func displayValues(row *exl.Row, date1904 bool) []string {
+	values := row.Strings()
+	for i, c := range row.Cells {
+		if c.Type != exl.CellTypeNumber || c.NumFmt == "" {
+			continue
+		}
+		xc := c.XLSX()
+		if !xc.IsTime() {
+			continue
+		}
+		if t, err := xc.GetTime(date1904); err == nil {
+			t = t.Round(time.Second)
+			if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+				values[i] = t.Format("2006-01-02")
+			} else {
+				values[i] = t.Format("2006-01-02 15:04:05")
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command exl converts, inspects and validates xlsx files.
+//
+// Usage:
+//
+//	exl sheets FILE
+//	exl convert [-sheet N] [-format csv|json] [-header N] FILE
+//	exl schema [-sheet N] [-header N] FILE
+//	exl validate -schema SCHEMA.json [-sheet N] [-header N] FILE
+//	exl template -schema SCHEMA.json -o OUT.xlsx
+//
+// FILE may be "-" to read standard input.
+// The schema file written by exl schema is accepted by exl validate and exl template.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/nullcache/exl"
+)
+
+var (
+	// errValidation reports that a file doesn't match its schema, the problems are printed already
+	errValidation = errors.New("validation failed")
+	// errUsage reports missing or surplus arguments
+	errUsage = errors.New("invalid arguments")
+	// errFlags reports invalid flags, the flag package printed the problem already
+	errFlags = errors.New("invalid flags")
+)
+
+const usage = `usage:
+  exl sheets FILE                      list the sheets and their dimensions
+  exl convert [flags] FILE             convert a sheet to csv or json
+  exl schema [flags] FILE              infer the column types of a sheet
+  exl validate -schema FILE FILE       check a sheet against a schema file
+  exl template -schema FILE -o FILE    write an empty sheet with the schema columns
+FILE may be "-" to read standard input, run "exl COMMAND -h" for the flags.
+`
+
+type command func(fs *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer) error
+
+var commands = map[string]command{
+	"sheets":   runSheets,
+	"convert":  runConvert,
+	"schema":   runSchema,
+	"validate": runValidate,
+	"template": runTemplate,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return 2
+	}
+	cmd, have := commands[args[0]]
+	if !have {
+		_, _ = fmt.Fprintf(stderr, "exl: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("exl "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	if err := cmd(fs, args[1:], stdin, stdout); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		if err == errUsage {
+			fs.Usage()
+		}
+		if err == errUsage || err == errFlags {
+			return 2
+		}
+		if err != errValidation {
+			_, _ = fmt.Fprintf(stderr, "exl %s: %v\n", args[0], err)
+		}
+		return 1
+	}
+	return 0
+}
+
+// readInput returns the content of the file named name, or of stdin for "-"
+func readInput(name string, stdin io.Reader) (*bytes.Reader, error) {
+	var bs []byte
+	var err error
+	if name == "-" {
+		bs, err = io.ReadAll(stdin)
+	} else {
+		bs, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(bs), nil
+}
+
+// fileArg parses the flags of fs and returns the single file argument
+func fileArg(fs *flag.FlagSet, args []string) (string, error) {
+	if err := parseFlags(fs, args); err != nil {
+		return "", err
+	}
+	if fs.NArg() != 1 {
+		return "", errUsage
+	}
+	return fs.Arg(0), nil
+}
+
+// parseFlags parses the flags of fs, passing on flag.ErrHelp
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return errFlags
+	}
+	return nil
+}
+
+// sheetIndex resolves the sheet flag, a sheet name or 0-based index, to the index of the sheet,
+// also returning the info of the workbook
+func sheetIndex(input *bytes.Reader, sheet string) (int, exl.FileInfo, error) {
+	info, err := exl.Inspect(input)
+	if err != nil {
+		return 0, info, err
+	}
+	for i, s := range info.Sheets {
+		if s.Name == sheet {
+			return i, info, nil
+		}
+	}
+	if i, err := strconv.Atoi(sheet); err == nil && i >= 0 && i < len(info.Sheets) {
+		return i, info, nil
+	}
+	return 0, info, fmt.Errorf("%w: %s", exl.ErrSheetNotFound, sheet)
+}
+
+// readConfig returns the read config locating the data of a sheet below the header row
+func readConfig(sheet, header int) *exl.ReadConfig {
+	return &exl.ReadConfig{
+		TagName:           "excel",
+		SheetIndex:        sheet,
+		HeaderRowIndex:    header,
+		DataStartRowIndex: 1,
+		DataStartRelative: true,
+		Backend:           exl.XLSXBackend{},
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nullcache/exl"
+)
+
+type item struct {
+	Name    string    `excel:"Name"`
+	Qty     int       `excel:"Qty"`
+	Created time.Time `excel:"Created"`
+}
+
+func (*item) WriteConfigure(*exl.WriteConfig) {}
+
+type item1904 item
+
+func (*item1904) WriteConfigure(wc *exl.WriteConfig) { wc.Date1904 = true }
+
+func writeItems(t *testing.T, dir string) string {
+	t.Helper()
+	created := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	file := filepath.Join(dir, "items.xlsx")
+	if err := exl.WriteFile(file, []*item{{"apple", 2, created}, {"pear", 3, created}}); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func exec(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := run(args, strings.NewReader(stdin), stdout, stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestRunUsage(t *testing.T) {
+	if _, stderr, code := exec(t, ""); code != 2 || !strings.Contains(stderr, "usage:") {
+		t.Errorf("expected usage, got %d %q", code, stderr)
+	}
+	if _, stderr, code := exec(t, "", "unknown"); code != 2 || !strings.Contains(stderr, `unknown command "unknown"`) {
+		t.Errorf("expected unknown command, got %d %q", code, stderr)
+	}
+	if _, _, code := exec(t, "", "convert"); code != 2 {
+		t.Errorf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestRunSheetsAndConvert(t *testing.T) {
+	file := writeItems(t, t.TempDir())
+
+	stdout, _, code := exec(t, "", "sheets", file)
+	if code != 0 || !strings.Contains(stdout, "0      Sheet1  A1:C3") {
+		t.Errorf("unexpected sheets output %d %q", code, stdout)
+	}
+
+	stdout, _, code = exec(t, "", "convert", file)
+	if expected := "Name,Qty,Created\napple,2,2023-01-02\npear,3,2023-01-02\n"; code != 0 || stdout != expected {
+		t.Errorf("expected %q, got %d %q", expected, code, stdout)
+	}
+
+	data, _ := os.ReadFile(file)
+	stdout, _, code = exec(t, string(data), "convert", "-format", "json", "-sheet", "Sheet1", "-")
+	expected := "[\n {\"Name\":\"apple\",\"Qty\":\"2\",\"Created\":\"2023-01-02\"},\n {\"Name\":\"pear\",\"Qty\":\"3\",\"Created\":\"2023-01-02\"}\n]\n"
+	if code != 0 || stdout != expected {
+		t.Errorf("expected %q, got %d %q", expected, code, stdout)
+	}
+
+	if _, stderr, code := exec(t, "", "convert", "-sheet", "Other", file); code != 1 || !strings.Contains(stderr, "sheet not found") {
+		t.Errorf("expected sheet not found, got %d %q", code, stderr)
+	}
+
+	// Dates of the 1904 date system
+	file = filepath.Join(t.TempDir(), "items1904.xlsx")
+	created := time.Date(2023, 1, 2, 12, 30, 0, 0, time.UTC)
+	if err := exl.WriteFile(file, []*item1904{{"apple", 2, created}}); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, code = exec(t, "", "convert", file)
+	if expected := "Name,Qty,Created\napple,2,2023-01-02 12:30:00\n"; code != 0 || stdout != expected {
+		t.Errorf("expected %q, got %d %q", expected, code, stdout)
+	}
+	stdout, _, code = exec(t, "", "convert", "-format", "json", file)
+	if expected := "[\n {\"Name\":\"apple\",\"Qty\":\"2\",\"Created\":\"2023-01-02 12:30:00\"}\n]\n"; code != 0 || stdout != expected {
+		t.Errorf("expected %q, got %d %q", expected, code, stdout)
+	}
+}
+
+func TestRunSchemaValidateTemplate(t *testing.T) {
+	dir := t.TempDir()
+	file := writeItems(t, dir)
+
+	stdout, _, code := exec(t, "", "schema", file)
+	if code != 0 || !strings.Contains(stdout, `"header": "Created",`) || !strings.Contains(stdout, `"type": "date",`) {
+		t.Fatalf("unexpected schema output %d %q", code, stdout)
+	}
+	schema := filepath.Join(dir, "schema.json")
+	_ = os.WriteFile(schema, []byte(stdout), 0o600)
+
+	if stdout, _, code = exec(t, "", "validate", "-schema", schema, file); code != 0 || stdout != "" {
+		t.Errorf("expected a valid file, got %d %q", code, stdout)
+	}
+
+	invalid := filepath.Join(dir, "invalid.xlsx")
+	_ = exl.WriteExcel(invalid, [][]string{{"Name", "Qty"}, {"apple", "two"}, {"", "3"}})
+	stdout, _, code = exec(t, "", "validate", "-schema", schema, invalid)
+	expected := "column \"Created\": missing\nB2: column \"Qty\": \"two\" is no int\nA3: column \"Name\": value required\n"
+	if code != 1 || stdout != expected {
+		t.Errorf("expected %q, got %d %q", expected, code, stdout)
+	}
+
+	template := filepath.Join(dir, "template.xlsx")
+	if _, stderr, code := exec(t, "", "template", "-schema", schema, "-o", template); code != 0 {
+		t.Fatalf("template failed: %s", stderr)
+	}
+	stdout, _, _ = exec(t, "", "convert", template)
+	if expected = "Name,Qty,Created\n"; stdout != expected {
+		t.Errorf("expected %q, got %q", expected, stdout)
+	}
+}
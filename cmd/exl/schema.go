@@ -0,0 +1,193 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nullcache/exl"
+)
+
+type (
+	// schemaFile describes the columns of a sheet,
+	// as written by exl schema and read by exl validate and exl template.
+	schemaFile struct {
+		Columns []schemaColumn `json:"columns"`
+	}
+	schemaColumn struct {
+		Header string           `json:"header"`
+		Type   exl.InferredType `json:"type"`
+		// Cells of required columns must not be empty
+		Required bool `json:"required,omitempty"`
+		// Informational, written by exl schema
+		Values  int      `json:"values,omitempty"`
+		Nulls   int      `json:"nulls,omitempty"`
+		Samples []string `json:"samples,omitempty"`
+	}
+)
+
+func runSchema(fs *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer) error {
+	sheet := fs.String("sheet", "0", "name or 0-based index of the sheet")
+	header := fs.Int("header", 0, "0-based index of the header row")
+	file, err := fileArg(fs, args)
+	if err != nil {
+		return err
+	}
+	input, err := readInput(file, stdin)
+	if err != nil {
+		return err
+	}
+	index, _, err := sheetIndex(input, *sheet)
+	if err != nil {
+		return err
+	}
+	columns, err := exl.InferSchema(input, readConfig(index, *header))
+	if err != nil {
+		return err
+	}
+	schema := schemaFile{Columns: make([]schemaColumn, 0, len(columns))}
+	for _, c := range columns {
+		schema.Columns = append(schema.Columns, schemaColumn{
+			Header:   c.Header,
+			Type:     c.Type,
+			Required: c.Values > 0 && c.Nulls == 0,
+			Values:   c.Values,
+			Nulls:    c.Nulls,
+			Samples:  c.Samples,
+		})
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
+
+func runValidate(fs *flag.FlagSet, args []string, stdin io.Reader, stdout io.Writer) error {
+	schemaName := fs.String("schema", "", "schema file as written by exl schema")
+	sheet := fs.String("sheet", "0", "name or 0-based index of the sheet")
+	header := fs.Int("header", 0, "0-based index of the header row")
+	file, err := fileArg(fs, args)
+	if err != nil {
+		return err
+	}
+	schema, err := readSchema(*schemaName)
+	if err != nil {
+		return err
+	}
+	input, err := readInput(file, stdin)
+	if err != nil {
+		return err
+	}
+	index, _, err := sheetIndex(input, *sheet)
+	if err != nil {
+		return err
+	}
+	problems := 0
+	report := func(format string, a ...any) {
+		problems++
+		_, _ = fmt.Fprintf(stdout, format+"\n", a...)
+	}
+	// The column index of each schema column, -1 if missing
+	columnIndexes := make([]int, len(schema.Columns))
+	sawHeader := false
+	err = exl.ReadExcelFrom(input, index, func(rowIndex int, row *exl.Row) error {
+		if rowIndex < *header || (rowIndex > *header && row.IsEmpty()) {
+			return nil
+		}
+		if rowIndex == *header {
+			sawHeader = true
+			headers := row.Strings()
+			for i, c := range schema.Columns {
+				columnIndexes[i] = -1
+				for j, h := range headers {
+					if h == c.Header {
+						columnIndexes[i] = j
+					}
+				}
+				if columnIndexes[i] < 0 {
+					report("column %q: missing", c.Header)
+				}
+			}
+			return nil
+		}
+		for i, c := range schema.Columns {
+			if columnIndexes[i] < 0 {
+				continue
+			}
+			cell := row.Cell(columnIndexes[i])
+			ref := exl.CellRef(rowIndex, columnIndexes[i])
+			switch {
+			case c.Required && cell.Value == "":
+				report("%s: column %q: value required", ref, c.Header)
+			case !c.Type.Accepts(cell):
+				report("%s: column %q: %q is no %s", ref, c.Header, cell.Value, c.Type)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !sawHeader {
+		report("header row %d: missing", *header+1)
+	}
+	if problems > 0 {
+		return errValidation
+	}
+	return nil
+}
+
+func runTemplate(fs *flag.FlagSet, args []string, _ io.Reader, _ io.Writer) error {
+	schemaName := fs.String("schema", "", "schema file as written by exl schema")
+	out := fs.String("o", "", "xlsx file to write")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *out == "" || fs.NArg() != 0 {
+		return errUsage
+	}
+	schema, err := readSchema(*schemaName)
+	if err != nil {
+		return err
+	}
+	headers := make([]string, 0, len(schema.Columns))
+	for _, c := range schema.Columns {
+		headers = append(headers, c.Header)
+	}
+	return exl.WriteExcel(*out, [][]string{headers})
+}
+
+// readSchema reads the schema file named name
+func readSchema(name string) (*schemaFile, error) {
+	if name == "" {
+		return nil, errUsage
+	}
+	bs, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	schema := &schemaFile{}
+	if err = json.Unmarshal(bs, schema); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+	for _, c := range schema.Columns {
+		switch c.Type {
+		case exl.TypeInt, exl.TypeFloat, exl.TypeDate, exl.TypeBool, exl.TypeString:
+		default:
+			return nil, fmt.Errorf("schema %s: column %q: unknown type %q", name, c.Header, c.Type)
+		}
+	}
+	return schema, nil
+}
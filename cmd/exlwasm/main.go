@@ -0,0 +1,86 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+
+// Command exlwasm exposes exl to JavaScript when compiled to WebAssembly,
+// e.g. to check uploads in the browser before sending them:
+//
+//	GOOS=js GOARCH=wasm go build -o exl.wasm ./cmd/exlwasm
+//
+// It registers a global exl object, whose functions take the file content as Uint8Array
+// and return {result: "<JSON>"} or {error: "<message>"}:
+//
+//	exl.inspect(data)                          // exl.FileInfo
+//	exl.preview(data, sheetIndex, n)           // [][]string
+//	exl.inferSchema(data, sheetIndex, header)  // []exl.ColumnSchema
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/nullcache/exl"
+)
+
+func main() {
+	js.Global().Set("exl", js.ValueOf(map[string]any{
+		"inspect": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return result(exl.Inspect(bytes.NewReader(content(args))))
+		}),
+		"preview": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return result(exl.Preview(bytes.NewReader(content(args)), intArg(args, 1), intArg(args, 2)))
+		}),
+		"inferSchema": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			rc := &exl.ReadConfig{
+				TagName:           "excel",
+				SheetIndex:        intArg(args, 1),
+				HeaderRowIndex:    intArg(args, 2),
+				DataStartRowIndex: 1,
+				DataStartRelative: true,
+				Backend:           exl.XLSXBackend{},
+			}
+			return result(exl.InferSchema(bytes.NewReader(content(args)), rc))
+		}),
+	}))
+	// Keep the functions callable
+	select {}
+}
+
+// content copies the Uint8Array of the first argument
+func content(args []js.Value) []byte {
+	if len(args) == 0 || args[0].Type() != js.TypeObject {
+		return nil
+	}
+	data := make([]byte, args[0].Length())
+	js.CopyBytesToGo(data, args[0])
+	return data
+}
+
+// intArg returns the argument at index as int, 0 if missing
+func intArg(args []js.Value, index int) int {
+	if index >= len(args) || args[index].Type() != js.TypeNumber {
+		return 0
+	}
+	return args[index].Int()
+}
+
+// result returns the JavaScript object holding the JSON encoded v or the message of err
+func result(v any, err error) any {
+	if err == nil {
+		var bs []byte
+		if bs, err = json.Marshal(v); err == nil {
+			return map[string]any{"result": string(bs)}
+		}
+	}
+	return map[string]any{"error": err.Error()}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// groupedNumberPattern matches numbers with thousands separators like "1,234.5"
+var groupedNumberPattern = regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+(\.\d+)?$`)
+
+// coerceNumber returns the number of the text value of a numeric field of kind, see ReadConfig.CoerceNumbers:
+// thousands separators are stripped, and percentages like "12.5%" are divided by 100 for float fields
+func coerceNumber(value string, kind reflect.Kind) (string, bool) {
+	value = strings.TrimSpace(value)
+	percent := false
+	if p, ok := trimSuffix(value, "%"); ok && (kind == reflect.Float32 || kind == reflect.Float64) {
+		value, percent = strings.TrimSpace(p), true
+	}
+	coerced := percent
+	if groupedNumberPattern.MatchString(value) {
+		value, coerced = strings.ReplaceAll(value, ",", ""), true
+	}
+	if !coerced {
+		return "", false
+	}
+	if percent {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", false
+		}
+		value = strconv.FormatFloat(f/100, 'g', -1, 64)
+	}
+	return value, true
+}
+
+// trimSuffix returns s without suffix, ok is false if s doesn't end with it
+func trimSuffix(s, suffix string) (string, bool) {
+	if !strings.HasSuffix(s, suffix) {
+		return s, false
+	}
+	return s[:len(s)-len(suffix)], true
+}
+
+// numericKind reports whether fields of type t hold numbers
+func numericKind(t reflect.Type) bool {
+	switch indirectType(t).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
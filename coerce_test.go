@@ -0,0 +1,74 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type coerceTmp struct {
+	Qty   int      `excel:"Qty"`
+	Rate  *float64 `excel:"Rate"`
+	Label string   `excel:"Label"`
+}
+
+func (*coerceTmp) ReadConfigure(rc *ReadConfig) { rc.CoerceNumbers = true }
+
+type strictNumberTmp coerceTmp
+
+func (*strictNumberTmp) ReadConfigure(*ReadConfig) {}
+
+func TestReadCoerceNumbers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Qty", "Rate", "Label"}, {"1,234", "12.5%", "1,000"}, {"7", "0.5", "x"}})
+	data := buf.Bytes()
+	ts, report, err := ReadBinaryWithReport[*coerceTmp](data)
+	equal(t, nil, err)
+	equal(t, 1234, ts[0].Qty)
+	equal(t, 0.125, *ts[0].Rate)
+	equal(t, "1,000", ts[0].Label)
+	equal(t, 7, ts[1].Qty)
+	equal(t, []Warning{
+		{Kind: WarningCoerced, RowIndex: 1, ColumnIndex: 0, ColumnHeader: "Qty", CellRef: "A2", Message: `text "1,234" read as number 1234`},
+		{Kind: WarningCoerced, RowIndex: 1, ColumnIndex: 1, ColumnHeader: "Rate", CellRef: "B2", Message: `text "12.5%" read as number 0.125`},
+	}, report.Warnings)
+
+	// Without CoerceNumbers, the text is an unmarshal error
+	_, err = ReadBinary[*strictNumberTmp](data)
+	var fe FieldError
+	equal(t, true, errors.As(err, &fe))
+}
+
+func TestCoerceNumber(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		kind     reflect.Kind
+		expected string
+		ok       bool
+	}{
+		{"1,234,567", reflect.Int, "1234567", true},
+		{"-1,234.25", reflect.Float64, "-1234.25", true},
+		{" 50 % ", reflect.Float32, "0.5", true},
+		{"1,234%", reflect.Float64, "12.34", true},
+		{"50%", reflect.Int, "", false},
+		{"12,34", reflect.Int, "", false},
+		{"1234", reflect.Int, "", false},
+		{"abc%", reflect.Float64, "", false},
+	} {
+		value, ok := coerceNumber(c.value, c.kind)
+		equal(t, c.expected, value)
+		equal(t, c.ok, ok)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"time"
+)
+
+// ColumnSpec is a column of WriteConfig.ColumnLayout.
+type ColumnSpec struct {
+	// The tag name of the field written, or the field name of fields without tag.
+	Tag string
+	// The header text, "" for the tag name.
+	Header string
+	// The column width in characters, 0 for the default width.
+	// The backend must implement ColumnWidthSetter.
+	Width float64
+	// The number format of the cells, e.g. "#,##0.00" or "yyyy-mm-dd", "" for the format of the value.
+	Format string
+}
+
+// arrangeColumns returns the columns in the order of layout with its header, width and format,
+// the columns are returned as they are for a nil layout
+func arrangeColumns(columns []writeColumn, layout []ColumnSpec) []writeColumn {
+	if layout == nil {
+		return columns
+	}
+	arranged := make([]writeColumn, 0, len(layout))
+	for _, spec := range layout {
+		for _, col := range columns {
+			if col.header == spec.Tag {
+				col.title, col.width, col.format = spec.Header, spec.Width, spec.Format
+				arranged = append(arranged, col)
+				break
+			}
+		}
+	}
+	return arranged
+}
+
+// checkColumnLayout returns an error if a column of WriteConfig.ColumnLayout isn't written by layout
+func checkColumnLayout(layout *sheetLayout, wc *WriteConfig) error {
+	for _, spec := range wc.ColumnLayout {
+		found := false
+		for _, col := range layout.columns {
+			found = found || col.header == spec.Tag
+		}
+		if !found && wc.includes(spec.Tag) {
+			return fmt.Errorf("exl: column layout: no field tagged %q", spec.Tag)
+		}
+	}
+	return nil
+}
+
+// setColumnWidths sets the widths of columns of WriteConfig.ColumnLayout,
+// the first column being at column index offset
+func setColumnWidths(book Spreadsheet, sheet, offset int, columns []writeColumn) error {
+	for i, col := range columns {
+		if col.width <= 0 {
+			continue
+		}
+		setter, ok := book.(ColumnWidthSetter)
+		if !ok {
+			return ErrUnsupported
+		}
+		if err := setter.SetColumnWidth(sheet, offset+i, col.width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatValue returns the cell of value displayed with the number format of WriteConfig.ColumnLayout
+func formatValue(value any, format string, wc *WriteConfig) any {
+	if t, ok := value.(time.Time); ok {
+		if t.IsZero() {
+			return value
+		}
+		return timeCell(t, format, wc.Date1904)
+	}
+	c := NewCell(value)
+	if c.Value == "" {
+		return c
+	}
+	c.NumFmt = format
+	return c
+}
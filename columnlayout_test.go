@@ -0,0 +1,63 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type columnLayoutTmp struct {
+	ID      int       `excel:"ID"`
+	Name    string    `excel:"Name"`
+	Price   float64   `excel:"Price"`
+	Created time.Time `excel:"Created"`
+}
+
+func (*columnLayoutTmp) WriteConfigure(*WriteConfig) {}
+
+func TestWriteColumnLayout(t *testing.T) {
+	created := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	ts := []*columnLayoutTmp{{1, "a", 1234.5, created}}
+	wc := newWriteConfig[*columnLayoutTmp]()
+	wc.ColumnLayout = []ColumnSpec{
+		{Tag: "Price", Header: "Amount", Width: 12, Format: "#,##0.00"},
+		{Tag: "Name"},
+		{Tag: "Created", Format: "yyyy-mm-dd"},
+	}
+
+	plan, err := PlanWrite(ts, wc)
+	equal(t, nil, err)
+	equal(t, []string{"Amount", "Name", "Created"}, plan.Sheets[0].Header)
+	equal(t, []float64{12}, plan.Sheets[0].ColumnWidths)
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteToConfig(buf, ts, wc))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	sheet := f.Sheets[0]
+	equal(t, 12.0, *sheet.Cols.FindColByIndex(1).Width)
+	price, _ := sheet.Cell(1, 0)
+	equal(t, "1234.5", price.Value)
+	equal(t, "#,##0.00", price.NumFmt)
+	name, _ := sheet.Cell(1, 1)
+	equal(t, "a", name.Value)
+	day, _ := sheet.Cell(1, 2)
+	equal(t, "yyyy-mm-dd", day.NumFmt)
+
+	wc.ColumnLayout = []ColumnSpec{{Tag: "Missing"}}
+	_, err = PlanWrite(ts, wc)
+	equal(t, true, err != nil)
+}
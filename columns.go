@@ -0,0 +1,40 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "io"
+
+// ReadColumns binds only the columns tagged with one of tags to `T`,
+// e.g. when a wide type serves several narrow use cases.
+// The other tagged fields keep their zero value and their cells aren't unmarshalled,
+// so their errors aren't reported. Their columns are neither reported as unknown.
+// rc replaces the read config of T, nil to use it.
+func ReadColumns[T ReadConfigurator](reader io.Reader, rc *ReadConfig, tags []string) ([]T, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		rc = newReadConfig[T]()
+	} else {
+		rc = rc.Clone()
+	}
+	rc.columns = make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		rc.columns[tag] = true
+	}
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return nil, err
+	}
+	return readSpreadsheet[T](book, rc, nil, nil, nil)
+}
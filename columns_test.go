@@ -0,0 +1,52 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+)
+
+type projectTmp struct {
+	ID    int    `excel:"ID"`
+	Name  string `excel:"Name"`
+	Age   int    `excel:"Age"`
+	Email string `excel:"Email"`
+}
+
+func (*projectTmp) ReadConfigure(*ReadConfig) {}
+
+func TestReadColumns(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"ID", "Name", "Age", "Email"},
+		{"1", "a", "x", "a@example.com"},
+		{"2", "b", "y", "b@example.com"},
+	})
+	data := buf.Bytes()
+
+	// The invalid ages aren't unmarshalled
+	ts, err := ReadColumns[*projectTmp](bytes.NewReader(data), nil, []string{"ID", "Email"})
+	equal(t, nil, err)
+	equal(t, []*projectTmp{{ID: 1, Email: "a@example.com"}, {ID: 2, Email: "b@example.com"}}, ts)
+
+	_, err = ReadColumns[*projectTmp](bytes.NewReader(data), nil, []string{"ID", "Age"})
+	equal(t, true, err != nil)
+
+	// The projected out columns aren't unknown, and rc isn't changed
+	rc := newReadConfig[*projectTmp]()
+	rc.SkipUnknownColumns = false
+	ts, err = ReadColumns[*projectTmp](bytes.NewReader(data), rc, []string{"Name"})
+	equal(t, nil, err)
+	equal(t, []*projectTmp{{Name: "a"}, {Name: "b"}}, ts)
+	equal(t, true, rc.columns == nil)
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// CombineFunc sets the field dest from the cells of the columns combined by the combine tag option,
+// e.g. `excel:"Name,combine=First Name|Last Name"`, in the order of the option,
+// with empty cells for missing columns, see ReadConfig.Combiners.
+type CombineFunc func(dest reflect.Value, cells []*xlsx.Cell, params *ExcelUnmarshalParameters) error
+
+// combinedField is a field bound from several columns, see the combine tag option
+type combinedField struct {
+	// The column indexes in the order of the option, -1 for missing columns
+	columns []int
+	combine CombineFunc
+}
+
+// combineHeaders returns the headers of the columns combined into the field tagged with opts, nil if none
+func combineHeaders(opts tagOptions) []string {
+	headers, ok := opts.Value("combine")
+	if !ok || headers == "" {
+		return nil
+	}
+	return strings.Split(headers, "|")
+}
+
+// combinedColumns returns the unmarshalling info of the columns of headers combined into fields of typ by column index.
+// The first column present of a field binds it, the others are only read.
+func combinedColumns(typ reflect.Type, headers []string, rc *ReadConfig) map[int]fieldInfo {
+	var columns map[int]fieldInfo
+	val := reflect.New(typ).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		name, opts := parseTag(typ.Field(i).Tag.Get(rc.TagName))
+		combined := combineHeaders(opts)
+		if combined == nil || rc.columns != nil && !rc.columns[name] {
+			continue
+		}
+		cf := &combinedField{columns: make([]int, len(combined)), combine: rc.Combiners[name]}
+		if cf.combine == nil {
+			cf.combine = joinCombiner(GetUnmarshalFunc(val.Field(i)))
+		}
+		anchor := -1
+		for j, header := range combined {
+			cf.columns[j] = indexOf(headers, header)
+			if cf.columns[j] >= 0 && (anchor < 0 || cf.columns[j] < anchor) {
+				anchor = cf.columns[j]
+			}
+		}
+		if anchor < 0 || cf.combine == nil {
+			continue
+		}
+		if columns == nil {
+			columns = make(map[int]fieldInfo)
+		}
+		for _, col := range cf.columns {
+			if col >= 0 {
+				columns[col] = fieldInfo{reflectFieldIndex: i, header: headers[col], combinePart: true}
+			}
+		}
+		columns[anchor] = fieldInfo{reflectFieldIndex: i, header: headers[anchor], combined: cf}
+	}
+	return columns
+}
+
+// indexOf returns the index of the first value in values, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// bind combines the cells of row into dest
+func (cf *combinedField) bind(dest reflect.Value, row *Row, params *ExcelUnmarshalParameters) error {
+	cells := make([]*xlsx.Cell, len(cf.columns))
+	for i, col := range cf.columns {
+		if col >= 0 {
+			cells[i] = row.cellXLSX(col)
+		} else {
+			cells[i] = StringCell("").XLSX()
+		}
+	}
+	return cf.combine(dest, cells, params)
+}
+
+// joinCombiner returns the default CombineFunc, unmarshalling the non-empty cell values joined by spaces,
+// e.g. "2024-01-02 10:30" read with ReadConfig.FallbackDateFormats
+func joinCombiner(unmarshal UnmarshalExcelFunc) CombineFunc {
+	if unmarshal == nil {
+		return nil
+	}
+	return func(dest reflect.Value, cells []*xlsx.Cell, params *ExcelUnmarshalParameters) error {
+		values := make([]string, 0, len(cells))
+		for _, c := range cells {
+			if v := strings.TrimSpace(c.Value); v != "" {
+				values = append(values, v)
+			}
+		}
+		return unmarshal(dest, StringCell(strings.Join(values, " ")).XLSX(), params)
+	}
+}
+
+// CombineDateTime is a CombineFunc for time.Time fields combining a date column and a time column,
+// e.g. `excel:"Start,combine=Date|Time"`. Both may be date cells, serial numbers or texts,
+// times like "10:30" or "10:30:00".
+func CombineDateTime(dest reflect.Value, cells []*xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	if len(cells) != 2 {
+		return fmt.Errorf("exl: combining date and time: %d columns instead of 2", len(cells))
+	}
+	if dest.Kind() == reflect.Ptr {
+		if cells[0].Value == "" && cells[1].Value == "" {
+			return nil
+		}
+		if dest.IsNil() {
+			dest.Set(reflect.New(dest.Type().Elem()))
+		}
+		dest = dest.Elem()
+	}
+	day, err := parseTimeCell(cells[0], params, time.UTC)
+	if err != nil {
+		return err
+	}
+	clock, err := timeOfDay(cells[1], params)
+	if err != nil {
+		return err
+	}
+	if !day.IsZero() || clock != 0 {
+		day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()).Add(clock)
+	}
+	dest.Set(reflect.ValueOf(day))
+	return nil
+}
+
+// timeOfDay returns the time of day of cell, a fraction of a day, date cell or text like "10:30"
+func timeOfDay(cell *xlsx.Cell, params *ExcelUnmarshalParameters) (time.Duration, error) {
+	value := strings.TrimSpace(cell.Value)
+	if value == "" {
+		return 0, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		_, frac := math.Modf(f)
+		// Excel stores times with millisecond precision
+		return (time.Duration(frac * float64(24*time.Hour))).Round(time.Millisecond), nil
+	}
+	for _, layout := range append([]string{"15:04:05", "15:04", "3:04 PM", "3:04:05 PM"}, params.FallbackDateFormats...) {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond()), nil
+		}
+	}
+	return 0, fmt.Errorf("error parsing cell as time of day: %w", ErrNoRecognizedFormat)
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type combineTmp struct {
+	ID    int       `excel:"ID"`
+	Name  string    `excel:"Name,combine=First Name|Last Name"`
+	Start time.Time `excel:"Start,combine=Date|Time"`
+}
+
+func (*combineTmp) ReadConfigure(rc *ReadConfig) {
+	rc.Combiners = map[string]CombineFunc{"Start": CombineDateTime}
+}
+
+func (*combineTmp) WriteConfigure(*WriteConfig) {}
+
+func TestReadCombine(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Last Name", "ID", "First Name", "Date", "Time"},
+		{"Doe", "1", "Jane", "2024-01-02T00:00:00Z", "10:30"},
+		{"Roe", "2", "", "", ""},
+	})
+	ts, err := ReadBinary[*combineTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*combineTmp{
+		{1, "Jane Doe", time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC)},
+		{2, "Roe", time.Time{}},
+	}, ts)
+
+	// Invalid times are reported at the leftmost column of the field
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"ID", "Date", "Time"}, {"1", "2024-01-02T00:00:00Z", "late"}})
+	_, err = ReadBinary[*combineTmp](buf.Bytes())
+	equal(t, true, err != nil)
+	equal(t, "Date", err.(FieldError).ColumnHeader)
+}
+
+func TestTimeOfDay(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"0.4375":   10*time.Hour + 30*time.Minute,
+		"10:30:15": 10*time.Hour + 30*time.Minute + 15*time.Second,
+		"3:04 PM":  15*time.Hour + 4*time.Minute,
+		"":         0,
+	} {
+		d, err := timeOfDay(&xlsx.Cell{Value: value}, &ExcelUnmarshalParameters{})
+		equal(t, nil, err)
+		equal(t, expected, d)
+	}
+}
+
+func TestWriteCombine(t *testing.T) {
+	ts := []*combineTmp{{1, "Jane Doe", time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC)}}
+	plan, err := PlanWrite(ts, nil)
+	equal(t, nil, err)
+	equal(t, []string{"ID", "First Name", "Last Name", "Date", "Time"}, plan.Sheets[0].Header)
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	read, err := ReadBinary[*combineTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, ts, read)
+}
@@ -0,0 +1,235 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"sync"
+)
+
+// The functions changing the package-wide defaults, see SetDefaultReadConfig and SetDefaultWriteConfig
+var (
+	defaultsMu          sync.RWMutex
+	readConfigDefaults  func(rc *ReadConfig)
+	writeConfigDefaults func(wc *WriteConfig)
+)
+
+// SetDefaultReadConfig changes the package-wide defaults of ReadConfig with configure, e.g. the TagName,
+// applied before the ReadConfigure method of types and wherever a nil config stands for the defaults.
+// nil restores the built-in defaults. It may be called while other goroutines are reading.
+func SetDefaultReadConfig(configure func(rc *ReadConfig)) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	readConfigDefaults = configure
+}
+
+// SetDefaultWriteConfig changes the package-wide defaults of WriteConfig with configure,
+// applied before the WriteConfigure method of types like SetDefaultReadConfig.
+// nil restores the built-in defaults. It may be called while other goroutines are writing.
+func SetDefaultWriteConfig(configure func(wc *WriteConfig)) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	writeConfigDefaults = configure
+}
+
+// DefaultReadConfig returns the default ReadConfig, see SetDefaultReadConfig,
+// e.g. as base config shared by several types, see ReadConfig.Inherit.
+func DefaultReadConfig() *ReadConfig {
+	return defaultReadConfig()
+}
+
+// DefaultWriteConfig returns the default WriteConfig, see SetDefaultWriteConfig,
+// e.g. as base config shared by several types, see WriteConfig.Inherit.
+func DefaultWriteConfig() *WriteConfig {
+	return defaultWriteConfig()
+}
+
+// Clone returns a copy of rc with copies of its slices and maps.
+// Functions and the values of pointers are shared.
+func (rc *ReadConfig) Clone() *ReadConfig {
+	c := *rc
+	cloneFields(reflect.ValueOf(&c).Elem())
+	return &c
+}
+
+// Inherit sets rc to a clone of base, keeping the options of the Sheet tag of the type configured,
+// e.g. to start the config of a type from a base config shared by a family of types, only setting the differences:
+//
+//	func (*Order) ReadConfigure(rc *exl.ReadConfig) {
+//		rc.Inherit(base)
+//		rc.SheetIndex = 1
+//	}
+func (rc *ReadConfig) Inherit(base *ReadConfig) {
+	tag, columns := rc.sheetTag, rc.columns
+	*rc = *base.Clone()
+	rc.columns = columns
+	applySheetOptions(tag, rc)
+}
+
+// Clone returns a copy of wc with copies of its slices and maps, see ReadConfig.Clone.
+func (wc *WriteConfig) Clone() *WriteConfig {
+	c := *wc
+	cloneFields(reflect.ValueOf(&c).Elem())
+	return &c
+}
+
+// Inherit sets wc to a clone of base, keeping the options of the Sheet tag of the type configured,
+// see ReadConfig.Inherit.
+func (wc *WriteConfig) Inherit(base *WriteConfig) {
+	tag := wc.sheetTag
+	*wc = *base.Clone()
+	applySheetWriteOptions(tag, wc)
+}
+
+// cloneFields replaces the slices and maps of the struct v by copies
+func cloneFields(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() || f.IsZero() {
+			continue
+		}
+		switch f.Kind() {
+		case reflect.Slice:
+			c := reflect.MakeSlice(f.Type(), f.Len(), f.Len())
+			reflect.Copy(c, f)
+			f.Set(c)
+		case reflect.Map:
+			c := reflect.MakeMapWithSize(f.Type(), f.Len())
+			for iter := f.MapRange(); iter.Next(); {
+				c.SetMapIndex(iter.Key(), iter.Value())
+			}
+			f.Set(c)
+		case reflect.Struct:
+			cloneFields(f)
+		}
+	}
+}
+
+func readDefaults() func(rc *ReadConfig) {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return readConfigDefaults
+}
+
+func writeDefaults() func(wc *WriteConfig) {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return writeConfigDefaults
+}
+
+// ErrInvalidConfig is matched by the errors of ReadConfig.Validate and WriteConfig.Validate.
+var ErrInvalidConfig = errors.New("exl: invalid config")
+
+// ConfigError describes an inconsistent setting of a ReadConfig or WriteConfig,
+// it matches ErrInvalidConfig with errors.Is.
+type ConfigError struct {
+	// The name of the config field.
+	Field  string
+	Reason string
+	// The error formerly returned for the setting, still matched by errors.Is,
+	// e.g. ErrHeaderRowIndexOutOfRange.
+	err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrInvalidConfig, e.Field, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidConfig or the error formerly returned for the setting.
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrInvalidConfig || (e.err != nil && target == e.err)
+}
+
+// Validate returns a ConfigError for the first inconsistent setting of rc.
+// It is called before reading.
+func (rc *ReadConfig) Validate() error {
+	switch {
+	case rc.tagErr != nil:
+		return rc.tagErr
+	case rc.TagName == "":
+		return &ConfigError{Field: "TagName", Reason: "is empty"}
+	case rc.SheetIndex < 0:
+		return &ConfigError{Field: "SheetIndex", Reason: fmt.Sprintf("%d is negative", rc.SheetIndex), err: ErrSheetIndexOutOfRange}
+	case rc.HeaderRowIndex < 0:
+		return &ConfigError{Field: "HeaderRowIndex", Reason: fmt.Sprintf("%d is negative", rc.HeaderRowIndex), err: ErrHeaderRowIndexOutOfRange}
+	case rc.DataStartRowIndex < 0:
+		return &ConfigError{Field: "DataStartRowIndex", Reason: fmt.Sprintf("%d is negative", rc.DataStartRowIndex), err: ErrDataStartRowIndexOutOfRange}
+	case rc.DataStartRelative && rc.DataStartRowIndex == 0:
+		return &ConfigError{Field: "DataStartRowIndex", Reason: "0 is not below HeaderRowIndex in relative mode", err: ErrDataStartRowIndexOutOfRange}
+	case !rc.DataStartRelative && rc.DataStartRowIndex <= rc.HeaderRowIndex:
+		return &ConfigError{Field: "DataStartRowIndex", Reason: fmt.Sprintf("%d is not below HeaderRowIndex %d", rc.DataStartRowIndex, rc.HeaderRowIndex), err: ErrDataStartRowIndexOutOfRange}
+	case rc.UnmarshalErrorHandling > UnmarshalErrorCollect:
+		return &ConfigError{Field: "UnmarshalErrorHandling", Reason: fmt.Sprintf("%d is unknown", rc.UnmarshalErrorHandling)}
+	case rc.BlankHeaders > BlankHeaderLetter:
+		return &ConfigError{Field: "BlankHeaders", Reason: fmt.Sprintf("%d is unknown", rc.BlankHeaders)}
+	case rc.SkipFooterRows < 0:
+		return &ConfigError{Field: "SkipFooterRows", Reason: fmt.Sprintf("%d is negative", rc.SkipFooterRows)}
+	case rc.RecordBlockSize < 0:
+		return &ConfigError{Field: "RecordBlockSize", Reason: fmt.Sprintf("%d is negative", rc.RecordBlockSize)}
+	case rc.MaxDownloadSize < 0:
+		return &ConfigError{Field: "MaxDownloadSize", Reason: fmt.Sprintf("%d is negative", rc.MaxDownloadSize)}
+	case rc.DownloadRetries < 0:
+		return &ConfigError{Field: "DownloadRetries", Reason: fmt.Sprintf("%d is negative", rc.DownloadRetries)}
+	case rc.MinRows < 0:
+		return &ConfigError{Field: "MinRows", Reason: fmt.Sprintf("%d is negative", rc.MinRows)}
+	case rc.Backend == nil:
+		return &ConfigError{Field: "Backend", Reason: "is nil"}
+	}
+	for unit, c := range rc.UnitConversions {
+		if c.Factor == 0 {
+			return &ConfigError{Field: "UnitConversions", Reason: fmt.Sprintf("%q has no factor", unit)}
+		}
+	}
+	for _, r := range rc.RequiredRules {
+		if r.Column == "" || r.When == "" {
+			return &ConfigError{Field: "RequiredRules", Reason: fmt.Sprintf("rule %q when %q needs both columns", r.Column, r.When)}
+		}
+	}
+	return nil
+}
+
+// Validate returns a ConfigError for the first inconsistent setting of wc.
+// It is called before writing.
+func (wc *WriteConfig) Validate() error {
+	switch {
+	case wc.TagName == "":
+		return &ConfigError{Field: "TagName", Reason: "is empty"}
+	case wc.SheetName == "":
+		return &ConfigError{Field: "SheetName", Reason: "is empty"}
+	case wc.SheetPosition < -1:
+		return &ConfigError{Field: "SheetPosition", Reason: fmt.Sprintf("%d is neither a position nor -1", wc.SheetPosition)}
+	case wc.LimitHandling > LimitTruncate:
+		return &ConfigError{Field: "LimitHandling", Reason: fmt.Sprintf("%d is unknown", wc.LimitHandling)}
+	case wc.CompressionLevel < 0 || wc.CompressionLevel > 9:
+		return &ConfigError{Field: "CompressionLevel", Reason: fmt.Sprintf("%d is not between 0 and 9", wc.CompressionLevel)}
+	case wc.Overwrite > OverwriteBackup:
+		return &ConfigError{Field: "Overwrite", Reason: fmt.Sprintf("%d is unknown", wc.Overwrite)}
+	case wc.Backend == nil:
+		return &ConfigError{Field: "Backend", Reason: "is nil"}
+	case !wc.BoolFormat.isZero() && (wc.BoolFormat.True == "" || wc.BoolFormat.False == "" || wc.BoolFormat.True == wc.BoolFormat.False):
+		return &ConfigError{Field: "BoolFormat", Reason: fmt.Sprintf("%q and %q are no distinct texts", wc.BoolFormat.True, wc.BoolFormat.False)}
+	}
+	for _, pattern := range wc.StoredParts {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return &ConfigError{Field: "StoredParts", Reason: fmt.Sprintf("%q is no valid pattern", pattern), err: err}
+		}
+	}
+	if wc.TabColor != "" {
+		if _, err := argbColor(wc.TabColor); err != nil {
+			return &ConfigError{Field: "TabColor", Reason: fmt.Sprintf("%q is no hex RGB color", wc.TabColor), err: ErrInvalidColor}
+		}
+	}
+	return nil
+}
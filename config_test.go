@@ -0,0 +1,185 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type configHeaderBelowDataTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*configHeaderBelowDataTmp) ReadConfigure(rc *ReadConfig) {
+	rc.HeaderRowIndex = 2
+	rc.DataStartRowIndex = 2
+}
+
+type configNoSheetNameTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*configNoSheetNameTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = ""
+}
+
+func TestReadConfigValidate(t *testing.T) {
+	equal(t, nil, newReadConfig[*readTmp]().Validate())
+	for _, tc := range []struct {
+		configure func(rc *ReadConfig)
+		message   string
+	}{
+		{func(rc *ReadConfig) { rc.TagName = "" }, "exl: invalid config: TagName is empty"},
+		{func(rc *ReadConfig) { rc.SheetIndex = -1 }, "exl: invalid config: SheetIndex -1 is negative"},
+		{func(rc *ReadConfig) { rc.DataStartRowIndex = 0 }, "exl: invalid config: DataStartRowIndex 0 is not below HeaderRowIndex 0"},
+		{func(rc *ReadConfig) { rc.DataStartRowIndex, rc.DataStartRelative = 0, true }, "exl: invalid config: DataStartRowIndex 0 is not below HeaderRowIndex in relative mode"},
+		{func(rc *ReadConfig) { rc.UnmarshalErrorHandling = 9 }, "exl: invalid config: UnmarshalErrorHandling 9 is unknown"},
+		{func(rc *ReadConfig) { rc.BlankHeaders = 9 }, "exl: invalid config: BlankHeaders 9 is unknown"},
+		{func(rc *ReadConfig) { rc.SkipFooterRows = -2 }, "exl: invalid config: SkipFooterRows -2 is negative"},
+		{func(rc *ReadConfig) { rc.Backend = nil }, "exl: invalid config: Backend is nil"},
+		{func(rc *ReadConfig) { rc.UnitConversions = map[string]UnitConversion{"g": {Unit: "kg"}} }, `exl: invalid config: UnitConversions "g" has no factor`},
+		{func(rc *ReadConfig) { rc.RequiredRules = []RequiredRule{{Column: "State"}} }, `exl: invalid config: RequiredRules rule "State" when "" needs both columns`},
+	} {
+		rc := newReadConfig[*readTmp]()
+		tc.configure(rc)
+		err := rc.Validate()
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("expected ErrInvalidConfig, got %v", err)
+		}
+		equal(t, tc.message, err.Error())
+	}
+}
+
+func TestWriteConfigValidate(t *testing.T) {
+	equal(t, nil, newWriteConfig[*writeTmp]().Validate())
+	for _, tc := range []struct {
+		configure func(wc *WriteConfig)
+		message   string
+	}{
+		{func(wc *WriteConfig) { wc.TagName = "" }, "exl: invalid config: TagName is empty"},
+		{func(wc *WriteConfig) { wc.SheetName = "" }, "exl: invalid config: SheetName is empty"},
+		{func(wc *WriteConfig) { wc.SheetPosition = -2 }, "exl: invalid config: SheetPosition -2 is neither a position nor -1"},
+		{func(wc *WriteConfig) { wc.LimitHandling = 9 }, "exl: invalid config: LimitHandling 9 is unknown"},
+		{func(wc *WriteConfig) { wc.CompressionLevel = 10 }, "exl: invalid config: CompressionLevel 10 is not between 0 and 9"},
+		{func(wc *WriteConfig) { wc.Overwrite = 3 }, "exl: invalid config: Overwrite 3 is unknown"},
+		{func(wc *WriteConfig) { wc.StoredParts = []string{"xl/["} }, `exl: invalid config: StoredParts "xl/[" is no valid pattern`},
+		{func(wc *WriteConfig) { wc.TabColor = "red" }, `exl: invalid config: TabColor "red" is no hex RGB color`},
+		{func(wc *WriteConfig) { wc.BoolFormat = BoolFormat{True: "Y", False: "Y"} }, `exl: invalid config: BoolFormat "Y" and "Y" are no distinct texts`},
+	} {
+		wc := newWriteConfig[*writeTmp]()
+		tc.configure(wc)
+		err := wc.Validate()
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("expected ErrInvalidConfig, got %v", err)
+		}
+		equal(t, tc.message, err.Error())
+	}
+}
+
+func TestInvalidConfigRejected(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*configNoSheetNameTmp{{"a"}}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+	_ = WriteTo(buf, []*writeTmp{{}})
+	if _, err := ReadBinary[*configHeaderBelowDataTmp](buf.Bytes()); !errors.Is(err, ErrDataStartRowIndexOutOfRange) || !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
+
+type configDefaultsTmp struct {
+	Name string `xlsx:"Name"`
+}
+
+func (*configDefaultsTmp) ReadConfigure(*ReadConfig)   {}
+func (*configDefaultsTmp) WriteConfigure(*WriteConfig) {}
+
+func TestSetDefaultConfig(t *testing.T) {
+	SetDefaultReadConfig(func(rc *ReadConfig) {
+		rc.TagName = "xlsx"
+		rc.TrimSpace = true
+	})
+	SetDefaultWriteConfig(func(wc *WriteConfig) { wc.TagName = "xlsx" })
+	defer SetDefaultReadConfig(nil)
+	defer SetDefaultWriteConfig(nil)
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*configDefaultsTmp{{" a "}}))
+	ts, err := ReadBinary[*configDefaultsTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*configDefaultsTmp{{"a"}}, ts)
+	// The ReadConfigure method of a type still overrides the defaults
+	equal(t, "xlsx", newReadConfig[*configDefaultsTmp]().TagName)
+	equal(t, 2, newReadConfig[*configHeaderBelowDataTmp]().HeaderRowIndex)
+
+	SetDefaultReadConfig(nil)
+	equal(t, "excel", newReadConfig[*configDefaultsTmp]().TagName)
+}
+
+var configBase = func() *ReadConfig {
+	rc := DefaultReadConfig()
+	rc.TrimSpace = true
+	rc.FallbackDateFormats = []string{"2006-01-02"}
+	return rc
+}()
+
+type configFamilyTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*configFamilyTmp) ReadConfigure(rc *ReadConfig) {
+	rc.Inherit(configBase)
+	rc.FallbackDateFormats = append(rc.FallbackDateFormats, "02.01.2006")
+}
+
+type configTaggedTmp struct {
+	Sheet `exl:"sheet=Orders,header=2"`
+	Name  string `excel:"Name"`
+}
+
+func (*configTaggedTmp) ReadConfigure(rc *ReadConfig)   { rc.Inherit(configBase) }
+func (*configTaggedTmp) WriteConfigure(wc *WriteConfig) { wc.Inherit(DefaultWriteConfig()) }
+
+type configBadTagTmp struct {
+	Sheet `exl:"header=x"`
+	Name  string `excel:"Name"`
+}
+
+func (*configBadTagTmp) ReadConfigure(rc *ReadConfig) { rc.Inherit(configBase) }
+
+func TestConfigInherit(t *testing.T) {
+	rc := newReadConfig[*configTaggedTmp]()
+	equal(t, true, rc.TrimSpace)
+	equal(t, 2, rc.HeaderRowIndex)
+	equal(t, 3, rc.DataStartRowIndex)
+	equal(t, "Orders", newWriteConfig[*configTaggedTmp]().SheetName)
+	equal(t, true, errors.Is(newReadConfig[*configBadTagTmp]().Validate(), ErrInvalidConfig))
+}
+
+func TestConfigClone(t *testing.T) {
+	rc := newReadConfig[*configFamilyTmp]()
+	equal(t, true, rc.TrimSpace)
+	equal(t, "excel", rc.TagName)
+	equal(t, []string{"2006-01-02", "02.01.2006"}, rc.FallbackDateFormats)
+	// The base config isn't modified
+	rc.FallbackDateFormats[0] = "2006/01/02"
+	equal(t, []string{"2006-01-02"}, configBase.FallbackDateFormats)
+
+	wc := DefaultWriteConfig()
+	wc.Redactors = map[string]Redactor{"all": MaskAll}
+	c := wc.Clone()
+	c.Redactors["last4"] = MaskLast4
+	equal(t, 1, len(wc.Redactors))
+	equal(t, 2, len(c.Redactors))
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Master-detail sheets.
+//
+// A slice field tagged with the sheet option, e.g. `excel:"Order Items,sheet"`,
+// is written to a sheet of its own, named by the tag or the field name.
+// Every detail row starts with a foreign key column linking it to its record.
+// The key is the field tagged with the key option, e.g. `excel:"Order No,key"`,
+// or else a generated record number written in the first column of both sheets,
+// see WriteConfig.KeyColumn.
+
+var (
+	// ErrNoKeyColumn is returned if the key column of a master or detail sheet is missing.
+	ErrNoKeyColumn = errors.New("exl: key column not found")
+	// ErrOrphanDetailRow is returned for detail rows whose key matches no record.
+	ErrOrphanDetailRow = errors.New("exl: detail row without record")
+)
+
+// detailField is a slice field written to a sheet of its own
+type detailField struct {
+	fieldIndex int
+	sheet      string
+	elem       reflect.Type
+}
+
+// detailFields returns the fields of typ tagged with the sheet option
+func detailFields(typ reflect.Type, tagName string) []detailField {
+	var fields []detailField
+	for i := 0; i < typ.NumField(); i++ {
+		fe := typ.Field(i)
+		name, opts := parseTag(fe.Tag.Get(tagName))
+		if !opts.Contains("sheet") || fe.Type.Kind() != reflect.Slice {
+			continue
+		}
+		if name == "" {
+			name = fe.Name
+		}
+		elem := fe.Type.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		fields = append(fields, detailField{fieldIndex: i, sheet: name, elem: elem})
+	}
+	return fields
+}
+
+// keyField returns the index and header of the field tagged with the key option,
+// or -1 and the generated key column header.
+func keyField(typ reflect.Type, tagName, keyColumn string) (int, string) {
+	for i := 0; i < typ.NumField(); i++ {
+		name, opts := parseTag(typ.Field(i).Tag.Get(tagName))
+		if opts.Contains("key") {
+			if name == "" {
+				name = typ.Field(i).Name
+			}
+			return i, name
+		}
+	}
+	return -1, keyColumn
+}
+
+// noColumn reports whether a field is not bound to a column of its own,
+// but to rows or to several columns.
+func noColumn(opts tagOptions) bool {
+	return opts.Contains("children") || opts.Contains("sheet") || opts.Contains("rest")
+}
+
+// appendElem appends the pointer elem to the slice value, dereferencing it for value slices
+func appendElem(slice, elem reflect.Value) {
+	if slice.Type().Elem().Kind() == reflect.Ptr {
+		slice.Set(reflect.Append(slice, elem))
+	} else {
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+}
+
+// writeDetails writes the detail sheets of records, keys holding the key value of each record
+func writeDetails(book Spreadsheet, records []reflect.Value, keys []any, details []detailField, keyHeader string, wc *WriteConfig) error {
+	for _, df := range details {
+		name, err := newSheetName(book, df.sheet, wc.StrictSheetNames)
+		if err != nil {
+			return err
+		}
+		sheet, err := book.AddSheet(name)
+		if err != nil {
+			return err
+		}
+		columns := writeColumns(df.elem, wc)
+		header := make([]any, 0, len(columns)+1)
+		header = append(header, keyHeader)
+		for _, col := range columns {
+			header = append(header, col.header)
+		}
+		if err = addValidations(book, sheet, 1, 1, columns, wc); err != nil {
+			return err
+		}
+		sw := newSheetWriter(book, sheet, wc)
+		if err = sw.append(header, 0); err != nil {
+			return err
+		}
+		for i, rv := range records {
+			items := rv.Field(df.fieldIndex)
+			for j := 0; j < items.Len(); j++ {
+				item := reflect.Indirect(items.Index(j))
+				if !item.IsValid() {
+					continue
+				}
+				values, err := recordValues(item, columns, wc)
+				if err != nil {
+					return err
+				}
+				data := append([]any{keys[i]}, values...)
+				if err = sw.append(data, 0); err != nil {
+					return err
+				}
+			}
+		}
+		sw.countRows()
+	}
+	return nil
+}
+
+// readDetails reads the detail sheets of records, keys holding the key cell value of each record,
+// skipping the detail rows of the records not read, e.g. outside of the page of ReadPage, by skippedKeys
+func readDetails(book Spreadsheet, binder *rowBinder, records []reflect.Value, keys []string, skippedKeys map[string]bool, details []detailField, keyHeader string) error {
+	rc := binder.rc
+	byKey := make(map[string]reflect.Value, len(records))
+	for i, key := range keys {
+		if _, have := byKey[key]; !have {
+			byKey[key] = records[i]
+		}
+	}
+	sheets := book.Sheets()
+	for _, df := range details {
+		sheet := -1
+		for i, name := range sheets {
+			if name == df.sheet || name == SanitizeSheetName(df.sheet) {
+				sheet = i
+			}
+		}
+		if sheet < 0 {
+			return fmt.Errorf("%w: %s", ErrSheetNotFound, df.sheet)
+		}
+		headerRow, err := readRow(book, sheet, rc.HeaderRowIndex)
+		if err != nil {
+			return err
+		}
+		if headerRow == nil {
+			// Empty sheet, no details at all
+			continue
+		}
+		headers := headerNames(headerRow, rc)
+		keyIndex := -1
+		for i, header := range headers {
+			if header == keyHeader {
+				keyIndex = i
+			}
+		}
+		if keyIndex < 0 {
+			return fmt.Errorf("%w: \"%s\" in sheet %s", ErrNoKeyColumn, keyHeader, df.sheet)
+		}
+		detailRC := *rc
+		detailRC.SkipUnknownColumns = true
+		columnFields, err := mapColumns(df.elem, headers, &detailRC, nil, nil)
+		if err != nil {
+			return err
+		}
+		err = book.Rows(sheet, func(row *Row) error {
+			if row.Index < rc.dataStartRowIndex() || row.IsEmpty() {
+				return nil
+			}
+			key := row.Cell(keyIndex).Value
+			record, have := byKey[key]
+			if !have && skippedKeys[key] {
+				return nil
+			}
+			if !have {
+				return fmt.Errorf("%w in sheet %s row %d", ErrOrphanDetailRow, df.sheet, row.Index+1)
+			}
+			val := reflect.New(df.elem)
+			if err := binder.bind(val.Elem(), row, columnFields); err != nil {
+				return err
+			}
+			appendElem(record.Elem().Field(df.fieldIndex), val)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
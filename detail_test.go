@@ -0,0 +1,109 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type (
+	lineTmp struct {
+		SKU string `excel:"SKU"`
+		Qty int    `excel:"Qty"`
+	}
+	masterTmp struct {
+		Customer string     `excel:"Customer"`
+		Lines    []*lineTmp `excel:"Lines,sheet"`
+	}
+	masterKeyTmp struct {
+		No    string    `excel:"Order No,key"`
+		Lines []lineTmp `excel:",sheet"`
+	}
+)
+
+func (*masterTmp) WriteConfigure(_ *WriteConfig)    {}
+func (*masterTmp) ReadConfigure(_ *ReadConfig)      {}
+func (*masterKeyTmp) WriteConfigure(_ *WriteConfig) {}
+func (*masterKeyTmp) ReadConfigure(_ *ReadConfig)   {}
+
+func TestMasterDetail(t *testing.T) {
+	t.Run("generated key", func(t *testing.T) {
+		masters := []*masterTmp{
+			{Customer: "Ann", Lines: []*lineTmp{{"apple", 2}, {"pear", 1}}},
+			{Customer: "Bob"},
+			{Customer: "Cid", Lines: []*lineTmp{{"plum", 5}}},
+		}
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, masters); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		equal(t, 2, len(f.Sheets))
+		equal(t, "Lines", f.Sheets[1].Name)
+		header, _ := f.Sheets[0].Row(0)
+		equal(t, "#", header.GetCell(0).Value)
+		detail, _ := f.Sheets[1].Row(3)
+		equal(t, "3", detail.GetCell(0).Value)
+		equal(t, "plum", detail.GetCell(1).Value)
+
+		read, err := Read[*masterTmp](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, masters, read)
+	})
+	t.Run("key field", func(t *testing.T) {
+		masters := []*masterKeyTmp{
+			{No: "A-1", Lines: []lineTmp{{"apple", 2}}},
+			{No: "A-2", Lines: []lineTmp{{"pear", 1}, {"plum", 5}}},
+		}
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, masters); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		equal(t, "Lines", f.Sheets[1].Name)
+		detail, _ := f.Sheets[1].Row(0)
+		equal(t, "Order No", detail.GetCell(0).Value)
+
+		read, err := Read[*masterKeyTmp](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, masters, read)
+	})
+	t.Run("orphan detail", func(t *testing.T) {
+		f := xlsx.NewFile()
+		writeExcel0(f, [][]string{{"Order No"}, {"A-1"}})
+		lines, _ := f.AddSheet("Lines")
+		appendXLSXRow(lines, NewRow("Order No", "SKU"))
+		appendXLSXRow(lines, NewRow("A-9", "apple"))
+		buf := &bytes.Buffer{}
+		_ = f.Write(buf)
+		_, err := Read[*masterKeyTmp](buf)
+		if !errors.Is(err, ErrOrphanDetailRow) {
+			t.Errorf("expected ErrOrphanDetailRow, got %v", err)
+		}
+	})
+	t.Run("missing key column", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_ = WriteExcelTo(buf, [][]string{{"Customer"}, {"Ann"}})
+		_, err := Read[*masterTmp](buf)
+		if !errors.Is(err, ErrNoKeyColumn) {
+			t.Errorf("expected ErrNoKeyColumn, got %v", err)
+		}
+	})
+}
@@ -12,4 +12,30 @@
 // Package exl
 //
 // Excel binding to struct written in Go.(Only supports Go1.18+)
+//
+// # Round trip
+//
+// Records written with WriteTo and read back with Read are deeply equal,
+// using the same tags and default options apart from ReadConfig.PointerCanNil,
+// for fields of these types and pointers to them, also pointer chains like **int:
+// strings, booleans (also with BoolFormat), integers, floats, time.Time,
+// and types implementing ExcelMarshaler and ExcelUnmarshaler,
+// or encoding.TextMarshaler and encoding.TextUnmarshaler.
+//
+// Cells only keep what is written, so some values can't be told apart when read:
+// nil pointers and pointers to empty strings are written as empty cells, read as nil,
+// and the zero time.Time too, read as the zero time.
+// Times are read in UTC, rounded to the millisecond,
+// leading and trailing spaces are removed with ReadConfig.TrimSpace,
+// and strings can't hold control characters except tab and line breaks.
+//
+// # Concurrency
+//
+// The functions reading and writing workbooks, like Read, ReadBinary, WriteTo and WriteFile,
+// may be called concurrently, also for the same type, as every call builds its own config and workbook.
+// The values passed to them, like the records and the maps and funcs of configs,
+// must not be modified during the call.
+// Register unmarshal funcs with RegisterUnmarshalFunc instead of modifying the deprecated DefaultUnmarshalFuncs.
+//
+// Writer, StreamWriter and PartWriter must not be used concurrently, Workbook may.
 package exl
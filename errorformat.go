@@ -0,0 +1,73 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "fmt"
+
+type (
+	// ErrorFormatter renders read errors, see ReadConfig.ErrorFormatter.
+	// Implementations get the structured error data
+	// and must not call the Error method of the error they format.
+	ErrorFormatter interface {
+		FormatFieldError(e FieldError) string
+		FormatContentError(e ContentError) string
+	}
+	// DefaultErrorFormatter renders English messages.
+	// Embed it to only customize one of the messages.
+	DefaultErrorFormatter struct{}
+	// ErrorFormatterFuncs adapts functions to an ErrorFormatter,
+	// nil functions fall back to DefaultErrorFormatter.
+	ErrorFormatterFuncs struct {
+		FieldError   func(e FieldError) string
+		ContentError func(e ContentError) string
+	}
+)
+
+// FormatFieldError implements ErrorFormatter.
+func (DefaultErrorFormatter) FormatFieldError(e FieldError) string {
+	return fmt.Sprintf("error unmarshalling column \"%s\" in row %d: %s", e.ColumnHeader, e.RowIndex+1, e.Err.Error())
+}
+
+// FormatContentError implements ErrorFormatter.
+// With ReadConfig.GroupErrors, the error groups follow on a line each.
+func (DefaultErrorFormatter) FormatContentError(e ContentError) string {
+	msg := fmt.Sprintf("%d errors reading data from Excel", len(e.FieldErrors))
+	if e.LimitReached {
+		msg = fmt.Sprintf("too many (%d) errors reading data from Excel", len(e.FieldErrors))
+	}
+	if e.TotalErrors > 0 {
+		msg = fmt.Sprintf("%d errors in %d rows reading data from Excel", e.TotalErrors, e.FailedRows)
+		if e.TotalErrors > len(e.FieldErrors) {
+			msg += fmt.Sprintf(", the first %d reported", len(e.FieldErrors))
+		}
+	}
+	if e.grouped {
+		msg += ":\n" + groupsText(e)
+	}
+	return msg
+}
+
+// FormatFieldError implements ErrorFormatter.
+func (f ErrorFormatterFuncs) FormatFieldError(e FieldError) string {
+	if f.FieldError == nil {
+		return DefaultErrorFormatter{}.FormatFieldError(e)
+	}
+	return f.FieldError(e)
+}
+
+// FormatContentError implements ErrorFormatter.
+func (f ErrorFormatterFuncs) FormatContentError(e ContentError) string {
+	if f.ContentError == nil {
+		return DefaultErrorFormatter{}.FormatContentError(e)
+	}
+	return f.ContentError(e)
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type germanErrorTmp struct {
+	Qty int `excel:"Menge"`
+}
+
+func (*germanErrorTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.ErrorFormatter = ErrorFormatterFuncs{
+		FieldError: func(e FieldError) string {
+			return fmt.Sprintf("Ungültiger Wert in Zelle %s (%s)", CellRef(e.RowIndex, e.ColumnIndex), e.ColumnHeader)
+		},
+	}
+}
+
+func TestErrorFormatter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Menge"}, {"apple", "x"}})
+	_, err := Read[*germanErrorTmp](buf)
+	equal(t, "1 errors reading data from Excel", err.Error())
+	var ce ContentError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected ContentError, got %v", err)
+	}
+	equal(t, "Ungültiger Wert in Zelle B2 (Menge)", ce.FieldErrors[0].Error())
+
+	fe := FieldError{RowIndex: 1, ColumnHeader: "Menge", Err: ErrOverflow}
+	equal(t, DefaultErrorFormatter{}.FormatFieldError(fe), fe.Error())
+}
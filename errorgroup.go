@@ -0,0 +1,105 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type (
+	// ErrorGroup is the field errors of one column and error kind, see ContentError.Groups.
+	ErrorGroup struct {
+		ColumnIndex  int
+		ColumnHeader string
+		// The message of the innermost wrapped error, e.g. "invalid syntax".
+		Kind  string
+		Count int
+		// The errors of the first and last row of the group.
+		First FieldError
+		Last  FieldError
+	}
+	// ErrorGroupReport is the JSON serializable form of an ErrorGroup.
+	ErrorGroupReport struct {
+		Column   int    `json:"column"` // 1-based column number
+		Header   string `json:"header"`
+		Kind     string `json:"kind"`
+		Count    int    `json:"count"`
+		FirstRow int    `json:"firstRow"` // 1-based row number
+		LastRow  int    `json:"lastRow"`  // 1-based row number
+		Example  string `json:"example"`  // The message of the first error
+	}
+)
+
+// Groups groups the field errors by column and error kind, in order of their first error,
+// to summarize an error occurring on thousands of rows. See ReadConfig.GroupErrors.
+func (e ContentError) Groups() []ErrorGroup {
+	groups := make([]ErrorGroup, 0)
+	index := map[string]int{}
+	for _, fe := range e.FieldErrors {
+		kind := errorKind(fe.Err)
+		key := fmt.Sprintf("%d\x00%s", fe.ColumnIndex, kind)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ErrorGroup{ColumnIndex: fe.ColumnIndex, ColumnHeader: fe.ColumnHeader, Kind: kind, First: fe})
+		}
+		groups[i].Count++
+		groups[i].Last = fe
+	}
+	return groups
+}
+
+// ToReport returns the serializable form of the group.
+func (g ErrorGroup) ToReport() ErrorGroupReport {
+	return ErrorGroupReport{
+		Column:   g.ColumnIndex + 1,
+		Header:   g.ColumnHeader,
+		Kind:     g.Kind,
+		Count:    g.Count,
+		FirstRow: g.First.RowIndex + 1,
+		LastRow:  g.Last.RowIndex + 1,
+		Example:  g.First.Err.Error(),
+	}
+}
+
+// String implements fmt.Stringer.
+func (g ErrorGroup) String() string {
+	if g.Count == 1 {
+		return fmt.Sprintf("column \"%s\": %s in row %d", g.ColumnHeader, g.Kind, g.First.RowIndex+1)
+	}
+	return fmt.Sprintf("column \"%s\": %s in %d rows, %d to %d", g.ColumnHeader, g.Kind, g.Count, g.First.RowIndex+1, g.Last.RowIndex+1)
+}
+
+// errorKind returns the message of the innermost error wrapped by err,
+// which doesn't hold the cell value for the errors of the unmarshal funcs
+func errorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+	for next := errors.Unwrap(err); next != nil; next = errors.Unwrap(err) {
+		err = next
+	}
+	return err.Error()
+}
+
+// groupsText returns the groups of e, one per line
+func groupsText(e ContentError) string {
+	groups := e.Groups()
+	lines := make([]string, len(groups))
+	for i, g := range groups {
+		lines[i] = g.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type groupErrorsTmp struct {
+	Qty   int     `excel:"Qty"`
+	Price float64 `excel:"Price"`
+}
+
+func (*groupErrorsTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.MaxUnmarshalErrors = 0
+	rc.GroupErrors = true
+}
+
+type flatErrorsTmp groupErrorsTmp
+
+func (*flatErrorsTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+}
+
+func TestContentErrorGroups(t *testing.T) {
+	rows := [][]string{{"Qty", "Price"}}
+	for i := 0; i < 1000; i++ {
+		rows = append(rows, []string{fmt.Sprintf("n/a %d", i), "1.5"})
+	}
+	rows = append(rows, []string{"99999999999999999999", "x"})
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, rows)
+
+	_, err := ReadBinary[*groupErrorsTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 1002, len(ce.FieldErrors))
+	groups := ce.Groups()
+	equal(t, 3, len(groups))
+	equal(t, "invalid syntax", groups[0].Kind)
+	equal(t, 1000, groups[0].Count)
+	equal(t, 1, groups[0].First.RowIndex)
+	equal(t, 1000, groups[0].Last.RowIndex)
+	equal(t, `column "Qty": invalid syntax in 1000 rows, 2 to 1001`, groups[0].String())
+	equal(t, `column "Qty": value out of range in row 1002`, groups[1].String())
+	equal(t, "Price", groups[2].ColumnHeader)
+	equal(t, "1002 errors reading data from Excel:\n"+
+		"column \"Qty\": invalid syntax in 1000 rows, 2 to 1001\n"+
+		"column \"Qty\": value out of range in row 1002\n"+
+		"column \"Price\": invalid syntax in row 1002", err.Error())
+
+	report := ToReport(err)
+	equal(t, 3, len(report.Errors))
+	equal(t, ErrorGroupReport{Column: 1, Header: "Qty", Kind: "invalid syntax", Count: 1000, FirstRow: 2, LastRow: 1001,
+		Example: `error parsing cell as integer value: strconv.ParseInt: parsing "n/a 0": invalid syntax`}, report.Groups[0])
+
+	// The flat output lists every error
+	_, err = ReadBinary[*flatErrorsTmp](buf.Bytes())
+	equal(t, "too many (10) errors reading data from Excel", err.Error())
+	equal(t, 10, len(ToReport(err).Errors))
+	equal(t, 0, len(ToReport(err).Groups))
+}
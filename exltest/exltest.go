@@ -0,0 +1,256 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exltest provides helpers for testing code using exl:
+// building in-memory workbooks to read, and comparing written workbooks
+// with golden files by content, ignoring volatile zip and XML metadata.
+package exltest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nullcache/exl"
+)
+
+// Update makes AssertGolden write the golden files instead of comparing with them,
+// typically set from a flag of the test binary:
+//
+//	func init() { flag.BoolVar(&exltest.Update, "update", false, "update golden files") }
+var Update bool
+
+// MaxDiffs is the number of differences reported by AssertEqual and AssertGolden.
+var MaxDiffs = 20
+
+type (
+	// Sheet is the content of one sheet of a workbook.
+	Sheet struct {
+		Name string
+		// The rows, cells converted by exl.NewCell, so they can be exl.Cell values too.
+		Rows [][]any
+	}
+)
+
+// Workbook returns the content of an xlsx file holding sheets.
+func Workbook(t testing.TB, sheets ...Sheet) []byte {
+	t.Helper()
+	book := exl.XLSXBackend{}.Create()
+	for _, s := range sheets {
+		sheet, err := book.AddSheet(s.Name)
+		if err != nil {
+			t.Fatalf("exltest: add sheet %s: %v", s.Name, err)
+		}
+		for _, values := range s.Rows {
+			if err = book.AppendRow(sheet, exl.NewRow(values...)); err != nil {
+				t.Fatalf("exltest: append row to sheet %s: %v", s.Name, err)
+			}
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := book.Save(buf); err != nil {
+		t.Fatalf("exltest: save workbook: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// Rows returns the content of an xlsx file holding rows in the single sheet "Sheet1".
+func Rows(t testing.TB, rows ...[]any) []byte {
+	t.Helper()
+	return Workbook(t, Sheet{Name: "Sheet1", Rows: rows})
+}
+
+// AssertEqual fails t if the workbooks want and got differ
+// in sheet names, cell types, values, number formats or formulas.
+// Trailing empty cells and empty rows are ignored.
+func AssertEqual(t testing.TB, want, got []byte) {
+	t.Helper()
+	diffs, err := Diff(want, got)
+	if err != nil {
+		t.Fatalf("exltest: %v", err)
+	}
+	reportDiffs(t, diffs)
+}
+
+// AssertGolden fails t if the workbook got differs from the golden file, see AssertEqual.
+// The golden file is written if Update is set.
+func AssertGolden(t testing.TB, got []byte, golden string) {
+	t.Helper()
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatalf("exltest: %v", err)
+		}
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("exltest: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("exltest: %v, set exltest.Update to create it", err)
+	}
+	diffs, err := Diff(want, got)
+	if err != nil {
+		t.Fatalf("exltest: %v", err)
+	}
+	if len(diffs) > 0 {
+		t.Errorf("workbook differs from golden file %s", golden)
+	}
+	reportDiffs(t, diffs)
+}
+
+func reportDiffs(t testing.TB, diffs []string) {
+	t.Helper()
+	for i, d := range diffs {
+		if i == MaxDiffs {
+			t.Errorf("... and %d more differences", len(diffs)-i)
+			return
+		}
+		t.Error(d)
+	}
+}
+
+// Diff returns the differences between the workbooks want and got, see AssertEqual.
+func Diff(want, got []byte) ([]string, error) {
+	wantSheets, err := contents(want)
+	if err != nil {
+		return nil, fmt.Errorf("open wanted workbook: %w", err)
+	}
+	gotSheets, err := contents(got)
+	if err != nil {
+		return nil, fmt.Errorf("open workbook: %w", err)
+	}
+	wantNames, gotNames := sheetNames(wantSheets), sheetNames(gotSheets)
+	if strings.Join(wantNames, "\x00") != strings.Join(gotNames, "\x00") {
+		return []string{fmt.Sprintf("sheets: want %q, got %q", wantNames, gotNames)}, nil
+	}
+	var diffs []string
+	for i, ws := range wantSheets {
+		gs := gotSheets[i]
+		rows := len(ws.rows)
+		if len(gs.rows) > rows {
+			rows = len(gs.rows)
+		}
+		for r := 0; r < rows; r++ {
+			wr, gr := rowAt(ws.rows, r), rowAt(gs.rows, r)
+			cols := len(wr)
+			if len(gr) > cols {
+				cols = len(gr)
+			}
+			for c := 0; c < cols; c++ {
+				wc, gc := cellAt(wr, c), cellAt(gr, c)
+				if wc != gc {
+					diffs = append(diffs, fmt.Sprintf("%s!%s: want %s, got %s", ws.name, exl.CellRef(r, c), describe(wc), describe(gc)))
+				}
+			}
+		}
+	}
+	return diffs, nil
+}
+
+// sheetContent holds the normalized cells of a sheet by row index
+type sheetContent struct {
+	name string
+	rows [][]exl.Cell
+}
+
+func contents(data []byte) ([]sheetContent, error) {
+	book, err := exl.XLSXBackend{}.Open(data)
+	if err != nil {
+		return nil, err
+	}
+	names := book.Sheets()
+	sheets := make([]sheetContent, 0, len(names))
+	for i, name := range names {
+		s := sheetContent{name: name}
+		err = book.Rows(i, func(row *exl.Row) error {
+			cells := make([]exl.Cell, 0, len(row.Cells))
+			for _, c := range row.Cells {
+				cells = append(cells, normalize(c))
+			}
+			for len(cells) > 0 && cells[len(cells)-1] == (exl.Cell{}) {
+				cells = cells[:len(cells)-1]
+			}
+			for len(s.rows) <= row.Index {
+				s.rows = append(s.rows, nil)
+			}
+			s.rows[row.Index] = cells
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for len(s.rows) > 0 && len(s.rows[len(s.rows)-1]) == 0 {
+			s.rows = s.rows[:len(s.rows)-1]
+		}
+		sheets = append(sheets, s)
+	}
+	return sheets, nil
+}
+
+// normalize makes cells which look the same in a spreadsheet application compare equal
+func normalize(c exl.Cell) exl.Cell {
+	if strings.EqualFold(c.NumFmt, "general") {
+		c.NumFmt = ""
+	}
+	if c.Type == exl.CellTypeString && c.Value == "" && c.Formula == "" {
+		c.Type = exl.CellTypeEmpty
+	}
+	if c.Type == exl.CellTypeEmpty && c.Value == "" && c.Formula == "" {
+		c.NumFmt = ""
+	}
+	return c
+}
+
+func sheetNames(sheets []sheetContent) []string {
+	names := make([]string, 0, len(sheets))
+	for _, s := range sheets {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func rowAt(rows [][]exl.Cell, i int) []exl.Cell {
+	if i < len(rows) {
+		return rows[i]
+	}
+	return nil
+}
+
+func cellAt(cells []exl.Cell, i int) exl.Cell {
+	if i < len(cells) {
+		return cells[i]
+	}
+	return exl.Cell{}
+}
+
+var cellTypes = [...]string{"empty", "string", "number", "bool", "error"}
+
+func describe(c exl.Cell) string {
+	if c == (exl.Cell{}) {
+		return "empty cell"
+	}
+	typ := "unknown"
+	if int(c.Type) < len(cellTypes) {
+		typ = cellTypes[c.Type]
+	}
+	s := fmt.Sprintf("%s %q", typ, c.Value)
+	if c.NumFmt != "" {
+		s += fmt.Sprintf(" format %q", c.NumFmt)
+	}
+	if c.Formula != "" {
+		s += fmt.Sprintf(" formula %q", c.Formula)
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exltest
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nullcache/exl"
+)
+
+type user struct {
+	Name string `excel:"Name"`
+	Age  int    `excel:"Age"`
+}
+
+func (*user) ReadConfigure(_ *exl.ReadConfig)   {}
+func (*user) WriteConfigure(_ *exl.WriteConfig) {}
+
+func TestRowsRead(t *testing.T) {
+	data := Rows(t, []any{"Name", "Age"}, []any{"Ann", 31}, []any{"Bob", 42})
+	users, err := exl.ReadBinary[*user](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*user{{"Ann", 31}, {"Bob", 42}}; !reflect.DeepEqual(want, users) {
+		t.Errorf("want %v, got %v", want, users)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	want := Workbook(t, Sheet{Name: "A", Rows: [][]any{{"x", 1}, {true, exl.TextCell("007")}}})
+	same := Workbook(t, Sheet{Name: "A", Rows: [][]any{{"x", 1, ""}, {true, exl.TextCell("007")}, {}}})
+	diffs, err := Diff(want, same)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 0 {
+		t.Errorf("want no differences, got %q", diffs)
+	}
+
+	other := Workbook(t, Sheet{Name: "A", Rows: [][]any{{"x", 2}, {true, "007"}}})
+	diffs, _ = Diff(want, other)
+	expected := []string{
+		`A!B1: want number "1", got number "2"`,
+		`A!B2: want string "007" format "@", got string "007"`,
+	}
+	if !reflect.DeepEqual(expected, diffs) {
+		t.Errorf("want %q, got %q", expected, diffs)
+	}
+
+	renamed := Workbook(t, Sheet{Name: "B", Rows: [][]any{{"x", 1}}})
+	diffs, _ = Diff(want, renamed)
+	if expected := []string{`sheets: want ["A"], got ["B"]`}; !reflect.DeepEqual(expected, diffs) {
+		t.Errorf("want %q, got %q", expected, diffs)
+	}
+
+	if _, err = Diff(want, []byte("no excel")); err == nil {
+		t.Error("want error for invalid workbook")
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := exl.WriteTo(buf, []*user{{"Ann", 31}}); err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(t.TempDir(), "testdata", "users.xlsx")
+	Update = true
+	AssertGolden(t, buf.Bytes(), golden)
+	Update = false
+
+	// Written again, the zip metadata differs but the content does not
+	buf.Reset()
+	if err := exl.WriteTo(buf, []*user{{"Ann", 31}}); err != nil {
+		t.Fatal(err)
+	}
+	AssertGolden(t, buf.Bytes(), golden)
+	AssertEqual(t, Rows(t, []any{"Name", "Age"}, []any{"Ann", 31}), buf.Bytes())
+}
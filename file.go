@@ -0,0 +1,192 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// The functions reading and writing files by path are the only ones using package os,
+// everything else works on readers and writers, e.g. for GOOS=js builds without a file system.
+
+// OverwritePolicy configures how WriteFile handles an existing file, see WriteConfig.Overwrite.
+type OverwritePolicy uint8
+
+const (
+	// OverwriteReplace
+	// Replace the existing file
+	OverwriteReplace OverwritePolicy = iota
+	// OverwriteError
+	// Fail with an error matching fs.ErrExist, keeping the existing file
+	OverwriteError
+	// OverwriteBackup
+	// Rename the existing file to the file name with the suffix .bak, replacing an older backup
+	OverwriteBackup
+)
+
+// ReadFile each row bind to `T`
+func ReadFile[T ReadConfigurator](file string, filterFunc ...func(t T) (add bool)) ([]T, error) {
+	if bytes, err := os.ReadFile(file); err != nil {
+		return []T(nil), err
+	} else {
+		return ReadBinary(bytes, filterFunc...)
+	}
+}
+
+// ReadExcel walk func from excel
+func ReadExcel(file string, sheetIndex int, walk func(index int, rows *xlsx.Row)) error {
+	f, err := xlsx.OpenFile(file)
+	if err != nil {
+		return err
+	}
+	if sheetIndex < 0 || sheetIndex > len(f.Sheets)-1 {
+		return ErrSheetIndexOutOfRange
+	}
+	sheet := f.Sheets[sheetIndex]
+	for i := 0; i < sheet.MaxRow; i++ {
+		if row, _ := sheet.Row(i); row != nil {
+			walk(i, row)
+		}
+	}
+	return nil
+}
+
+// WriteFile defines write []T to excel file
+//
+// params: file,excel file full path
+//
+// params: typed parameter T, must be implements exl.Bind
+//
+// An existing file is handled as configured by WriteConfig.Overwrite,
+// set WriteConfig.AtomicWrite to never leave a partial file.
+func WriteFile[T WriteConfigurator](file string, ts []T) error {
+	wc := newWriteConfig[T]()
+	if err := wc.Validate(); err != nil {
+		return err
+	}
+	return createFile(file, wc, func(w io.Writer) error { return writeTo(w, ts, wc, nil) })
+}
+
+// createFile creates file with the content written by write,
+// handling an existing file and writing atomically as configured by wc
+func createFile(file string, wc *WriteConfig, write func(w io.Writer) error) error {
+	_, err := os.Stat(file)
+	exists := err == nil
+	if exists && wc.Overwrite == OverwriteError {
+		return &fs.PathError{Op: "create", Path: file, Err: fs.ErrExist}
+	}
+	if !wc.AtomicWrite {
+		if exists && wc.Overwrite == OverwriteBackup {
+			if err = os.Rename(file, file+".bak"); err != nil {
+				return err
+			}
+		}
+		flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if wc.Overwrite == OverwriteError {
+			flag |= os.O_EXCL
+		}
+		f, err := os.OpenFile(file, flag, 0o666)
+		if err != nil {
+			return err
+		}
+		if err = write(f); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// Removing fails once the temporary file is renamed, and only unlinks it once linked
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	// CreateTemp creates the file readable by the owner only
+	mode := fs.FileMode(0o644)
+	if info, err := os.Stat(file); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	switch wc.Overwrite {
+	case OverwriteError:
+		// Unlike renaming, linking fails if the file was created while writing
+		if err = os.Link(tmp.Name(), file); errors.Is(err, fs.ErrExist) {
+			return &fs.PathError{Op: "create", Path: file, Err: fs.ErrExist}
+		}
+		return err
+	case OverwriteBackup:
+		if err = os.Rename(file, file+".bak"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// WriteExcel defines write [][]string to excel
+//
+// params: file, excel file pull path
+//
+// params: data, write data to excel
+func WriteExcel(file string, data [][]string) error {
+	f := xlsx.NewFile()
+	writeExcel0(f, data)
+	return f.Save(file)
+}
+
+// WriteExcelAny defines write [][]any to excel,
+// numbers, booleans and times are written as typed cells.
+//
+// params: file, excel file pull path
+//
+// params: data, write data to excel
+//
+// params: header, optional header row written above data
+func WriteExcelAny(file string, data [][]any, header ...string) error {
+	f := xlsx.NewFile()
+	writeExcelAny0(f, data, header)
+	return f.Save(file)
+}
+
+// SaveTo the buffered binary into dist file
+func (w *Writer) SaveTo(path string) (err error) {
+	if err = w.Wait(); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = w.book.Save(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,295 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownAggregate is returned for agg tag options naming no aggregate function.
+var ErrUnknownAggregate = errors.New("exl: unknown aggregate")
+
+// aggregates are the Excel functions of the agg tag option by name
+var aggregates = map[string]string{"sum": "SUM", "avg": "AVERAGE", "min": "MIN", "max": "MAX", "count": "COUNT"}
+
+// Footer configures the computed rows appended below the records, e.g. control totals.
+// Each row is labeled in the first column, unless that column holds a total.
+//
+// Columns tagged with the agg option, e.g. `excel:"Amount,agg=sum"`, are aggregated
+// in a row appended before all other footer rows, without enabling any of them.
+// The aggregates are sum, avg, min, max and count, the number of numeric cells.
+type Footer struct {
+	// Append a row with SUM formulas for the numeric columns.
+	Sum bool
+	// Append a row with COUNT formulas for the numeric columns.
+	Count bool
+	// Append a row with the number of data rows.
+	RecordCount bool
+	// Append a row with the checksum of the header and data rows, see Checksum.
+	Checksum bool
+	// Labels of the footer rows.
+	// Default to "Total", "Count", "Records" and "Checksum".
+	SumLabel, CountLabel, RecordCountLabel, ChecksumLabel string
+	// Label of the row of the agg tag options.
+	// Defaults to "Total".
+	AggregateLabel string
+}
+
+// enabled reports whether any footer row is configured
+func (f Footer) enabled() bool {
+	return f.Sum || f.Count || f.RecordCount || f.Checksum
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of the cell values of rows,
+// as written in the checksum footer row for the header and data rows, see Footer.
+// Trailing empty cells are ignored, so the checksum can be verified
+// with the raw values of the rows read back, e.g. Row.Strings.
+func Checksum(rows [][]string) string {
+	h := sha256.New()
+	for _, row := range rows {
+		checksumRow(h, row)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checksumRow adds the values of a row to the checksum,
+// separating cells by the ASCII unit separator and rows by the record separator.
+func checksumRow(h hash.Hash, values []string) {
+	for len(values) > 0 && values[len(values)-1] == "" {
+		values = values[:len(values)-1]
+	}
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0x1f})
+	}
+	h.Write([]byte{0x1e})
+}
+
+// footerTracker collects the totals of the written rows for the footer rows
+type footerTracker struct {
+	footer  Footer
+	numeric []bool
+	// The Excel functions of the agg tag options by column, "" for none
+	aggs     []string
+	sums     []float64
+	counts   []int
+	min, max []float64
+	rows     int
+	sum      hash.Hash
+	// The number of rows above the header row
+	top int
+}
+
+// newFooterTracker returns a tracker for the sheet of layout, or nil if no footer is configured
+func newFooterTracker(layout *sheetLayout, wc *WriteConfig) (*footerTracker, error) {
+	offset := layout.offset()
+	aggs := make([]string, offset)
+	aggregated := false
+	for _, columns := range [][]writeColumn{layout.columns, layout.children} {
+		for _, col := range columns {
+			name, ok := col.opts.Value("agg")
+			fn := aggregates[name]
+			if ok && fn == "" {
+				return nil, fmt.Errorf("%w %q of column %q", ErrUnknownAggregate, name, col.header)
+			}
+			aggs = append(aggs, fn)
+			aggregated = aggregated || ok
+		}
+	}
+	if !wc.Footer.enabled() && !aggregated {
+		return nil, nil
+	}
+	t := &footerTracker{footer: wc.Footer, numeric: make([]bool, offset), sum: sha256.New()}
+	if aggregated {
+		t.aggs = aggs
+	}
+	for _, columns := range [][]writeColumn{layout.columns, layout.children} {
+		for _, col := range columns {
+			t.numeric = append(t.numeric, numericColumn(col, wc))
+		}
+	}
+	t.sums = make([]float64, len(t.numeric))
+	t.counts = make([]int, len(t.numeric))
+	t.min = make([]float64, len(t.numeric))
+	t.max = make([]float64, len(t.numeric))
+	return t, nil
+}
+
+// numericColumn reports whether col holds numbers to total,
+// which masked and pseudonymized columns don't
+func numericColumn(col writeColumn, wc *WriteConfig) bool {
+	if col.opts.Contains("text") || (wc.Redact && col.opts.Contains("mask")) || (wc.Pseudonymize && col.opts.Contains("pseudonym")) {
+		return false
+	}
+	switch indirectType(col.typ).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setTop sets the number of rows above the header row, t may be nil
+func (t *footerTracker) setTop(top int) {
+	if t != nil {
+		t.top = top
+	}
+}
+
+// add records a written row, the first one being the header row
+func (t *footerTracker) add(row *Row) {
+	checksumRow(t.sum, row.Strings())
+	if t.rows++; t.rows == 1 {
+		return
+	}
+	for i, c := range row.Cells {
+		if i >= len(t.numeric) || !(t.numeric[i] || t.aggregated(i)) || c.Type != CellTypeNumber {
+			continue
+		}
+		f, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			continue
+		}
+		if t.counts[i] == 0 || f < t.min[i] {
+			t.min[i] = f
+		}
+		if t.counts[i] == 0 || f > t.max[i] {
+			t.max[i] = f
+		}
+		t.sums[i] += f
+		t.counts[i]++
+	}
+}
+
+// aggregated reports whether the column at index i has an agg tag option
+func (t *footerTracker) aggregated(i int) bool {
+	return i < len(t.aggs) && t.aggs[i] != ""
+}
+
+// aggregate returns the value of the agg tag option of the column at index i, false if there is none
+func (t *footerTracker) aggregate(i int) (float64, bool) {
+	n := t.counts[i]
+	switch t.aggs[i] {
+	case "SUM":
+		return t.sums[i], true
+	case "COUNT":
+		return float64(n), true
+	case "AVERAGE":
+		return t.sums[i] / float64(n), n > 0
+	case "MIN":
+		return t.min[i], n > 0
+	case "MAX":
+		return t.max[i], n > 0
+	}
+	return 0, false
+}
+
+// footerRows returns the footer rows, the data rows being the t.rows-1 rows below the header row
+func (t *footerTracker) footerRows() [][]any {
+	var rows [][]any
+	totals := func(label, fn string, value func(col int) float64) []any {
+		row := make([]any, len(t.numeric))
+		for i := range row {
+			row[i] = ""
+		}
+		if len(row) == 0 {
+			row = append(row, "")
+		}
+		if len(t.numeric) == 0 || !t.numeric[0] {
+			row[0] = label
+		}
+		for i, numeric := range t.numeric {
+			if !numeric {
+				continue
+			}
+			cell := NumberCell(value(i))
+			if t.rows > 1 {
+				cell.Formula = fmt.Sprintf("%s(%s:%s)", fn, CellRef(t.top+1, i), CellRef(t.top+t.rows-1, i))
+			}
+			row[i] = cell
+		}
+		return row
+	}
+	f := t.footer
+	if t.aggs != nil {
+		row := make([]any, len(t.aggs))
+		for i := range row {
+			row[i] = ""
+		}
+		if len(row) > 0 && !t.aggregated(0) {
+			row[0] = labelOr(f.AggregateLabel, "Total")
+		}
+		for i := range t.aggs {
+			if !t.aggregated(i) {
+				continue
+			}
+			// AVERAGE, MIN and MAX of no numbers have no value
+			cell := StringCell("")
+			if value, ok := t.aggregate(i); ok {
+				cell = NumberCell(value)
+			}
+			if t.rows > 1 {
+				cell.Formula = fmt.Sprintf("%s(%s:%s)", t.aggs[i], CellRef(t.top+1, i), CellRef(t.top+t.rows-1, i))
+			}
+			row[i] = cell
+		}
+		rows = append(rows, row)
+	}
+	if f.Sum {
+		rows = append(rows, totals(labelOr(f.SumLabel, "Total"), "SUM", func(i int) float64 { return t.sums[i] }))
+	}
+	if f.Count {
+		rows = append(rows, totals(labelOr(f.CountLabel, "Count"), "COUNT", func(i int) float64 { return float64(t.counts[i]) }))
+	}
+	if f.RecordCount {
+		rows = append(rows, []any{labelOr(f.RecordCountLabel, "Records"), t.rows - 1})
+	}
+	if f.Checksum {
+		rows = append(rows, []any{labelOr(f.ChecksumLabel, "Checksum"), hex.EncodeToString(t.sum.Sum(nil))})
+	}
+	return rows
+}
+
+// FooterLabels returns a ReadConfig.FooterDetector for rows whose first non-empty cell
+// is one of labels, ignoring case and surrounding spaces, e.g. the labels of Footer rows.
+func FooterLabels(labels ...string) func(row *Row) bool {
+	return func(row *Row) bool {
+		for _, c := range row.Cells {
+			value := strings.TrimSpace(c.Value)
+			if value == "" {
+				continue
+			}
+			for _, label := range labels {
+				if strings.EqualFold(value, label) {
+					return true
+				}
+			}
+			return false
+		}
+		return false
+	}
+}
+
+func labelOr(label, defaultLabel string) string {
+	if label == "" {
+		return defaultLabel
+	}
+	return label
+}
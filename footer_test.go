@@ -0,0 +1,147 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type footerTmp struct {
+	Name  string   `excel:"Name"`
+	Qty   int      `excel:"Qty"`
+	Price *float64 `excel:"Price"`
+	Code  int      `excel:"Code,text"`
+}
+
+func (*footerTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Footer = Footer{Sum: true, Count: true, RecordCount: true, Checksum: true, SumLabel: "Sum"}
+}
+
+func TestWriteFooter(t *testing.T) {
+	price := 1.5
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*footerTmp{{"apple", 2, &price, 7}, {"pear", 3, nil, 8}}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	sheet := f.Sheets[0]
+	equal(t, 7, sheet.MaxRow)
+	sum, _ := sheet.Row(3)
+	equal(t, "Sum", sum.GetCell(0).Value)
+	equal(t, "SUM(B2:B3)", sum.GetCell(1).Formula())
+	equal(t, "5", sum.GetCell(1).Value)
+	equal(t, "SUM(C2:C3)", sum.GetCell(2).Formula())
+	equal(t, "1.5", sum.GetCell(2).Value)
+	equal(t, "", sum.GetCell(3).Formula())
+	count, _ := sheet.Row(4)
+	equal(t, "COUNT(C2:C3)", count.GetCell(2).Formula())
+	equal(t, "1", count.GetCell(2).Value)
+	records, _ := sheet.Row(5)
+	equal(t, []string{"Records", "2"}, []string{records.GetCell(0).Value, records.GetCell(1).Value})
+
+	var rows [][]string
+	var checksum string
+	_ = ReadExcelFrom(bytes.NewReader(buf.Bytes()), 0, func(index int, row *Row) error {
+		switch {
+		case index < 3:
+			rows = append(rows, row.Strings())
+		case index == 6:
+			checksum = row.Cell(1).Value
+		}
+		return nil
+	})
+	equal(t, Checksum(rows), checksum)
+	equal(t, Checksum([][]string{{"a", ""}}), Checksum([][]string{{"a"}}))
+
+	plan, _ := PlanWrite([]*footerTmp{}, nil)
+	equal(t, 5, plan.Sheets[0].Rows)
+}
+
+type aggregateTmp struct {
+	Name   string   `excel:"Name"`
+	Qty    int      `excel:"Qty,agg=sum"`
+	Price  *float64 `excel:"Price,agg=avg"`
+	Amount float64  `excel:"Amount,agg=max"`
+	Code   string   `excel:"Code,agg=count"`
+}
+
+func (*aggregateTmp) WriteConfigure(wc *WriteConfig) { wc.Footer.RecordCount = true }
+
+type unknownAggregateTmp struct {
+	Qty int `excel:"Qty,agg=median"`
+}
+
+func (*unknownAggregateTmp) WriteConfigure(*WriteConfig) {}
+
+func TestWriteAggregates(t *testing.T) {
+	price := 1.5
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*aggregateTmp{{"apple", 2, &price, 4, "a"}, {"pear", 3, nil, 7.5, "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	sheet := f.Sheets[0]
+	equal(t, 5, sheet.MaxRow)
+	total, _ := sheet.Row(3)
+	var values, formulas []string
+	for i := 0; i < 5; i++ {
+		values = append(values, total.GetCell(i).Value)
+		formulas = append(formulas, total.GetCell(i).Formula())
+	}
+	equal(t, []string{"Total", "5", "1.5", "7.5", "0"}, values)
+	equal(t, []string{"", "SUM(B2:B3)", "AVERAGE(C2:C3)", "MAX(D2:D3)", "COUNT(E2:E3)"}, formulas)
+	records, _ := sheet.Row(4)
+	equal(t, "Records", records.GetCell(0).Value)
+
+	// No numbers to average
+	buf.Reset()
+	_ = WriteTo(buf, []*aggregateTmp{})
+	f, _ = xlsx.OpenBinary(buf.Bytes())
+	total, _ = f.Sheets[0].Row(1)
+	equal(t, []string{"Total", "0", ""}, []string{total.GetCell(0).Value, total.GetCell(1).Value, total.GetCell(2).Value})
+
+	err := WriteTo(buf, []*unknownAggregateTmp{{1}})
+	equal(t, true, errors.Is(err, ErrUnknownAggregate))
+	equal(t, `exl: unknown aggregate "median" of column "Qty"`, err.Error())
+}
+
+type footerReadTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*footerReadTmp) ReadConfigure(rc *ReadConfig) { rc.FooterDetector = FooterLabels("total") }
+
+type footerSkipTmp footerReadTmp
+
+func (*footerSkipTmp) ReadConfigure(rc *ReadConfig) { rc.SkipFooterRows = 2 }
+
+func TestReadFooter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelAnyTo(buf, [][]any{{"apple", 2}, {"pear", 3}, {}, {" Total ", 5}, {"", "checked"}, {}}, "Name", "Qty")
+	skipped, err := Read[*footerSkipTmp](bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	equal(t, []*footerSkipTmp{{"apple", 2}, {"pear", 3}}, skipped)
+
+	buf.Reset()
+	_ = WriteExcelAnyTo(buf, [][]any{{"apple", 2}, {"pear", 3}, {" Total ", 5}, {"garbage"}}, "Name", "Qty")
+	ts, err := Read[*footerReadTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*footerReadTmp{{"apple", 2}, {"pear", 3}}, ts)
+
+	equal(t, false, FooterLabels("Total")(NewRow("", "Totally")))
+	equal(t, false, FooterLabels("Total")(NewRow()))
+}
@@ -2,7 +2,10 @@ module github.com/nullcache/exl
 
 go 1.18
 
-require github.com/tealeg/xlsx/v3 v3.3.4
+require (
+	github.com/tealeg/xlsx/v3 v3.3.4
+	golang.org/x/text v0.14.0
+)
 
 require (
 	github.com/frankban/quicktest v1.14.6 // indirect
@@ -14,5 +17,4 @@ require (
 	github.com/rogpeppe/fastuuid v1.2.0 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
 	github.com/shabbyrobe/xmlwriter v0.0.0-20230525083848-85336ec334fa // indirect
-	golang.org/x/text v0.14.0 // indirect
 )
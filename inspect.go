@@ -0,0 +1,251 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+type (
+	// FileInfo describes an xlsx file as reported by Inspect.
+	FileInfo struct {
+		// The size of the file in bytes.
+		Size int64
+		// The sheets in workbook order.
+		Sheets []SheetInfo
+		// The number of rows of all sheets, including header rows.
+		EstimatedRows int
+		// Whether the workbook uses the 1904 date system, see WriteConfig.Date1904.
+		Date1904 bool
+	}
+	// SheetInfo describes one sheet of an xlsx file.
+	SheetInfo struct {
+		Name string
+		// The used range of the sheet, e.g. "A1:D100".
+		// Formatted but empty cells count as used, so it may exceed the data.
+		Dimension string
+		// The number of rows and columns of the used range.
+		Rows    int
+		Columns int
+		// The uncompressed size of the sheet part in bytes.
+		Size int64
+	}
+)
+
+// ErrNotWorkbook is returned by Inspect for files which are no xlsx workbook.
+var ErrNotWorkbook = errors.New("exl: not an xlsx workbook")
+
+// Inspect reports the size, sheets and dimensions of an xlsx file without parsing its cells,
+// e.g. to enforce quotas before reading an upload.
+// The dimensions are taken from the sheet headers, only sheets without one are scanned.
+// Readers implementing io.ReaderAt and io.Seeker, like os.File and multipart.File,
+// are not read into memory and are left at their offset, e.g. to read the file after inspecting it.
+func Inspect(reader io.Reader) (FileInfo, error) {
+	ra, size, err := readerAt(reader)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("%w: %v", ErrNotWorkbook, err)
+	}
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		parts[f.Name] = f
+	}
+	workbookPath := "xl/workbook.xml"
+	var rootRels xmlRelationships
+	if err = decodePart(parts, "_rels/.rels", &rootRels); err == nil {
+		for _, rel := range rootRels.Relationships {
+			if strings.HasSuffix(rel.Type, "/officeDocument") {
+				workbookPath = strings.TrimPrefix(rel.Target, "/")
+			}
+		}
+	}
+	var workbook struct {
+		Properties struct {
+			Date1904 bool `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err = decodePart(parts, workbookPath, &workbook); err != nil {
+		return FileInfo{}, err
+	}
+	var rels xmlRelationships
+	dir, file := path.Split(workbookPath)
+	if err = decodePart(parts, dir+"_rels/"+file+".rels", &rels); err != nil {
+		return FileInfo{}, err
+	}
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join(dir, rel.Target)
+		}
+	}
+
+	info := FileInfo{Size: size, Sheets: make([]SheetInfo, 0, len(workbook.Sheets)), Date1904: workbook.Properties.Date1904}
+	for _, s := range workbook.Sheets {
+		part, have := parts[targets[s.ID]]
+		if !have {
+			return FileInfo{}, fmt.Errorf("%w: sheet %s not found", ErrNotWorkbook, s.Name)
+		}
+		si, err := inspectSheet(part)
+		if err != nil {
+			return FileInfo{}, err
+		}
+		si.Name = s.Name
+		info.Sheets = append(info.Sheets, si)
+		info.EstimatedRows += si.Rows
+	}
+	return info, nil
+}
+
+// Sheet returns the info of the sheet named name, or nil.
+func (fi *FileInfo) Sheet(name string) *SheetInfo {
+	for i := range fi.Sheets {
+		if fi.Sheets[i].Name == name {
+			return &fi.Sheets[i]
+		}
+	}
+	return nil
+}
+
+type xmlRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// readerAt returns the rest of reader as io.ReaderAt with its size, reading it into memory if needed.
+// Readers implementing io.ReaderAt and io.Seeker are left at their offset, so they can be read again.
+func readerAt(reader io.Reader) (io.ReaderAt, int64, error) {
+	if rs, ok := reader.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		if offset, err := rs.Seek(0, io.SeekCurrent); err == nil {
+			end, err := rs.Seek(0, io.SeekEnd)
+			if _, serr := rs.Seek(offset, io.SeekStart); err == nil {
+				err = serr
+			}
+			if err != nil {
+				return nil, 0, err
+			}
+			return io.NewSectionReader(rs, offset, end-offset), end - offset, nil
+		}
+	}
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(bs), int64(len(bs)), nil
+}
+
+// decodePart decodes the XML part named name into v
+func decodePart(parts map[string]*zip.File, name string, v any) error {
+	part, have := parts[name]
+	if !have {
+		return fmt.Errorf("%w: %s not found", ErrNotWorkbook, name)
+	}
+	rc, err := part.Open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// inspectSheet reads the dimension of a sheet part,
+// scanning its rows if the sheet has no dimension element.
+func inspectSheet(part *zip.File) (SheetInfo, error) {
+	si := SheetInfo{Size: int64(part.UncompressedSize64)}
+	rc, err := part.Open()
+	if err != nil {
+		return si, err
+	}
+	defer func() { _ = rc.Close() }()
+	decoder := xml.NewDecoder(rc)
+	scanning := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			if si.Rows > 0 {
+				si.Dimension = "A1:" + CellRef(si.Rows-1, si.Columns-1)
+			}
+			return si, nil
+		}
+		if err != nil {
+			return si, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "dimension":
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "ref" && setDimension(&si, attr.Value) {
+					return si, nil
+				}
+			}
+		case "sheetData":
+			scanning = true
+		case "c":
+			if !scanning {
+				continue
+			}
+			for _, attr := range start.Attr {
+				if attr.Name.Local != "r" {
+					continue
+				}
+				if row, col, err := ParseCellRef(attr.Value); err == nil {
+					if row >= si.Rows {
+						si.Rows = row + 1
+					}
+					if col >= si.Columns {
+						si.Columns = col + 1
+					}
+				}
+			}
+		}
+	}
+}
+
+// setDimension sets the dimension of si from a range like "A1:D100" or "A1",
+// and reports whether it is valid.
+func setDimension(si *SheetInfo, ref string) bool {
+	last := ref
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		last = ref[i+1:]
+	}
+	row, col, err := ParseCellRef(last)
+	if err != nil {
+		return false
+	}
+	si.Dimension = ref
+	si.Rows, si.Columns = row+1, col+1
+	return true
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Qty", "Price"}, {"apple", "2", "1.5"}, {"pear", "3", "2"}})
+	data := buf.Bytes()
+
+	info, err := Inspect(bytes.NewReader(data))
+	equal(t, nil, err)
+	equal(t, int64(len(data)), info.Size)
+	equal(t, 3, info.EstimatedRows)
+	equal(t, false, info.Date1904)
+	equal(t, 1, len(info.Sheets))
+	sheet := info.Sheet("Sheet1")
+	equal(t, "A1:C3", sheet.Dimension)
+	equal(t, 3, sheet.Rows)
+	equal(t, 3, sheet.Columns)
+	if sheet.Size <= 0 {
+		t.Errorf("expected the sheet size, got %d", sheet.Size)
+	}
+
+	// The reader is left at its offset, so it can be read after inspecting it
+	r := bytes.NewReader(data)
+	_, err = Inspect(r)
+	equal(t, nil, err)
+	equal(t, int64(len(data)), int64(r.Len()))
+	rows := 0
+	err = ReadExcelFrom(r, 0, func(int, *Row) error { rows++; return nil })
+	equal(t, nil, err)
+	equal(t, 3, rows)
+	prefixed := bytes.NewReader(append([]byte("junk"), data...))
+	_, _ = prefixed.Seek(4, io.SeekStart)
+	info, err = Inspect(prefixed)
+	equal(t, nil, err)
+	equal(t, int64(len(data)), info.Size)
+
+	// Sheets without dimension are scanned, a plain reader is read into memory
+	noDimension := &bytes.Buffer{}
+	dimension := regexp.MustCompile(`<dimension[^>]*/>`)
+	err = rewriteParts(data, noDimension, map[string][]partPatch{
+		"xl/worksheets/sheet1.xml": {func(content string) string { return dimension.ReplaceAllString(content, "") }},
+	}, zipOptions{})
+	equal(t, nil, err)
+	info, err = Inspect(strings.NewReader(noDimension.String()))
+	equal(t, nil, err)
+	equal(t, SheetInfo{Name: "Sheet1", Dimension: "A1:C3", Rows: 3, Columns: 3, Size: info.Sheets[0].Size}, info.Sheets[0])
+
+	if _, err = Inspect(strings.NewReader("Name,Qty")); !errors.Is(err, ErrNotWorkbook) {
+		t.Errorf("expected ErrNotWorkbook, got %v", err)
+	}
+}
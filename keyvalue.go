@@ -0,0 +1,148 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ReadKeyValue binds a vertical key-value sheet to a single T, the layout of settings and summary sheets:
+// column A holds the labels, i.e. the field tags, and column B the values.
+// The rows from ReadConfig.HeaderRowIndex on are read, rows with a blank label are skipped,
+// and the options of the columns of tabular sheets apply to the rows, e.g. SkipUnknownColumns.
+func ReadKeyValue[T ReadConfigurator](reader io.Reader) (T, error) {
+	var t T
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return t, err
+	}
+	rc := newReadConfig[T]()
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return t, err
+	}
+	if rc.SheetIndex > len(book.Sheets())-1 {
+		return t, ErrSheetIndexOutOfRange
+	}
+
+	// Transpose the sheet into a header row of the labels and a row of the values
+	var labels []string
+	var labelRows []int
+	values := &Row{Index: -1}
+	err = book.Rows(rc.SheetIndex, func(row *Row) error {
+		label := strings.TrimSpace(row.Cell(0).Value)
+		if row.Index < rc.HeaderRowIndex || label == "" {
+			return nil
+		}
+		labels = append(labels, label)
+		labelRows = append(labelRows, row.Index)
+		values.Cells = append(values.Cells, row.Cell(1))
+		return nil
+	})
+	if err != nil {
+		return t, err
+	}
+	typ := reflect.TypeOf(t).Elem()
+	fields, err := mapColumns(typ, labels, rc, nil, nil)
+	if err != nil {
+		return t, err
+	}
+	binder := &rowBinder{
+		rc:   rc,
+		book: book,
+		unmarshalConfig: &ExcelUnmarshalParameters{
+			TrimSpace:           rc.TrimSpace,
+			Date1904:            book.Date1904(),
+			FallbackDateFormats: rc.FallbackDateFormats,
+			BoolFormat:          rc.BoolFormat,
+			Locale:              rc.Locale,
+			UnitParser:          rc.UnitParser,
+			UnitConversions:     rc.UnitConversions,
+		},
+		collectedErrors: make([]FieldError, 0),
+	}
+	val := reflect.New(typ)
+	err = binder.bind(val.Elem(), values, fields)
+	binder.collectedErrors = append(binder.collectedErrors, binder.categoryErrors()...)
+	if err == nil && len(binder.collectedErrors) > 0 {
+		err = ContentError{FieldErrors: binder.collectedErrors, formatter: rc.ErrorFormatter, grouped: rc.GroupErrors}
+	}
+	if err != nil {
+		return t, keyValueError(err, labelRows)
+	}
+	return val.Interface().(T), nil
+}
+
+// keyValueError locates the field errors of a transposed key-value sheet at the value cells
+func keyValueError(err error, labelRows []int) error {
+	locate := func(fe *FieldError) {
+		fe.RowIndex, fe.ColumnIndex = labelRows[fe.ColumnIndex], 1
+		fe.CellRef = CellRef(fe.RowIndex, fe.ColumnIndex)
+	}
+	var fe FieldError
+	var ce ContentError
+	switch {
+	case errors.As(err, &ce):
+		ce.FieldErrors = append([]FieldError(nil), ce.FieldErrors...)
+		for i := range ce.FieldErrors {
+			locate(&ce.FieldErrors[i])
+		}
+		return ce
+	case errors.As(err, &fe):
+		locate(&fe)
+		return fe
+	}
+	return err
+}
+
+// WriteKeyValue writes t as vertical key-value sheet, see ReadKeyValue:
+// a row for each field with its label in column A and the value in column B.
+func WriteKeyValue[T WriteConfigurator](w io.Writer, t T) error {
+	wc := newWriteConfig[T]()
+	if err := wc.Validate(); err != nil {
+		return err
+	}
+	book := wc.Backend.Create()
+	if err := setDateSystem(book, wc.Date1904); err != nil {
+		return err
+	}
+	name, err := newSheetName(book, wc.SheetName, wc.StrictSheetNames)
+	if err != nil {
+		return err
+	}
+	sheet, err := book.AddSheet(name)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(t).Elem()
+	columns := writeColumns(rv.Type(), wc)
+	values, err := recordValues(rv, columns, wc)
+	if err != nil {
+		return err
+	}
+	sw := newSheetWriter(book, sheet, wc)
+	for i, col := range columns {
+		if err = sw.append([]any{col.header, values[i]}, 0); err != nil {
+			return err
+		}
+	}
+	if err = setCompression(book, wc); err != nil {
+		return err
+	}
+	if err = arrangeSheet(book, sheet, wc); err != nil {
+		return err
+	}
+	return book.Save(w)
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+type settingsTmp struct {
+	Name    string    `excel:"Name"`
+	Retries int       `excel:"Retries"`
+	Enabled bool      `excel:"Enabled"`
+	Start   time.Time `excel:"Start"`
+	Limit   *float64  `excel:"Limit"`
+}
+
+func (*settingsTmp) ReadConfigure(rc *ReadConfig) {
+	rc.PointerCanNil = true
+	rc.SkipUnknownColumns = true
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+}
+func (*settingsTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "Settings" }
+
+func TestReadWriteKeyValue(t *testing.T) {
+	settings := &settingsTmp{"import", 3, true, time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC), nil}
+	buf := &bytes.Buffer{}
+	if err := WriteKeyValue(buf, settings); err != nil {
+		t.Fatal(err)
+	}
+	rows, _ := Preview(bytes.NewReader(buf.Bytes()), 0, 5)
+	equal(t, []string{"Name", "import"}, rows[0])
+	equal(t, []string{"Retries", "3"}, rows[1])
+	equal(t, []string{"Limit", ""}, rows[4])
+
+	s, err := ReadKeyValue[*settingsTmp](bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	equal(t, settings, s)
+
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Settings"}, {"Retries", "many"}, {}, {" Name ", "export"}, {"Limit", "x"}})
+	s, err = ReadKeyValue[*settingsTmp](bytes.NewReader(buf.Bytes()))
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, (*settingsTmp)(nil), s)
+	equal(t, 2, len(ce.FieldErrors))
+	equal(t, "B2", ce.FieldErrors[0].CellRef)
+	equal(t, "Retries", ce.FieldErrors[0].ColumnHeader)
+	equal(t, "B5", ce.FieldErrors[1].CellRef)
+}
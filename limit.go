@@ -0,0 +1,224 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf16"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// LimitHandling configures how cells exceeding the limits of a worksheet are written,
+// see WriteConfig.LimitHandling.
+type LimitHandling uint8
+
+const (
+	// LimitIgnore
+	// Write all cells as they are, the file may be lossy or rejected by spreadsheet applications
+	LimitIgnore LimitHandling = iota
+	// LimitAbort
+	// Abort writing with a LimitError
+	LimitAbort
+	// LimitTruncate
+	// Truncate text and drop rows and columns beyond the limits,
+	// write inexact numbers as text, reporting each case to WriteConfig.LimitWarning
+	LimitTruncate
+)
+
+const (
+	// MaxCellLength is the maximum number of characters of a cell
+	MaxCellLength = 32767
+	// MaxRows is the maximum number of rows of a worksheet
+	MaxRows = 1048576
+	// MaxColumns is the maximum number of columns of a worksheet
+	MaxColumns = 16384
+	// maxExactInt is the largest integer a float64 represents exactly, 2^53
+	maxExactInt = 1 << 53
+)
+
+// Errors wrapped by LimitError
+var (
+	ErrCellTooLong    = errors.New("exl: text exceeds the cell length limit")
+	ErrTooManyRows    = errors.New("exl: too many rows for a worksheet")
+	ErrTooManyColumns = errors.New("exl: too many columns for a worksheet")
+	ErrInexactNumber  = errors.New("exl: number can't be represented exactly")
+)
+
+// LimitError reports a cell exceeding the limits of a worksheet.
+type LimitError struct {
+	Sheet       string
+	RowIndex    int // 0-based row index. Printed as 1-based row number in error text.
+	ColumnIndex int // 0-based column index.
+	Err         error
+}
+
+// Error implements error.
+func (e LimitError) Error() string {
+	return fmt.Sprintf("sheet %s row %d column %d: %s", e.Sheet, e.RowIndex+1, e.ColumnIndex+1, e.Err.Error())
+}
+
+// Unwrap
+// Error implements the anonymous unwrap interface used by errors.Unwrap and others.
+func (e LimitError) Unwrap() error {
+	return e.Err
+}
+
+// sheetWriter appends rows to a sheet, checking the worksheet limits as configured
+type sheetWriter struct {
+	book  Spreadsheet
+	sheet int
+	name  string
+	wc    *WriteConfig
+	rows  int
+	// The number of rows above the header row
+	top int
+	// Collects the totals of the footer rows, may be nil
+	footer *footerTracker
+	// Renders the floats in WriteConfig.Locale, nil without locale
+	printer *message.Printer
+}
+
+func newSheetWriter(book Spreadsheet, sheet int, wc *WriteConfig) *sheetWriter {
+	w := &sheetWriter{book: book, sheet: sheet, name: book.Sheets()[sheet], wc: wc}
+	if wc.Locale != language.Und {
+		w.printer = message.NewPrinter(wc.Locale)
+	}
+	return w
+}
+
+// append writes the values as next row with the given outline level
+func (w *sheetWriter) append(data []any, level uint8) error {
+	row := newRow(data, w.wc)
+	row.OutlineLevel = level
+	if w.wc.LimitHandling != LimitIgnore {
+		write, err := w.guard(row, data)
+		if err != nil || !write {
+			return err
+		}
+	}
+	w.rows++
+	if w.footer != nil {
+		w.footer.add(row)
+	}
+	if w.printer != nil {
+		// The footer totals are computed from the numeric cells
+		localizeRow(row, data, w.printer)
+	}
+	return w.book.AppendRow(w.sheet, row)
+}
+
+// appendFooter writes the footer rows, see WriteConfig.Footer
+func (w *sheetWriter) appendFooter() error {
+	if w.footer == nil {
+		return nil
+	}
+	rows := w.footer.footerRows()
+	w.footer = nil
+	for _, data := range rows {
+		if err := w.append(data, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countRows reports the data rows written, not counting the header row, to the metrics
+func (w *sheetWriter) countRows() {
+	if w.wc.Metrics != nil && w.rows > w.top+1 {
+		w.wc.Metrics.AddRows(OpWrite, w.rows-w.top-1)
+	}
+}
+
+// guard checks the row against the worksheet limits,
+// reporting whether to write it at all.
+func (w *sheetWriter) guard(row *Row, data []any) (bool, error) {
+	if w.rows >= MaxRows {
+		if err := w.exceeded(w.rows, 0, ErrTooManyRows); err != nil {
+			return false, err
+		}
+		// Warn only once
+		if w.rows == MaxRows {
+			w.rows++
+		}
+		return false, nil
+	}
+	if len(row.Cells) > MaxColumns {
+		if err := w.exceeded(w.rows, MaxColumns, ErrTooManyColumns); err != nil {
+			return false, err
+		}
+		row.Cells = row.Cells[:MaxColumns]
+	}
+	for i := range row.Cells {
+		cell := &row.Cells[i]
+		switch cell.Type {
+		case CellTypeString:
+			if runes := []rune(cell.Value); len(runes) > MaxCellLength && len(utf16.Encode(runes)) > MaxCellLength {
+				if err := w.exceeded(w.rows, i, ErrCellTooLong); err != nil {
+					return false, err
+				}
+				cell.Value = truncateUTF16(runes, MaxCellLength)
+			}
+		case CellTypeNumber:
+			if !exactNumber(data[i]) {
+				if err := w.exceeded(w.rows, i, ErrInexactNumber); err != nil {
+					return false, err
+				}
+				// Keep all digits as text
+				*cell = StringCell(fmt.Sprint(data[i]))
+			}
+		}
+	}
+	return true, nil
+}
+
+// exceeded returns the LimitError for LimitAbort handling,
+// or passes it to the warning callback and returns nil.
+func (w *sheetWriter) exceeded(row, col int, err error) error {
+	le := LimitError{Sheet: w.name, RowIndex: row, ColumnIndex: col, Err: err}
+	if w.wc.LimitHandling == LimitAbort {
+		return le
+	}
+	if w.wc.LimitWarning != nil {
+		w.wc.LimitWarning(le)
+	}
+	return nil
+}
+
+// exactNumber reports whether an integer value survives the conversion to float64
+func exactNumber(v any) bool {
+	switch n := v.(type) {
+	case int64:
+		return n >= -maxExactInt && n <= maxExactInt
+	case int:
+		return n >= -maxExactInt && n <= maxExactInt
+	case uint64:
+		return n <= maxExactInt
+	case uint:
+		return n <= maxExactInt
+	}
+	return true
+}
+
+// truncateUTF16 returns the longest prefix of runes of at most n UTF-16 code units
+func truncateUTF16(runes []rune, n int) string {
+	units := 0
+	for i, r := range runes {
+		units += len(utf16.Encode([]rune{r}))
+		if units > n {
+			return string(runes[:i])
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type limitTmp struct {
+	Text string `excel:"Text"`
+	ID   int64  `excel:"ID"`
+}
+
+func (*limitTmp) WriteConfigure(wc *WriteConfig) { wc.LimitHandling = LimitAbort }
+
+type limitTruncateTmp limitTmp
+
+var limitWarnings []error
+
+func (*limitTruncateTmp) WriteConfigure(wc *WriteConfig) {
+	wc.LimitHandling = LimitTruncate
+	wc.LimitWarning = func(err error) { limitWarnings = append(limitWarnings, err) }
+}
+
+func TestWriteLimits(t *testing.T) {
+	long := strings.Repeat("é", MaxCellLength+10)
+
+	err := WriteTo(&bytes.Buffer{}, []*limitTmp{{"ok", 1}, {long, 2}})
+	var le LimitError
+	if !errors.As(err, &le) || !errors.Is(err, ErrCellTooLong) {
+		t.Fatalf("expected ErrCellTooLong, got %v", err)
+	}
+	equal(t, LimitError{Sheet: "Sheet1", RowIndex: 2, ColumnIndex: 0, Err: ErrCellTooLong}, le)
+	equal(t, "sheet Sheet1 row 3 column 1: exl: text exceeds the cell length limit", le.Error())
+
+	err = WriteTo(&bytes.Buffer{}, []*limitTmp{{"ok", 1 << 60}})
+	if !errors.Is(err, ErrInexactNumber) {
+		t.Errorf("expected ErrInexactNumber, got %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	limitWarnings = nil
+	if err = WriteTo(buf, []*limitTruncateTmp{{long, 1<<60 + 1}}); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, 2, len(limitWarnings))
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, MaxCellLength, len([]rune(row.GetCell(0).Value)))
+	equal(t, "1152921504606846977", row.GetCell(1).Value)
+	equal(t, xlsx.CellTypeString, row.GetCell(1).Type())
+}
+
+func TestTruncateUTF16(t *testing.T) {
+	equal(t, "ab", truncateUTF16([]rune("ab😀"), 3))
+	equal(t, "ab😀", truncateUTF16([]rune("ab😀"), 4))
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// localeDateFormats are the date formats of WriteConfig.Locale by language or language and region,
+// dots are escaped as number formats don't allow them unescaped
+var localeDateFormats = map[string]string{
+	"en":    "m/d/yyyy",
+	"en-GB": "dd/mm/yyyy",
+	"en-AU": "dd/mm/yyyy",
+	"en-IN": "dd/mm/yyyy",
+	"de":    `dd\.mm\.yyyy`,
+	"fr":    "dd/mm/yyyy",
+	"fr-CA": "yyyy-mm-dd",
+	"es":    "dd/mm/yyyy",
+	"it":    "dd/mm/yyyy",
+	"pt":    "dd/mm/yyyy",
+	"nl":    "dd-mm-yyyy",
+	"pl":    `dd\.mm\.yyyy`,
+	"ru":    `dd\.mm\.yyyy`,
+	"tr":    `dd\.mm\.yyyy`,
+	"sv":    "yyyy-mm-dd",
+	"zh":    "yyyy/m/d",
+	"ja":    "yyyy/mm/dd",
+	"ko":    "yyyy-mm-dd",
+}
+
+// localeDateFormat returns the date format of tag, ok is false for locales without known format
+func localeDateFormat(tag language.Tag) (format string, ok bool) {
+	base, _ := tag.Base()
+	if region, conf := tag.Region(); conf != language.No {
+		if format, ok = localeDateFormats[base.String()+"-"+region.String()]; ok {
+			return format, true
+		}
+	}
+	format, ok = localeDateFormats[base.String()]
+	return format, ok
+}
+
+// timeFormat returns the number format of dates, see Locale
+func (wc *WriteConfig) timeFormat() string {
+	if wc.Locale == language.Und || wc.WriteTimeFmt != xlsx.DefaultDateFormat {
+		return wc.WriteTimeFmt
+	}
+	if format, ok := localeDateFormat(wc.Locale); ok {
+		return format
+	}
+	return wc.WriteTimeFmt
+}
+
+// localizeRow replaces the cells of the floats of data by text rendered by p, see WriteConfig.Locale
+func localizeRow(row *Row, data []any, p *message.Printer) {
+	for i, v := range data {
+		var f float64
+		switch t := v.(type) {
+		case float64:
+			f = t
+		case float32:
+			f = float64(t)
+		default:
+			continue
+		}
+		if i < len(row.Cells) && row.Cells[i].Type == CellTypeNumber {
+			row.Cells[i] = StringCell(formatFloat(p, f))
+		}
+	}
+}
+
+// formatFloat renders f in the locale of p with the fraction digits of its shortest representation
+func formatFloat(p *message.Printer, f float64) string {
+	digits := 0
+	if s := strconv.FormatFloat(f, 'f', -1, 64); strings.Contains(s, ".") {
+		digits = len(s) - strings.Index(s, ".") - 1
+	}
+	return p.Sprint(number.Decimal(f, number.MinFractionDigits(digits), number.MaxFractionDigits(digits)))
+}
+
+// numberSeparators caches the thousands and decimal separators of locales by language.Tag
+var numberSeparators sync.Map
+
+// localeSeparators returns the thousands and decimal separators of tag, taken from its rendering of 1234.5
+func localeSeparators(tag language.Tag) (group, decimal string) {
+	if seps, ok := numberSeparators.Load(tag); ok {
+		s := seps.([2]string)
+		return s[0], s[1]
+	}
+	probe := message.NewPrinter(tag).Sprint(number.Decimal(1234.5, number.MinFractionDigits(1)))
+	if i, j := strings.Index(probe, "1"), strings.Index(probe, "234"); i >= 0 && j > i {
+		group = probe[i+1 : j]
+	}
+	if i, j := strings.LastIndex(probe, "4"), strings.LastIndex(probe, "5"); i >= 0 && j > i {
+		decimal = probe[i+1 : j]
+	}
+	numberSeparators.Store(tag, [2]string{group, decimal})
+	return group, decimal
+}
+
+// delocalizeNumber returns the number text value of the locale tag in the syntax of strconv.ParseFloat,
+// e.g. "1234.56" for "1.234,56" in German
+func delocalizeNumber(value string, tag language.Tag) string {
+	group, decimal := localeSeparators(tag)
+	if group != "" {
+		value = strings.ReplaceAll(value, group, "")
+		if strings.TrimSpace(group) == "" {
+			// Spaces typed instead of the non-breaking space of the locale
+			value = strings.ReplaceAll(value, " ", "")
+		}
+	}
+	if decimal != "" && decimal != "." {
+		value = strings.ReplaceAll(value, decimal, ".")
+	}
+	return value
+}
+
+// monthNames are the month names of ReadConfig.Locale by language, January first,
+// followed by their abbreviations
+var monthNames = map[string][24]string{
+	"de": {"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember",
+		"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	"fr": {"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+	"es": {"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre",
+		"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	"it": {"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre",
+		"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+	"nl": {"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december",
+		"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+	"pt": {"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+		"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+}
+
+// monthReplacers caches the replacers of englishMonths by language
+var monthReplacers sync.Map
+
+// englishMonths replaces the month names of the locale tag in value by English ones,
+// e.g. "31. December 2024" for "31. Dezember 2024" in German
+func englishMonths(value string, tag language.Tag) string {
+	base, _ := tag.Base()
+	if r, ok := monthReplacers.Load(base.String()); ok {
+		return r.(*strings.Replacer).Replace(value)
+	}
+	names, ok := monthNames[base.String()]
+	if !ok {
+		return value
+	}
+	// Full names are matched before abbreviations, abbreviations with dot before those without,
+	// all are replaced by the full English name
+	pairs := make([]string, 0, 12*2*3*3)
+	for _, group := range [][]string{names[:12], dotted(names[12:]), names[12:]} {
+		for i, name := range group {
+			english := time.Month(i + 1).String()
+			for _, variant := range []string{name, strings.ToLower(name), upperFirst(name)} {
+				pairs = append(pairs, variant, english)
+			}
+		}
+	}
+	r := strings.NewReplacer(pairs...)
+	monthReplacers.Store(base.String(), r)
+	return r.Replace(value)
+}
+
+// dotted returns names with a trailing dot
+func dotted(names []string) []string {
+	d := make([]string, len(names))
+	for i, name := range names {
+		d[i] = name + "."
+	}
+	return d
+}
+
+// upperFirst returns s with its first letter in upper case
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+type localeTmp struct {
+	ID     int       `excel:"ID"`
+	Amount float64   `excel:"Amount"`
+	Day    time.Time `excel:"Day"`
+}
+
+func (*localeTmp) WriteConfigure(wc *WriteConfig) { wc.Locale = language.German }
+
+func TestLocaleDateFormat(t *testing.T) {
+	for tag, expected := range map[language.Tag]string{
+		language.German:             `dd\.mm\.yyyy`,
+		language.AmericanEnglish:    "m/d/yyyy",
+		language.BritishEnglish:     "dd/mm/yyyy",
+		language.MustParse("fr-CA"): "yyyy-mm-dd",
+	} {
+		format, ok := localeDateFormat(tag)
+		equal(t, true, ok)
+		equal(t, expected, format)
+	}
+	_, ok := localeDateFormat(language.Hindi)
+	equal(t, false, ok)
+}
+
+func TestWriteLocale(t *testing.T) {
+	day := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*localeTmp{{1234, 1234.56, day}}))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	id, _ := f.Sheets[0].Cell(1, 0)
+	equal(t, "1234", id.Value)
+	amount, _ := f.Sheets[0].Cell(1, 1)
+	equal(t, "1.234,56", amount.Value)
+	equal(t, "0,1", formatFloat(message.NewPrinter(language.German), 0.1))
+	equal(t, "-1,234.5", formatFloat(message.NewPrinter(language.English), -1234.5))
+	cell, _ := f.Sheets[0].Cell(1, 2)
+	equal(t, `dd\.mm\.yyyy`, cell.NumFmt)
+	equal(t, true, cell.IsTime())
+
+	// An explicit time format takes precedence
+	wc := newWriteConfig[*localeTmp]()
+	wc.WriteTimeFmt = "yyyy-mm-dd"
+	equal(t, "yyyy-mm-dd", wc.timeFormat())
+}
+
+type readLocaleTmp struct {
+	Amount float64   `excel:"Amount"`
+	Day    time.Time `excel:"Day"`
+}
+
+func (*readLocaleTmp) ReadConfigure(rc *ReadConfig) {
+	rc.Locale = language.German
+	rc.FallbackDateFormats = []string{"2. January 2006"}
+}
+
+func TestDelocalizeNumber(t *testing.T) {
+	equal(t, "1234.56", delocalizeNumber("1.234,56", language.German))
+	equal(t, "-1234567.5", delocalizeNumber("-1 234 567,5", language.French))
+	equal(t, "1234.56", delocalizeNumber("1,234.56", language.AmericanEnglish))
+	equal(t, "1234.56", delocalizeNumber("1’234.56", language.MustParse("de-CH")))
+}
+
+func TestEnglishMonths(t *testing.T) {
+	equal(t, "31. December 2024", englishMonths("31. Dezember 2024", language.German))
+	equal(t, "3. March 2024", englishMonths("3. März 2024", language.German))
+	equal(t, "3. October 2024", englishMonths("3. Okt. 2024", language.German))
+	equal(t, "1 February 2024", englishMonths("1 février 2024", language.French))
+	equal(t, "31. Dezember 2024", englishMonths("31. Dezember 2024", language.Japanese))
+}
+
+func TestReadLocale(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Amount", "Day"},
+		{"1.234,56", "31. Dezember 2024"},
+		{"-0,5", "3. Okt. 2024"},
+	})
+	ts, err := ReadBinary[*readLocaleTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*readLocaleTmp{
+		{1234.56, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{-0.5, time.Date(2024, 10, 3, 0, 0, 0, 0, time.UTC)},
+	}, ts)
+
+	// Written with WriteConfig.Locale
+	buf.Reset()
+	equal(t, nil, WriteTo(buf, []*localeTmp{{1, 1234.56, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)}}))
+	read, err := ReadBinary[*readLocaleTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*readLocaleTmp{{1234.56, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)}}, read)
+}
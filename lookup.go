@@ -0,0 +1,125 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidLookup is returned for lookup tag options which can't be resolved.
+var ErrInvalidLookup = errors.New("exl: invalid lookup")
+
+// lookupColumn is a column written as VLOOKUP formula, see the lookup tag option
+type lookupColumn struct {
+	// Index of the column and of the column holding the key, in the written row
+	col, key int
+	// The looked up range, e.g. Products!$A:$C, and the 1-based index of the value column in it
+	table string
+	index int
+}
+
+// lookupColumns resolves the lookup tag options of the record columns,
+// e.g. `excel:"Product,lookup=Products!ID:Name,by=Product ID"`
+// looks up the value of the Product ID column in the ID column of the sheet Products,
+// which must have been written to the same workbook before, e.g. by WriteSheet, and returns its Name column.
+// by defaults to the key column header of the other sheet.
+func lookupColumns(book Spreadsheet, layout *sheetLayout) ([]lookupColumn, error) {
+	if _, ok := book.(*planBook); ok {
+		// The formulas don't change the layout planned without the other sheets
+		return nil, nil
+	}
+	var lookups []lookupColumn
+	offset := layout.offset()
+	for i, col := range layout.columns {
+		spec, ok := col.opts.Value("lookup")
+		if !ok {
+			continue
+		}
+		fail := func(reason string) error {
+			return fmt.Errorf("%w %q of column %q: %s", ErrInvalidLookup, spec, col.header, reason)
+		}
+		sep := strings.LastIndex(spec, "!")
+		keyHeader, valueHeader, found := strings.Cut(spec[sep+1:], ":")
+		if sep <= 0 || !found {
+			return nil, fail("expected sheet!key:value")
+		}
+		sheetName := spec[:sep]
+		by, _ := col.opts.Value("by")
+		if by == "" {
+			by = keyHeader
+		}
+		lookup := lookupColumn{col: offset + i, key: -1}
+		for j, c := range layout.columns {
+			if c.header == by {
+				lookup.key = offset + j
+			}
+		}
+		if lookup.key < 0 {
+			return nil, fail(fmt.Sprintf("no key column %q", by))
+		}
+		sheet := -1
+		for j, name := range book.Sheets() {
+			if name == sheetName || name == SanitizeSheetName(sheetName) {
+				sheet, sheetName = j, name
+			}
+		}
+		if sheet < 0 {
+			return nil, fail(fmt.Sprintf("no sheet %q written before", sheetName))
+		}
+		header, err := readRow(book, sheet, 0)
+		if errors.Is(err, ErrUnsupported) {
+			// The other sheet can't be read back, write the values instead
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		keyCol, valueCol := -1, -1
+		if header != nil {
+			for j, h := range header.Strings() {
+				switch h {
+				case keyHeader:
+					keyCol = j
+				case valueHeader:
+					valueCol = j
+				}
+			}
+		}
+		if keyCol < 0 || valueCol < 0 {
+			return nil, fail(fmt.Sprintf("no column %q and %q in sheet %q", keyHeader, valueHeader, sheetName))
+		}
+		if valueCol < keyCol {
+			return nil, fail("the key column must be left of the value column")
+		}
+		lookup.table = fmt.Sprintf("%s!$%s:$%s", quoteSheetName(sheetName), ColumnLetter(keyCol), ColumnLetter(valueCol))
+		lookup.index = valueCol - keyCol + 1
+		lookups = append(lookups, lookup)
+	}
+	return lookups, nil
+}
+
+// formula returns the VLOOKUP formula of the column in the row at the 0-based index row
+func (l lookupColumn) formula(row int) string {
+	return fmt.Sprintf("VLOOKUP(%s,%s,%d,FALSE)", CellRef(row, l.key), l.table, l.index)
+}
+
+// quoteSheetName quotes a sheet name for use in formulas, if needed
+func quoteSheetName(name string) string {
+	for i, r := range name {
+		if i == 0 && r >= '0' && r <= '9' || !(r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r > 0x7f) {
+			return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+		}
+	}
+	return name
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type productTmp struct {
+	ID    string  `excel:"ID"`
+	Name  string  `excel:"Name"`
+	Price float64 `excel:"Price"`
+}
+
+func (*productTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "Product List" }
+
+type orderLineTmp struct {
+	Product string  `excel:"Product"`
+	Name    string  `excel:"Name,lookup=Product List!ID:Name,by=Product"`
+	Price   float64 `excel:"Price,lookup=Product List!ID:Price,by=Product"`
+	Qty     int     `excel:"Qty"`
+}
+
+func (*orderLineTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "Orders" }
+
+type badLookupTmp struct {
+	Name string `excel:"Name,lookup=Product List!Name:ID"`
+}
+
+func (*badLookupTmp) WriteConfigure(*WriteConfig) {}
+
+func TestWriteLookup(t *testing.T) {
+	w := NewWriter()
+	equal(t, nil, WriteSheet(w, []*productTmp{{"p1", "pen", 1.5}, {"p2", "paper", 4}}))
+	equal(t, nil, WriteSheet(w, []*orderLineTmp{{"p2", "paper", 4, 3}, {"p1", "pen", 1.5, 2}}))
+	buf := &bytes.Buffer{}
+	_, err := w.WriteTo(buf)
+	equal(t, nil, err)
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheet["Orders"].Row(2)
+	equal(t, "VLOOKUP(A3,'Product List'!$A:$B,2,FALSE)", row.GetCell(1).Formula())
+	equal(t, "pen", row.GetCell(1).Value)
+	equal(t, "VLOOKUP(A3,'Product List'!$A:$C,3,FALSE)", row.GetCell(2).Formula())
+	equal(t, "1.5", row.GetCell(2).Value)
+
+	err = WriteSheet(w, []*badLookupTmp{})
+	equal(t, true, errors.Is(err, ErrInvalidLookup))
+	equal(t, `exl: invalid lookup "Product List!Name:ID" of column "Name": the key column must be left of the value column`, err.Error())
+	err = WriteTo(buf, []*orderLineTmp{})
+	equal(t, `exl: invalid lookup "Product List!ID:Name" of column "Name": no sheet "Product List" written before`, err.Error())
+	_, err = PlanWrite([]*orderLineTmp{}, nil)
+	equal(t, nil, err)
+	equal(t, "Product_List", quoteSheetName("Product_List"))
+	equal(t, "'2023'", quoteSheetName("2023"))
+}
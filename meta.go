@@ -0,0 +1,162 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+// metaField is a field of a metadata struct bound to a single cell by an absolute address,
+// e.g. `excel:"@B1"`, see ReadWithMeta
+type metaField struct {
+	fieldIndex int
+	row, col   int
+	column     writeColumn
+}
+
+// metaFields returns the fields of typ tagged with a cell address
+func metaFields(typ reflect.Type, tagName string) ([]metaField, error) {
+	fields := make([]metaField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		fe := typ.Field(i)
+		name, opts := parseTag(fe.Tag.Get(tagName))
+		if !fe.IsExported() || !strings.HasPrefix(name, "@") {
+			continue
+		}
+		ref := name[1:]
+		row, col, err := ParseCellRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, metaField{fieldIndex: i, row: row, col: col, column: writeColumn{fieldIndex: i, header: ref, tag: name, opts: opts, typ: fe.Type}})
+	}
+	return fields, nil
+}
+
+// ReadWithMeta is Read, also binding the cells of the sheet addressed by the fields of M,
+// e.g. a report date in B1 tagged `excel:"@B1"`, the metadata many files carry above the table.
+// Configure the ReadConfig.HeaderRowIndex of T to the header row below the metadata cells.
+func ReadWithMeta[M any, T ReadConfigurator](reader io.Reader, filterFunc ...func(t T) (add bool)) (*M, []T, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc := newReadConfig[T]()
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rc.SheetIndex > len(book.Sheets())-1 {
+		return nil, nil, ErrSheetIndexOutOfRange
+	}
+	meta := new(M)
+	if err = readMeta(book, rc, reflect.ValueOf(meta).Elem()); err != nil {
+		return nil, nil, err
+	}
+	ts, err := readSpreadsheet(book, rc, nil, nil, nil, filterFunc...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return meta, ts, nil
+}
+
+// readMeta binds the cells addressed by the fields of the struct value meta
+func readMeta(book Spreadsheet, rc *ReadConfig, meta reflect.Value) error {
+	fields, err := metaFields(meta.Type(), rc.TagName)
+	if err != nil || len(fields) == 0 {
+		return err
+	}
+	last := 0
+	for _, f := range fields {
+		if f.row > last {
+			last = f.row
+		}
+	}
+	rows := make(map[int]*Row, last+1)
+	err = book.Rows(rc.SheetIndex, func(row *Row) error {
+		if row.Index > last {
+			return errStopRows
+		}
+		rows[row.Index] = row
+		return nil
+	})
+	if err != nil && err != errStopRows {
+		return err
+	}
+	params := &ExcelUnmarshalParameters{TrimSpace: rc.TrimSpace, Date1904: book.Date1904(), FallbackDateFormats: rc.FallbackDateFormats, BoolFormat: rc.BoolFormat, Locale: rc.Locale, UnitParser: rc.UnitParser, UnitConversions: rc.UnitConversions}
+	for _, f := range fields {
+		row := rows[f.row]
+		if row == nil {
+			row = &Row{}
+		}
+		cell := row.Cell(f.col)
+		if cell.Value == "" && f.column.typ.Kind() == reflect.Ptr && rc.PointerCanNil {
+			continue
+		}
+		dest := meta.Field(f.fieldIndex)
+		unmarshal := GetUnmarshalFunc(dest)
+		if unmarshal == nil {
+			continue
+		}
+		if err = unmarshal(dest, row.cellXLSX(f.col), params); err != nil {
+			return FieldError{
+				RowIndex:     f.row,
+				ColumnIndex:  f.col,
+				CellRef:      f.column.header,
+				Value:        cell.Value,
+				ExpectedType: dest.Type().String(),
+				Err:          err,
+				formatter:    rc.ErrorFormatter,
+			}
+		}
+	}
+	return nil
+}
+
+// WriteWithMeta is WriteTo, also writing the fields of meta tagged with a cell address
+// into their cells, see ReadWithMeta.
+// The header row of ts follows the last metadata row after one blank row,
+// e.g. at row index 3 for metadata in B1 and B2.
+func WriteWithMeta[M any, T WriteConfigurator](w io.Writer, meta *M, ts []T) error {
+	wc := newWriteConfig[T]()
+	preamble, err := metaRows(reflect.ValueOf(meta).Elem(), wc)
+	if err != nil {
+		return err
+	}
+	return writeTo(w, ts, wc, preamble)
+}
+
+// metaRows returns the rows holding the cells addressed by the fields of the struct value meta,
+// followed by a blank row
+func metaRows(meta reflect.Value, wc *WriteConfig) ([][]any, error) {
+	fields, err := metaFields(meta.Type(), wc.TagName)
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+	var rows [][]any
+	for _, f := range fields {
+		for len(rows) <= f.row+1 {
+			rows = append(rows, []any{})
+		}
+		for len(rows[f.row]) <= f.col {
+			rows[f.row] = append(rows[f.row], "")
+		}
+		value, err := columnValue(meta.Field(f.fieldIndex), f.column, wc)
+		if err != nil {
+			return nil, err
+		}
+		rows[f.row][f.col] = value
+	}
+	return rows, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+type reportMeta struct {
+	Date       time.Time `excel:"@B1"`
+	Department string    `excel:"@B2"`
+	Note       string
+}
+
+type reportLineTmp struct {
+	Item   string  `excel:"Item"`
+	Amount float64 `excel:"Amount,agg=sum"`
+}
+
+func (*reportLineTmp) ReadConfigure(rc *ReadConfig) { rc.HeaderRowIndex, rc.DataStartRowIndex = 3, 4 }
+func (*reportLineTmp) WriteConfigure(*WriteConfig)  {}
+
+type badMeta struct {
+	Count int `excel:"@A1"`
+}
+
+type invalidRefMeta struct {
+	Count int `excel:"@1A"`
+}
+
+func TestReadWriteMeta(t *testing.T) {
+	meta := &reportMeta{Date: time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC), Department: "Sales", Note: "not written"}
+	lines := []*reportLineTmp{{"pens", 2.5}, {"paper", 4}}
+	buf := &bytes.Buffer{}
+	if err := WriteWithMeta(buf, meta, lines); err != nil {
+		t.Fatal(err)
+	}
+	rows, _ := Preview(bytes.NewReader(buf.Bytes()), 0, 7)
+	equal(t, []string{"", "Sales"}, rows[1])
+	equal(t, []string{"", ""}, rows[2])
+	equal(t, []string{"Item", "Amount"}, rows[3])
+	equal(t, []string{"Total", "6.5"}, rows[6])
+
+	m, ts, err := ReadWithMeta[reportMeta, *reportLineTmp](bytes.NewReader(buf.Bytes()), func(t *reportLineTmp) bool { return t.Item != "Total" })
+	equal(t, nil, err)
+	meta.Note = ""
+	equal(t, meta, m)
+	equal(t, lines, ts)
+
+	_, _, err = ReadWithMeta[badMeta, *reportLineTmp](bytes.NewReader(buf.Bytes()))
+	var fe FieldError
+	equal(t, true, errors.As(err, &fe))
+	equal(t, "A1", fe.CellRef)
+	_, _, err = ReadWithMeta[invalidRefMeta, *reportLineTmp](bytes.NewReader(buf.Bytes()))
+	equal(t, true, errors.Is(err, ErrInvalidCellRef))
+}
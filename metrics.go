@@ -0,0 +1,104 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	// Operation is either OpRead or OpWrite.
+	Operation string
+	// Phase is a step of an Operation, whose duration is measured.
+	Phase string
+	// Metrics receives measurements of reads and writes,
+	// see ReadConfig.Metrics and WriteConfig.Metrics.
+	// The methods map to counters and histograms of metric libraries like OpenTelemetry,
+	// with op and phase as attributes.
+	Metrics interface {
+		// AddRows counts the data rows read or written.
+		AddRows(op Operation, rows int)
+		// AddErrors counts the errors of an operation, one per FieldError.
+		AddErrors(op Operation, errors int)
+		// ObserveDuration measures how long a phase took.
+		ObserveDuration(op Operation, phase Phase, d time.Duration)
+	}
+	// MetricsFuncs adapts functions to Metrics, nil functions are skipped.
+	MetricsFuncs struct {
+		Rows     func(op Operation, rows int)
+		Errors   func(op Operation, errors int)
+		Duration func(op Operation, phase Phase, d time.Duration)
+	}
+)
+
+const (
+	OpRead  Operation = "read"
+	OpWrite Operation = "write"
+
+	// PhaseOpen
+	// Parsing the spreadsheet file
+	PhaseOpen Phase = "open"
+	// PhaseBind
+	// Binding the rows to records
+	PhaseBind Phase = "bind"
+	// PhaseWrite
+	// Converting the records to rows
+	PhaseWrite Phase = "write"
+	// PhaseSave
+	// Encoding the spreadsheet file
+	PhaseSave Phase = "save"
+)
+
+// AddRows implements Metrics.
+func (m MetricsFuncs) AddRows(op Operation, rows int) {
+	if m.Rows != nil {
+		m.Rows(op, rows)
+	}
+}
+
+// AddErrors implements Metrics.
+func (m MetricsFuncs) AddErrors(op Operation, errors int) {
+	if m.Errors != nil {
+		m.Errors(op, errors)
+	}
+}
+
+// ObserveDuration implements Metrics.
+func (m MetricsFuncs) ObserveDuration(op Operation, phase Phase, d time.Duration) {
+	if m.Duration != nil {
+		m.Duration(op, phase, d)
+	}
+}
+
+// measure returns a func which observes the duration of phase since the call of measure,
+// it is a no-op for nil metrics.
+func measure(m Metrics, op Operation, phase Phase) func() {
+	if m == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { m.ObserveDuration(op, phase, time.Since(start)) }
+}
+
+// countError counts a failed operation, a ContentError counts once per field error.
+func countError(m Metrics, op Operation, err error) {
+	if m == nil || err == nil {
+		return
+	}
+	var ce ContentError
+	if errors.As(err, &ce) && len(ce.FieldErrors) > 0 {
+		m.AddErrors(op, len(ce.FieldErrors))
+		return
+	}
+	m.AddErrors(op, 1)
+}
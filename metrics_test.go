@@ -0,0 +1,71 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testMetrics records the measurements as strings
+type testMetrics struct {
+	events []string
+}
+
+func (m *testMetrics) AddRows(op Operation, rows int)     { m.add("%s rows %d", op, rows) }
+func (m *testMetrics) AddErrors(op Operation, errors int) { m.add("%s errors %d", op, errors) }
+func (m *testMetrics) ObserveDuration(op Operation, phase Phase, d time.Duration) {
+	m.add("%s %s", op, phase)
+}
+func (m *testMetrics) add(format string, args ...any) {
+	m.events = append(m.events, fmt.Sprintf(format, args...))
+}
+
+var metricsTmpMetrics = &testMetrics{}
+
+type metricsTmp struct {
+	Qty int `excel:"Qty"`
+}
+
+func (*metricsTmp) ReadConfigure(rc *ReadConfig) {
+	rc.Metrics = metricsTmpMetrics
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+}
+func (*metricsTmp) WriteConfigure(wc *WriteConfig) { wc.Metrics = metricsTmpMetrics }
+
+func TestMetrics(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*metricsTmp{{1}, {2}, {3}}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = Read[*metricsTmp](bytes.NewReader(buf.Bytes()))
+	equal(t, []string{"write rows 3", "write write", "write save", "read open", "read bind", "read rows 3"}, metricsTmpMetrics.events)
+
+	metricsTmpMetrics.events = nil
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Qty"}, {"x"}, {"y"}})
+	_, _ = Read[*metricsTmp](buf)
+	equal(t, []string{"read open", "read bind", "read rows 2", "read errors 2"}, metricsTmpMetrics.events)
+
+	metricsTmpMetrics.events = nil
+	_, _ = ReadBinary[*metricsTmp]([]byte("no excel"))
+	equal(t, []string{"read open", "read errors 1"}, metricsTmpMetrics.events)
+
+	calls := 0
+	m := MetricsFuncs{Rows: func(Operation, int) { calls++ }}
+	m.AddRows(OpRead, 1)
+	m.AddErrors(OpRead, 1)
+	m.ObserveDuration(OpRead, PhaseBind, time.Second)
+	equal(t, 1, calls)
+}
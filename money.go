@@ -0,0 +1,143 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// Money is an amount of money in a currency.
+//
+// A Money field reads and writes one cell like "12.5 EUR", or with the currency tag option,
+// e.g. `excel:"Price,currency=Currency"`, the amount in the column of the field
+// and the currency code in the column named by the option, written right of it.
+type Money struct {
+	Amount float64
+	// The currency code, e.g. "EUR", "" if unknown.
+	Currency string
+}
+
+var moneyType = reflect.TypeOf(Money{})
+
+// String returns the amount followed by the currency code, e.g. "12.5 EUR".
+func (m Money) String() string {
+	amount := strconv.FormatFloat(m.Amount, 'f', -1, 64)
+	if m.Currency == "" {
+		return amount
+	}
+	return amount + " " + m.Currency
+}
+
+// MarshalExcel writes the amount as number, or followed by the currency code as text.
+func (m Money) MarshalExcel() (Cell, error) {
+	if m.Currency == "" {
+		return NumberCell(m.Amount), nil
+	}
+	return StringCell(m.String()), nil
+}
+
+// UnmarshalExcel reads an amount preceded or followed by a currency code, e.g. "12.5 EUR" or "EUR 12.5".
+func (m *Money) UnmarshalExcel(cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	value := strings.TrimSpace(cell.Value)
+	if value == "" {
+		*m = Money{}
+		return nil
+	}
+	amount, currency := value, ""
+	if i := strings.LastIndexFunc(value, unicode.IsSpace); i >= 0 {
+		switch first, last := value[:i], strings.TrimSpace(value[i:]); {
+		case isCurrencyCode(last):
+			amount, currency = strings.TrimSpace(first), last
+		case isCurrencyCode(strings.TrimSpace(first)):
+			amount, currency = last, strings.TrimSpace(first)
+		}
+	}
+	parsed := *cell
+	parsed.Value = amount
+	if err := UnmarshalFloat(reflect.ValueOf(&m.Amount).Elem(), &parsed, params); err != nil {
+		return err
+	}
+	m.Currency = currency
+	return nil
+}
+
+// isCurrencyCode reports whether s looks like an ISO 4217 code, three upper case letters
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// currencyColumn is the currency column of a Money field tagged with the currency option
+type currencyColumn struct {
+	fieldIndex int
+	// The tag name of the amount column
+	amountTag string
+}
+
+// currencyColumns maps the headers of the currency columns of typ to their Money fields
+func currencyColumns(typ reflect.Type, tagName string) map[string]currencyColumn {
+	var columns map[string]currencyColumn
+	for i := 0; i < typ.NumField(); i++ {
+		fe := typ.Field(i)
+		name, opts := parseTag(fe.Tag.Get(tagName))
+		if currency, ok := opts.Value("currency"); ok && fe.Type == moneyType {
+			if columns == nil {
+				columns = make(map[string]currencyColumn)
+			}
+			columns[currency] = currencyColumn{fieldIndex: i, amountTag: name}
+		}
+	}
+	return columns
+}
+
+// unmarshalAmount reads the amount column of a Money field tagged with the currency option
+func unmarshalAmount(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	if strings.TrimSpace(cell.Value) == "" {
+		destValue.Field(0).SetFloat(0)
+		return nil
+	}
+	return UnmarshalFloat(destValue.Field(0), cell, params)
+}
+
+// unmarshalCurrency reads the currency column of a Money field tagged with the currency option
+func unmarshalCurrency(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	destValue.Field(1).SetString(strings.TrimSpace(cell.Value))
+	return nil
+}
+
+// moneyColumnValue returns the amount or currency code of v written in col,
+// ok is false unless v is a Money field tagged with the currency option
+func moneyColumnValue(v reflect.Value, col writeColumn) (value any, ok bool) {
+	if v.Type() != moneyType {
+		return nil, false
+	}
+	m := v.Interface().(Money)
+	if col.currency {
+		return m.Currency, true
+	}
+	if _, ok = col.opts.Value("currency"); ok {
+		return m.Amount, true
+	}
+	return nil, false
+}
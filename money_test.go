@@ -0,0 +1,64 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type moneyTmp struct {
+	ID    int   `excel:"ID"`
+	Price Money `excel:"Price,currency=Currency"`
+	Fee   Money `excel:"Fee"`
+}
+
+func (*moneyTmp) ReadConfigure(*ReadConfig)   {}
+func (*moneyTmp) WriteConfigure(*WriteConfig) {}
+
+func TestMoneyUnmarshalExcel(t *testing.T) {
+	for value, expected := range map[string]Money{
+		"12.5 EUR": {12.5, "EUR"},
+		"USD -3":   {-3, "USD"},
+		"7":        {7, ""},
+		"":         {},
+	} {
+		var m Money
+		equal(t, nil, m.UnmarshalExcel(&xlsx.Cell{Value: value}, &ExcelUnmarshalParameters{}))
+		equal(t, expected, m)
+	}
+	var m Money
+	equal(t, true, m.UnmarshalExcel(&xlsx.Cell{Value: "12 euros"}, &ExcelUnmarshalParameters{}) != nil)
+	equal(t, "12.5 EUR", Money{12.5, "EUR"}.String())
+}
+
+func TestMoneyCurrencyColumn(t *testing.T) {
+	ts := []*moneyTmp{{1, Money{12.5, "EUR"}, Money{1, "USD"}}, {2, Money{3, "CHF"}, Money{}}}
+	plan, err := PlanWrite(ts, nil)
+	equal(t, nil, err)
+	equal(t, []string{"ID", "Price", "Currency", "Fee"}, plan.Sheets[0].Header)
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	read, err := ReadBinary[*moneyTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, ts, read)
+
+	// The currency column may precede the amount column
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Currency", "ID", "Price"}, {"EUR", "1", "9.99"}})
+	read, err = ReadBinary[*moneyTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*moneyTmp{{ID: 1, Price: Money{9.99, "EUR"}}}, read)
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPartSize is the part size of WriteObject, the minimum part size of S3 multipart uploads.
+const DefaultPartSize = 5 << 20
+
+// abortTimeout bounds aborting an upload, which can't use the context of the writer once it is cancelled
+const abortTimeout = time.Minute
+
+// MultipartUploader uploads an object in parts, e.g. an adapter of an S3 multipart upload
+// or a GCS resumable upload, so exports don't need a local temporary file.
+type MultipartUploader interface {
+	// UploadPart uploads the part with the 1-based number n, data must not be retained.
+	// All parts but the last one are of the same size.
+	UploadPart(ctx context.Context, n int, data []byte) error
+	// Complete finishes the upload after the last part.
+	Complete(ctx context.Context) error
+	// Abort discards the uploaded parts after an error.
+	Abort(ctx context.Context) error
+}
+
+// PartWriter buffers the bytes written to it into parts uploaded by a MultipartUploader,
+// e.g. to stream a StreamWriter or Writer to cloud storage.
+type PartWriter struct {
+	ctx  context.Context
+	up   MultipartUploader
+	buf  []byte
+	part int
+	err  error
+}
+
+// NewPartWriter returns a PartWriter uploading parts of partSize bytes, 0 for DefaultPartSize.
+// Close completes the upload.
+func NewPartWriter(ctx context.Context, up MultipartUploader, partSize int) *PartWriter {
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	return &PartWriter{ctx: ctx, up: up, buf: make([]byte, 0, partSize)}
+}
+
+// Write implements io.Writer, uploading each part once it is full.
+func (w *PartWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n := 0
+	for len(p) > 0 {
+		free := cap(w.buf) - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf = append(w.buf, p[:free]...)
+		p, n = p[free:], n+free
+		if len(w.buf) == cap(w.buf) {
+			if err := w.flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// flush uploads the buffered part
+func (w *PartWriter) flush() error {
+	if w.err = w.ctx.Err(); w.err == nil {
+		w.part++
+		w.err = w.up.UploadPart(w.ctx, w.part, w.buf)
+	}
+	w.buf = w.buf[:0]
+	return w.err
+}
+
+// Close uploads the last part and completes the upload, or aborts it if uploading a part or completing failed.
+func (w *PartWriter) Close() error {
+	if w.err == nil && (len(w.buf) > 0 || w.part == 0) {
+		_ = w.flush()
+	}
+	if w.err != nil {
+		return w.abort(w.err)
+	}
+	if err := w.up.Complete(w.ctx); err != nil {
+		return w.abort(err)
+	}
+	w.err = errClosed
+	return nil
+}
+
+// abort aborts the upload after err
+func (w *PartWriter) abort(err error) error {
+	if err == errClosed {
+		return err
+	}
+	w.err = errClosed
+	// The parts must be discarded even if writing failed because ctx was cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	if abortErr := w.up.Abort(ctx); abortErr != nil {
+		return fmt.Errorf("%w, aborting the upload failed: %v", err, abortErr)
+	}
+	return err
+}
+
+var errClosed = errors.New("exl: part writer closed")
+
+// WriteObject writes ts like WriteTo, uploading the file in parts of DefaultPartSize with up.
+// The upload is aborted if writing fails.
+func WriteObject[T WriteConfigurator](ctx context.Context, up MultipartUploader, ts []T) error {
+	w := NewPartWriter(ctx, up, 0)
+	if err := WriteTo(w, ts); err != nil {
+		return w.abort(err)
+	}
+	return w.Close()
+}
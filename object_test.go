@@ -0,0 +1,104 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type memUploader struct {
+	parts        [][]byte
+	failPart     int
+	failComplete bool
+	completed    bool
+	aborted      bool
+	abortErr     error
+}
+
+func (u *memUploader) UploadPart(_ context.Context, n int, data []byte) error {
+	if n == u.failPart {
+		return errors.New("connection reset")
+	}
+	u.parts = append(u.parts, append([]byte(nil), data...))
+	return nil
+}
+
+func (u *memUploader) Complete(context.Context) error {
+	if u.failComplete {
+		return errors.New("invalid part order")
+	}
+	u.completed = true
+	return nil
+}
+func (u *memUploader) Abort(ctx context.Context) error {
+	u.aborted, u.abortErr = true, ctx.Err()
+	return nil
+}
+
+func (u *memUploader) object() []byte { return bytes.Join(u.parts, nil) }
+
+func TestWriteObject(t *testing.T) {
+	ts := make([]*streamTmp, 0, 300)
+	for i := 0; i < 300; i++ {
+		ts = append(ts, &streamTmp{"item", i})
+	}
+	up := &memUploader{}
+	equal(t, nil, WriteObject(context.Background(), up, ts))
+	equal(t, true, up.completed)
+	read, err := ReadBinary[*streamTmp](up.object())
+	equal(t, nil, err)
+	equal(t, ts, read)
+
+	// Stream in parts of 4 KiB
+	up = &memUploader{}
+	w := NewPartWriter(context.Background(), up, 4096)
+	sw, _ := NewStreamWriter[*streamTmp](w)
+	equal(t, nil, sw.Write(ts...))
+	equal(t, nil, sw.Close())
+	equal(t, nil, w.Close())
+	equal(t, true, len(up.parts) > 1)
+	for _, part := range up.parts[:len(up.parts)-1] {
+		equal(t, 4096, len(part))
+	}
+	read, _ = ReadBinary[*streamTmp](up.object())
+	equal(t, ts, read)
+
+	up = &memUploader{failPart: 2}
+	w = NewPartWriter(context.Background(), up, 4096)
+	err = WriteTo(w, ts)
+	equal(t, true, strings.Contains(err.Error(), "connection reset"))
+	equal(t, "connection reset", w.Close().Error())
+	equal(t, true, up.aborted)
+	equal(t, false, up.completed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	up = &memUploader{}
+	equal(t, context.Canceled, WriteObject(ctx, up, ts))
+	equal(t, true, up.aborted)
+	equal(t, nil, up.abortErr)
+
+	// The parts are discarded if completing fails
+	up = &memUploader{failComplete: true}
+	equal(t, "invalid part order", WriteObject(context.Background(), up, ts).Error())
+	equal(t, true, up.aborted)
+	equal(t, false, up.completed)
+
+	// Empty objects are uploaded as one empty part
+	up = &memUploader{}
+	equal(t, nil, NewPartWriter(context.Background(), up, 0).Close())
+	equal(t, 1, len(up.parts))
+}
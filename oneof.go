@@ -0,0 +1,113 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotOneOf is matched by CategoryError with errors.Is.
+var ErrNotOneOf = errors.New("exl: value not one of the allowed values")
+
+// CategoryError aggregates the values of a categorical column outside its set,
+// tagged with the oneof option like `excel:"Status,oneof=open|closed"`,
+// so dirty files don't produce one FieldError per row.
+// It is the Err of a FieldError referring to the first offending cell.
+type CategoryError struct {
+	Allowed []string
+	// The distinct values outside of Allowed, in order of appearance.
+	Values []string
+	// The number of rows holding one of Values.
+	Rows int
+}
+
+// Error implements error.
+func (e *CategoryError) Error() string {
+	quoted := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%d rows hold values not one of %s: %s", e.Rows, strings.Join(e.Allowed, ", "), strings.Join(quoted, ", "))
+}
+
+// Is reports whether target is ErrNotOneOf.
+func (e *CategoryError) Is(target error) bool {
+	return target == ErrNotOneOf
+}
+
+// oneOf is the set of values of a field tagged with the oneof option
+type oneOf struct {
+	allowed []string
+	set     map[string]bool
+}
+
+// newOneOf returns the set of the oneof option of opts, separated by |, nil without oneof option
+func newOneOf(opts tagOptions) *oneOf {
+	values, ok := opts.Value("oneof")
+	if !ok {
+		return nil
+	}
+	o := &oneOf{allowed: strings.Split(values, "|"), set: map[string]bool{}}
+	for _, v := range o.allowed {
+		o.set[v] = true
+	}
+	return o
+}
+
+// categoryViolations collects the values of a column outside its oneof set
+type categoryViolations struct {
+	first FieldError
+	err   *CategoryError
+	seen  map[string]bool
+}
+
+// checkOneOf records value of the column of fi if it is outside its oneof set
+func (b *rowBinder) checkOneOf(fi fieldInfo, value string, fe FieldError) {
+	if b.rc.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" || fi.oneOf.set[value] {
+		return
+	}
+	v, ok := b.categories[fi.header]
+	if !ok {
+		v = &categoryViolations{first: fe, err: &CategoryError{Allowed: fi.oneOf.allowed}, seen: map[string]bool{}}
+		v.first.Err = v.err
+		if b.categories == nil {
+			b.categories = map[string]*categoryViolations{}
+		}
+		b.categories[fi.header] = v
+		b.categoryOrder = append(b.categoryOrder, fi.header)
+	}
+	v.err.Rows++
+	if !v.seen[value] {
+		v.seen[value] = true
+		v.err.Values = append(v.err.Values, value)
+	}
+}
+
+// categoryErrors returns a FieldError per column with values outside its oneof set,
+// or reports them as warnings with ReadConfig.WarnOneOf
+func (b *rowBinder) categoryErrors() []FieldError {
+	var errs []FieldError
+	for _, header := range b.categoryOrder {
+		v := b.categories[header]
+		if b.rc.WarnOneOf {
+			b.report.warn(Warning{Kind: WarningNotOneOf, RowIndex: -1, ColumnIndex: b.columnOffset + v.first.ColumnIndex, ColumnHeader: header, Message: v.err.Error()})
+			continue
+		}
+		errs = append(errs, v.first)
+	}
+	return errs
+}
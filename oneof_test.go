@@ -0,0 +1,62 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type oneOfTmp struct {
+	Status string  `excel:"Status,oneof=open|closed"`
+	Size   *string `excel:"Size,oneof=S|M|L"`
+}
+
+func (*oneOfTmp) ReadConfigure(rc *ReadConfig) { rc.TrimSpace = true }
+
+type warnOneOfTmp oneOfTmp
+
+func (*warnOneOfTmp) ReadConfigure(rc *ReadConfig) { rc.WarnOneOf = true }
+
+func TestReadOneOf(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Status", "Size"},
+		{"open", "S"},
+		{"Open", "XL"},
+		{"done", ""},
+		{" closed ", "M"},
+		{"Open", "XXL"},
+	})
+	data := buf.Bytes()
+
+	_, err := ReadBinary[*oneOfTmp](data)
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 2, len(ce.FieldErrors))
+	fe := ce.FieldErrors[0]
+	equal(t, "Status", fe.ColumnHeader)
+	equal(t, "A3", fe.CellRef)
+	equal(t, true, errors.Is(fe, ErrNotOneOf))
+	equal(t, &CategoryError{Allowed: []string{"open", "closed"}, Values: []string{"Open", "done"}, Rows: 3}, fe.Err)
+	equal(t, `error unmarshalling column "Status" in row 3: 3 rows hold values not one of open, closed: "Open", "done"`, fe.Error())
+	equal(t, &CategoryError{Allowed: []string{"S", "M", "L"}, Values: []string{"XL", "XXL"}, Rows: 2}, ce.FieldErrors[1].Err)
+
+	ts, report, err := ReadBinaryWithReport[*warnOneOfTmp](data)
+	equal(t, nil, err)
+	equal(t, 5, len(ts))
+	equal(t, []Warning{
+		{Kind: WarningNotOneOf, RowIndex: -1, ColumnIndex: 0, ColumnHeader: "Status", Message: `4 rows hold values not one of open, closed: "Open", "done", " closed "`},
+		{Kind: WarningNotOneOf, RowIndex: -1, ColumnIndex: 1, ColumnHeader: "Size", Message: `2 rows hold values not one of S, M, L: "XL", "XXL"`},
+	}, report.Warnings)
+}
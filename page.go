@@ -0,0 +1,57 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "io"
+
+// recordPage selects the records bound by bindSpreadsheet
+type recordPage struct {
+	offset, limit int
+	// The number of records of the sheet, set by bindSpreadsheet
+	total int
+}
+
+// contains reports whether the record at the 0-based index is bound, a nil page contains all records
+func (p *recordPage) contains(index int) bool {
+	return p == nil || index >= p.offset && index < p.offset+p.limit
+}
+
+// ReadPage binds the limit records starting at the 0-based record offset to `T`,
+// returning the total number of records of the sheet, e.g. for paginated viewers of uploaded workbooks.
+// The records outside of the page are counted, but not bound, so their errors aren't reported,
+// nor are their detail rows, see the sheet tag option.
+// rc replaces the read config of T, nil to use it.
+func ReadPage[T ReadConfigurator](reader io.Reader, rc *ReadConfig, offset, limit int) ([]T, int, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	if rc == nil {
+		rc = newReadConfig[T]()
+	}
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	page := &recordPage{offset: offset, limit: limit}
+	ts, err := readSpreadsheet[T](book, rc, nil, page, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ts, page.total, nil
+}
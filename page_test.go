@@ -0,0 +1,77 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+)
+
+type pageTmp struct {
+	ID   int    `excel:"ID"`
+	Name string `excel:"Name"`
+}
+
+func (*pageTmp) ReadConfigure(*ReadConfig)   {}
+func (*pageTmp) WriteConfigure(*WriteConfig) {}
+
+func TestReadPage(t *testing.T) {
+	records := make([]*pageTmp, 0, 25)
+	for i := 0; i < 25; i++ {
+		records = append(records, &pageTmp{i, "name"})
+	}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, records)
+	data := buf.Bytes()
+
+	ts, total, err := ReadPage[*pageTmp](bytes.NewReader(data), nil, 10, 10)
+	equal(t, nil, err)
+	equal(t, 25, total)
+	equal(t, records[10:20], ts)
+	ts, total, err = ReadPage[*pageTmp](bytes.NewReader(data), nil, 20, 10)
+	equal(t, nil, err)
+	equal(t, 25, total)
+	equal(t, records[20:], ts)
+	ts, total, err = ReadPage[*pageTmp](bytes.NewReader(data), nil, 30, 10)
+	equal(t, nil, err)
+	equal(t, 25, total)
+	equal(t, []*pageTmp{}, ts)
+
+	// Skipped rows aren't records
+	rc := &ReadConfig{TagName: "excel", DataStartRowIndex: 1, DataStartRelative: true, Backend: XLSXBackend{}}
+	rc.SkipRowsMatching = func(row *Row) bool { return row.Cell(0).Value == "3" }
+	ts, total, err = ReadPage[*pageTmp](bytes.NewReader(data), rc, 0, 3)
+	equal(t, nil, err)
+	equal(t, 24, total)
+	equal(t, []*pageTmp{records[0], records[1], records[2]}, ts)
+}
+
+func TestReadPageDetails(t *testing.T) {
+	masters := []*masterKeyTmp{
+		{No: "A-1", Lines: []lineTmp{{"apple", 2}}},
+		{No: "A-2", Lines: []lineTmp{{"pear", 1}, {"plum", 5}}},
+		{No: "A-3", Lines: []lineTmp{{"fig", 3}}},
+		{No: "A-4"},
+	}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, masters))
+	data := buf.Bytes()
+
+	ts, total, err := ReadPage[*masterKeyTmp](bytes.NewReader(data), nil, 1, 2)
+	equal(t, nil, err)
+	equal(t, 4, total)
+	equal(t, masters[1:3], ts)
+	ts, total, err = ReadPage[*masterKeyTmp](bytes.NewReader(data), nil, 2, 2)
+	equal(t, nil, err)
+	equal(t, 4, total)
+	equal(t, masters[2:], ts)
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"io"
+)
+
+type (
+	// WritePlan describes the workbook WriteTo would produce, see PlanWrite.
+	WritePlan struct {
+		// The sheets in workbook order.
+		Sheets []SheetPlan
+		// Whether the workbook uses the 1904 date system, see WriteConfig.Date1904.
+		Date1904 bool
+	}
+	// SheetPlan describes one written sheet.
+	SheetPlan struct {
+		Name string
+		// The header row, in column order.
+		Header []string
+		// The number of rows including the header row, and the number of columns.
+		Rows    int
+		Columns int
+		// The drop-down lists restricting the values of columns.
+		Validations []ValidationPlan
+		// Whether the sheet is hidden, like the audit sheet.
+		Hidden bool
+		// The column widths set by WriteConfig.ColumnLayout by column index, 0 for the default width.
+		ColumnWidths []float64
+	}
+	// ValidationPlan describes a drop-down list restricting the values of a column.
+	ValidationPlan struct {
+		// 0-based index and header of the column.
+		ColumnIndex  int
+		ColumnHeader string
+		// 0-based index of the first restricted row.
+		FirstRowIndex int
+		Values        []string
+		AllowBlank    bool
+	}
+)
+
+// PlanWrite computes the layout of the workbook written for ts without producing a file,
+// e.g. to verify the structure of a report in tests.
+// wc may be nil to use the write config of T.
+func PlanWrite[T WriteConfigurator](ts []T, wc *WriteConfig) (*WritePlan, error) {
+	if wc == nil {
+		wc = newWriteConfig[T]()
+	}
+	// Don't modify the config of the caller, nor report a dry run to its metrics
+	planWC := *wc
+	planWC.Metrics = nil
+	book := &planBook{}
+	if err := write0(book, ts, &planWC, nil); err != nil {
+		return nil, err
+	}
+	plan := &WritePlan{Sheets: make([]SheetPlan, 0, len(book.sheets)), Date1904: book.date1904}
+	for _, s := range book.sheets {
+		sp := s.plan
+		for i := range sp.Validations {
+			if v := &sp.Validations[i]; v.ColumnIndex < len(sp.Header) {
+				v.ColumnHeader = sp.Header[v.ColumnIndex]
+			}
+		}
+		plan.Sheets = append(plan.Sheets, sp)
+	}
+	return plan, nil
+}
+
+// Sheet returns the plan of the sheet named name, or nil.
+func (p *WritePlan) Sheet(name string) *SheetPlan {
+	for i := range p.Sheets {
+		if p.Sheets[i].Name == name {
+			return &p.Sheets[i]
+		}
+	}
+	return nil
+}
+
+// planBook is a Spreadsheet recording the layout of what is written to it
+type planBook struct {
+	sheets   []*planSheet
+	date1904 bool
+}
+
+type planSheet struct {
+	plan SheetPlan
+}
+
+func (b *planBook) sheet(sheet int) (*planSheet, error) {
+	if sheet < 0 || sheet >= len(b.sheets) {
+		return nil, ErrSheetNotFound
+	}
+	return b.sheets[sheet], nil
+}
+
+func (b *planBook) Sheets() []string {
+	names := make([]string, 0, len(b.sheets))
+	for _, s := range b.sheets {
+		names = append(names, s.plan.Name)
+	}
+	return names
+}
+
+func (b *planBook) Date1904() bool { return b.date1904 }
+
+func (b *planBook) SetDate1904(date1904 bool) error {
+	if len(b.sheets) > 0 {
+		return ErrDateSystemMismatch
+	}
+	b.date1904 = date1904
+	return nil
+}
+
+func (b *planBook) Dimension(sheet int) (rows, cols int, err error) {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return 0, 0, err
+	}
+	return s.plan.Rows, s.plan.Columns, nil
+}
+
+func (b *planBook) Rows(int, func(row *Row) error) error { return ErrUnsupported }
+
+func (b *planBook) AddSheet(name string) (int, error) {
+	b.sheets = append(b.sheets, &planSheet{plan: SheetPlan{Name: name}})
+	return len(b.sheets) - 1, nil
+}
+
+func (b *planBook) AppendRow(sheet int, row *Row) error {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	if s.plan.Rows == 0 {
+		s.plan.Header = make([]string, 0, len(row.Cells))
+		for _, c := range row.Cells {
+			s.plan.Header = append(s.plan.Header, c.Value)
+		}
+	}
+	s.plan.Rows++
+	if len(row.Cells) > s.plan.Columns {
+		s.plan.Columns = len(row.Cells)
+	}
+	return nil
+}
+
+func (b *planBook) Save(io.Writer) error { return ErrUnsupported }
+
+func (b *planBook) AddDropList(sheet, col, firstRow int, values []string, allowBlank bool, _ string) error {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	s.plan.Validations = append(s.plan.Validations, ValidationPlan{ColumnIndex: col, FirstRowIndex: firstRow, Values: values, AllowBlank: allowBlank})
+	return nil
+}
+
+func (b *planBook) SetTabColor(sheet int, rgb string) error {
+	if _, err := argbColor(rgb); err != nil {
+		return err
+	}
+	_, err := b.sheet(sheet)
+	return err
+}
+
+func (b *planBook) MoveSheet(sheet, position int) error {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	if _, err = b.sheet(position); err != nil {
+		return err
+	}
+	b.sheets = append(b.sheets[:sheet], b.sheets[sheet+1:]...)
+	b.sheets = append(b.sheets[:position], append([]*planSheet{s}, b.sheets[position:]...)...)
+	return nil
+}
+
+func (b *planBook) SetActiveSheet(sheet int) error {
+	_, err := b.sheet(sheet)
+	return err
+}
+
+func (b *planBook) SetCompression(int, []string) error { return nil }
+
+func (b *planBook) SetColumnWidth(sheet, col int, width float64) error {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	for len(s.plan.ColumnWidths) <= col {
+		s.plan.ColumnWidths = append(s.plan.ColumnWidths, 0)
+	}
+	s.plan.ColumnWidths[col] = width
+	return nil
+}
+
+func (b *planBook) HideSheet(sheet int) error {
+	s, err := b.sheet(sheet)
+	if err != nil {
+		return err
+	}
+	s.plan.Hidden = true
+	return nil
+}
+
+func (b *planBook) SetGroupSummaryBelow(sheet int, _ bool) error {
+	_, err := b.sheet(sheet)
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "testing"
+
+type planTmp struct {
+	Name   string     `excel:"Name"`
+	Active *bool      `excel:"Active"`
+	Lines  []*lineTmp `excel:"Lines,sheet"`
+}
+
+func (*planTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "Orders" }
+
+func TestPlanWrite(t *testing.T) {
+	records := []*planTmp{
+		{Name: "Ann", Lines: []*lineTmp{{"apple", 2}, {"pear", 1}}},
+		{Name: "Bob", Lines: []*lineTmp{{"plum", 5}}},
+	}
+	plan, err := PlanWrite(records, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []SheetPlan{
+		{
+			Name:    "Orders",
+			Header:  []string{"#", "Name", "Active"},
+			Rows:    3,
+			Columns: 3,
+			Validations: []ValidationPlan{
+				{ColumnIndex: 2, ColumnHeader: "Active", FirstRowIndex: 1, Values: []string{"TRUE", "FALSE"}, AllowBlank: true},
+			},
+		},
+		{Name: "Lines", Header: []string{"#", "SKU", "Qty"}, Rows: 4, Columns: 3},
+	}, plan.Sheets)
+	equal(t, 4, plan.Sheet("Lines").Rows)
+	equal(t, (*SheetPlan)(nil), plan.Sheet("Missing"))
+
+	wc := newWriteConfig[*planTmp]()
+	wc.SheetPosition = 1
+	if plan, err = PlanWrite(records, wc); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "Lines", plan.Sheets[0].Name)
+	equal(t, "Orders", plan.Sheets[1].Name)
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"io"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// Preview returns the cell values of the first n rows of the sheet at sheetIndex,
+// e.g. to let users map the columns of an upload.
+// Only the first n rows of each sheet are loaded, rows missing in the sheet are returned as empty slices.
+// Like with Inspect, readers implementing io.ReaderAt and io.Seeker are not read into memory
+// and are left at their offset.
+func Preview(reader io.Reader, sheetIndex, n int) ([][]string, error) {
+	ra, size, err := readerAt(reader)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return [][]string{}, nil
+	}
+	f, err := xlsx.OpenReaderAt(ra, size, xlsx.RowLimit(n))
+	if err != nil {
+		return nil, err
+	}
+	book := &xlsxSpreadsheet{file: f}
+	if sheetIndex < 0 || sheetIndex > len(book.Sheets())-1 {
+		return nil, ErrSheetIndexOutOfRange
+	}
+	rows := make([][]string, 0, n)
+	err = book.Rows(sheetIndex, func(row *Row) error {
+		if row.Index >= n {
+			return errStopRows
+		}
+		for len(rows) < row.Index {
+			rows = append(rows, []string{})
+		}
+		rows = append(rows, row.Strings())
+		return nil
+	})
+	if err != nil && err != errStopRows {
+		return nil, err
+	}
+	return rows, nil
+}
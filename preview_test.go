@@ -0,0 +1,47 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Qty"}, {}, {"apple", "2"}, {"pear", "3"}})
+	data := buf.Bytes()
+
+	rows, err := Preview(bytes.NewReader(data), 0, 3)
+	equal(t, nil, err)
+	equal(t, 3, len(rows))
+	equal(t, []string{"apple", "2"}, rows[2])
+
+	rows, err = Preview(bytes.NewReader(data), 0, 10)
+	equal(t, nil, err)
+	equal(t, 4, len(rows))
+
+	rows, err = Preview(bytes.NewReader(data), 0, 0)
+	equal(t, nil, err)
+	equal(t, [][]string{}, rows)
+
+	_, err = Preview(bytes.NewReader(data), 1, 3)
+	equal(t, ErrSheetIndexOutOfRange, err)
+
+	// The reader can be read after previewing it
+	r := bytes.NewReader(data)
+	_, err = Preview(r, 0, 1)
+	equal(t, nil, err)
+	rows, err = Preview(r, 0, 1)
+	equal(t, nil, err)
+	equal(t, [][]string{{"Name", "Qty"}}, rows)
+}
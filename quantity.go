@@ -0,0 +1,113 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
+)
+
+// ErrNoQuantity is returned for quantity texts not starting with a number.
+var ErrNoQuantity = errors.New("exl: no quantity")
+
+type (
+	// Quantity is a value in a unit, read from cells like "12 kg", "3.5 m" or "500ml",
+	// see ReadConfig.UnitParser and ReadConfig.UnitConversions.
+	Quantity struct {
+		Value float64
+		// The unit, e.g. "kg", "" for plain numbers.
+		Unit string
+	}
+	// UnitParser parses the text of a Quantity cell, see ReadConfig.UnitParser.
+	UnitParser func(text string) (Quantity, error)
+	// UnitConversion normalizes a unit, see ReadConfig.UnitConversions.
+	UnitConversion struct {
+		// The normalized unit.
+		Unit string
+		// The factor converting values to the normalized unit.
+		Factor float64
+	}
+)
+
+// ParseQuantity parses a number followed by an optional unit, with or without space in between,
+// e.g. "12 kg", "3.5 m" or "500ml".
+func ParseQuantity(text string) (Quantity, error) {
+	return parseQuantity(text, language.Und)
+}
+
+// parseQuantity parses text like ParseQuantity, the number in the syntax of locale tag
+func parseQuantity(text string, tag language.Tag) (Quantity, error) {
+	text = strings.TrimSpace(text)
+	// The longest number prefix
+	for i := len(text); i > 0; i-- {
+		number := strings.TrimSpace(text[:i])
+		if tag != language.Und {
+			number = delocalizeNumber(number, tag)
+		}
+		if v, err := strconv.ParseFloat(number, 64); err == nil {
+			return Quantity{Value: v, Unit: strings.TrimSpace(text[i:])}, nil
+		}
+	}
+	return Quantity{}, fmt.Errorf("%w in %q", ErrNoQuantity, text)
+}
+
+// String returns the value followed by the unit, e.g. "12 kg".
+func (q Quantity) String() string {
+	value := strconv.FormatFloat(q.Value, 'f', -1, 64)
+	if q.Unit == "" {
+		return value
+	}
+	return value + " " + q.Unit
+}
+
+// Convert returns q in the normalized unit of its conversion in conversions, if any.
+func (q Quantity) Convert(conversions map[string]UnitConversion) Quantity {
+	if c, ok := conversions[q.Unit]; ok {
+		return Quantity{Value: q.Value * c.Factor, Unit: c.Unit}
+	}
+	return q
+}
+
+// MarshalExcel writes the value as number, or followed by the unit as text.
+func (q Quantity) MarshalExcel() (Cell, error) {
+	if q.Unit == "" {
+		return NumberCell(q.Value), nil
+	}
+	return StringCell(q.String()), nil
+}
+
+// UnmarshalExcel reads the cell with ReadConfig.UnitParser, normalizing the unit with ReadConfig.UnitConversions.
+func (q *Quantity) UnmarshalExcel(cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	if strings.TrimSpace(cell.Value) == "" {
+		*q = Quantity{}
+		return nil
+	}
+	var parsed Quantity
+	var err error
+	if params.UnitParser != nil {
+		parsed, err = params.UnitParser(cell.Value)
+	} else if cell.Type() == xlsx.CellTypeNumeric {
+		parsed, err = ParseQuantity(cell.Value)
+	} else {
+		parsed, err = parseQuantity(cell.Value, params.Locale)
+	}
+	if err != nil {
+		return err
+	}
+	*q = parsed.Convert(params.UnitConversions)
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+type quantityTmp struct {
+	Item   string    `excel:"Item"`
+	Weight Quantity  `excel:"Weight"`
+	Volume *Quantity `excel:"Volume"`
+}
+
+func (*quantityTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnitConversions = map[string]UnitConversion{"g": {"kg", 0.001}, "ml": {"l", 0.001}}
+}
+
+func (*quantityTmp) WriteConfigure(*WriteConfig) {}
+
+func TestParseQuantity(t *testing.T) {
+	for text, expected := range map[string]Quantity{
+		"12 kg":  {12, "kg"},
+		"3.5 m":  {3.5, "m"},
+		"500ml":  {500, "ml"},
+		" -2 °C": {-2, "°C"},
+		"1e3 g":  {1000, "g"},
+		"7":      {7, ""},
+	} {
+		q, err := ParseQuantity(text)
+		equal(t, nil, err)
+		equal(t, expected, q)
+	}
+	_, err := ParseQuantity("kg 12")
+	equal(t, true, errors.Is(err, ErrNoQuantity))
+	q, err := parseQuantity("1.234,5 kg", language.German)
+	equal(t, nil, err)
+	equal(t, Quantity{1234.5, "kg"}, q)
+}
+
+func TestReadQuantity(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Item", "Weight", "Volume"},
+		{"a", "12 kg", "500ml"},
+		{"b", "250g", "1.5 l"},
+	})
+	ts, err := ReadBinary[*quantityTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*quantityTmp{
+		{"a", Quantity{12, "kg"}, &Quantity{0.5, "l"}},
+		{"b", Quantity{0.25, "kg"}, &Quantity{1.5, "l"}},
+	}, ts)
+
+	// A custom parser, e.g. for units in front
+	rc := newReadConfig[*quantityTmp]()
+	rc.UnitParser = func(text string) (Quantity, error) {
+		if unit, value, ok := strings.Cut(text, " "); ok {
+			return ParseQuantity(value + unit)
+		}
+		return ParseQuantity(text)
+	}
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Item", "Weight"}, {"a", "kg 12"}})
+	ts, err = ReadColumns[*quantityTmp](bytes.NewReader(buf.Bytes()), rc, []string{"Item", "Weight"})
+	equal(t, nil, err)
+	equal(t, []*quantityTmp{{Item: "a", Weight: Quantity{12, "kg"}}}, ts)
+
+	buf.Reset()
+	equal(t, nil, WriteTo(buf, []*quantityTmp{{"a", Quantity{12, "kg"}, nil}}))
+	ts, err = ReadBinary[*quantityTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, Quantity{12, "kg"}, ts[0].Weight)
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// cellRange is a rectangular block of cells, all indexes 0-based and inclusive
+type cellRange struct {
+	top, left, bottom, right int
+}
+
+// parseRange parses an A1 style range like "B3:H500"
+func parseRange(ref string) (cellRange, error) {
+	from, to, ok := strings.Cut(ref, ":")
+	if !ok {
+		return cellRange{}, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	var r cellRange
+	var err error
+	if r.top, r.left, err = ParseCellRef(from); err != nil {
+		return cellRange{}, err
+	}
+	if r.bottom, r.right, err = ParseCellRef(to); err != nil {
+		return cellRange{}, err
+	}
+	if r.bottom < r.top || r.right < r.left {
+		return cellRange{}, fmt.Errorf("%w: %q", ErrInvalidCellRef, ref)
+	}
+	return r, nil
+}
+
+// rangeSpreadsheet restricts the rows of a sheet to a cell range,
+// cells are shifted so the left column of the range has index 0.
+// Rows keep their index within the sheet.
+type rangeSpreadsheet struct {
+	Spreadsheet
+	sheet int
+	r     cellRange
+}
+
+func (s *rangeSpreadsheet) Dimension(sheet int) (rows, cols int, err error) {
+	rows, _, err = s.Spreadsheet.Dimension(sheet)
+	if err != nil || sheet != s.sheet {
+		return rows, cols, err
+	}
+	if rows > s.r.bottom+1 {
+		rows = s.r.bottom + 1
+	}
+	return rows, s.r.right - s.r.left + 1, nil
+}
+
+func (s *rangeSpreadsheet) Rows(sheet int, fn func(row *Row) error) error {
+	if sheet != s.sheet {
+		return s.Spreadsheet.Rows(sheet, fn)
+	}
+	err := s.Spreadsheet.Rows(sheet, func(row *Row) error {
+		if row.Index < s.r.top {
+			return nil
+		}
+		if row.Index > s.r.bottom {
+			return errStopRows
+		}
+		cropped := &Row{Index: row.Index, OutlineLevel: row.OutlineLevel, Cells: make([]Cell, s.r.right-s.r.left+1)}
+		for i := range cropped.Cells {
+			cropped.Cells[i] = row.Cell(s.r.left + i)
+		}
+		if row.src != nil {
+			cropped.src = make([]*xlsx.Cell, len(cropped.Cells))
+			for i := range cropped.src {
+				if col := s.r.left + i; col < len(row.src) {
+					cropped.src[i] = row.src[col]
+				}
+			}
+		}
+		return fn(cropped)
+	})
+	if err == errStopRows {
+		return nil
+	}
+	return err
+}
+
+// ReadRange binds the rows of a cell range like "B3:H500" to `T`,
+// for data blocks surrounded by other content.
+// The first row of the range holds the headers, column indexes of errors are relative to the range.
+// The range applies to the sheet at rc.SheetIndex, HeaderRowIndex and DataStartRowIndex of rc are ignored.
+// If rc is nil, the read config of T is used.
+func ReadRange[T ReadConfigurator](reader io.Reader, ref string, rc *ReadConfig, filterFunc ...func(t T) (add bool)) ([]T, error) {
+	r, err := parseRange(ref)
+	if err != nil {
+		return nil, err
+	}
+	if rc == nil {
+		rc = newReadConfig[T]()
+	}
+	// Don't modify the config of the caller
+	rangeRC := *rc
+	rangeRC.HeaderRowIndex = r.top
+	rangeRC.DataStartRowIndex = r.top + 1
+	rangeRC.DataStartRelative = false
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	book, err := openBook(&rangeRC, bs)
+	if err != nil {
+		return nil, err
+	}
+	return readSpreadsheet(&rangeSpreadsheet{Spreadsheet: book, sheet: rangeRC.SheetIndex, r: r}, &rangeRC, nil, nil, nil, filterFunc...)
+}
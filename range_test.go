@@ -0,0 +1,72 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type rangeTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*rangeTmp) ReadConfigure(rc *ReadConfig) { rc.SkipUnknownColumns = false }
+
+func TestReadRange(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Report", "", "", ""},
+		{"", "", "", ""},
+		{"note", "Name", "Qty", "x"},
+		{"", "apple", "2", "x"},
+		{"", "pear", "3", "x"},
+		{"Total", "", "5", ""},
+	})
+	data := buf.Bytes()
+
+	ts, err := ReadRange[*rangeTmp](bytes.NewReader(data), "B3:C5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []*rangeTmp{{"apple", 2}, {"pear", 3}}, ts)
+
+	// a range beyond the last row reads up to it
+	ts, err = ReadRange[*rangeTmp](bytes.NewReader(data), "B3:C500", &ReadConfig{TagName: "excel", SkipUnknownColumns: true, Backend: XLSXBackend{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, 3, len(ts))
+
+	_, err = ReadRange[*rangeTmp](bytes.NewReader(data), "B3:D5", nil)
+	if !errors.Is(err, ErrNoDestinationField) {
+		t.Errorf("expected ErrNoDestinationField, got %v", err)
+	}
+	for _, ref := range []string{"B3", "C5:B3", "B3:"} {
+		if _, err = ReadRange[*rangeTmp](bytes.NewReader(data), ref, nil); !errors.Is(err, ErrInvalidCellRef) {
+			t.Errorf("expected ErrInvalidCellRef for %q, got %v", ref, err)
+		}
+	}
+}
+
+func TestReadRangeCellRef(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"", "", ""}, {"", "Name", "Qty"}, {"", "apple", "many"}})
+	_, err := ReadRange[*rangeTmp](buf, "B2:C3", nil)
+	var fe FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected FieldError, got %v", err)
+	}
+	equal(t, "C3", fe.CellRef)
+}
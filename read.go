@@ -16,12 +16,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
 )
 
 type (
@@ -40,6 +41,11 @@ type (
 		// The header row counts as row.
 		// Zero-based, defaults to 1.
 		DataStartRowIndex int
+		// Interpret DataStartRowIndex as the number of rows after HeaderRowIndex,
+		// so moving the header row moves the start of the data along,
+		// e.g. 1 starts reading right below the header row.
+		// Defaults to false.
+		DataStartRelative bool
 		// Configure the default string unmarshaler to trim space after reading a cell.
 		// Does not impact any other default unmarshaler,
 		// but is available to custom unmarshalers via ExcelUnmarshalParameters.TrimSpace.
@@ -53,6 +59,40 @@ type (
 		// the raw cell value into a date.
 		// There are no fallback formats configured by default.
 		FallbackDateFormats []string
+		// The locale of the texts of numbers and dates, e.g. language.German,
+		// reading "1.234,56" as float 1234.56 like written with WriteConfig.Locale,
+		// and its month names like "31. Dezember 2024" as English ones,
+		// so FallbackDateFormats like "2. January 2006" parse them.
+		// Numeric cells are read as they are.
+		// Defaults to language.Und, reading numbers like strconv.ParseFloat.
+		Locale language.Tag
+		// Parses the text of Quantity fields like "12 kg", e.g. to accept other notations.
+		// Defaults to nil, ParseQuantity.
+		UnitParser UnitParser
+		// Normalize the units of Quantity fields by the unit parsed, e.g. {"g": {"kg", 0.001}}
+		// reading "500g" as 0.5 kg.
+		// Defaults to nil, keeping the units parsed.
+		UnitConversions map[string]UnitConversion
+		// Bind the fields tagged with the combine option, e.g. `excel:"Start,combine=Date|Time"`,
+		// from the cells of their columns by tag name, e.g. CombineDateTime.
+		// Defaults to none, unmarshalling the non-empty values joined by spaces like a single cell.
+		Combiners map[string]CombineFunc
+		// The headers of the columns whose values must be unique, like those tagged with the unique option,
+		// e.g. `excel:"Email,unique"`. Duplicates are reported as FieldError with a DuplicateError,
+		// empty cells aren't checked.
+		// Defaults to none.
+		UniqueColumns []string
+		// The known values of columns by header, e.g. customer codes loaded from a database,
+		// reporting other values as FieldError matching ErrUnknownReference, empty cells aren't checked.
+		// Defaults to none.
+		ReferenceSets map[string]Set
+		// Require the cells of columns depending on the values of other columns in the same row,
+		// like the requiredif tag option, reporting empty cells as FieldError matching ErrRequired.
+		// Defaults to none.
+		RequiredRules []RequiredRule
+		// The text of booleans written with WriteConfig.BoolFormat, read besides TRUE and FALSE.
+		// Defaults to none.
+		BoolFormat BoolFormat
 		// Skip reading columns for which no target field is found.
 		// Defaults to true.
 		SkipUnknownColumns bool
@@ -73,6 +113,10 @@ type (
 		// Configure a limit of 0 to collect all errors, without upper limit.
 		// Defaults to 10.
 		MaxUnmarshalErrors uint64
+		// Keep reading past MaxUnmarshalErrors, only counting the further errors and the rows failing,
+		// so ContentError.TotalErrors and ContentError.FailedRows tell e.g. "14322 rows failed".
+		// Defaults to false, aborting at the limit.
+		CountErrorsPastLimit bool
 		// Parse data from key.
 		DropListMap map[string][]struct {
 			Key   string
@@ -80,17 +124,154 @@ type (
 		}
 		// Set pointer struct field to nil when read empty string.
 		PointerCanNil bool
+		// The text read as nil pointer, see WriteConfig.NilPlaceholder,
+		// overridden per field by the nilas tag option.
+		// Defaults to "", none.
+		NilPlaceholder string
+		// The backend used to open the spreadsheet.
+		// Defaults to XLSXBackend.
+		Backend SpreadsheetBackend
+		// Header of the column holding the hierarchy level of rows,
+		// for types with a children field, see WriteConfig.LevelColumn.
+		// Defaults to "", rows without values in the parent columns are child rows.
+		LevelColumn string
+		// Header of the generated key column linking detail sheets to their records,
+		// for types without key field, see WriteConfig.KeyColumn.
+		// Defaults to "#".
+		KeyColumn string
+		// Configure how columns with a blank header cell are named,
+		// so they can be bound by tag like any other column.
+		// Defaults to BlankHeaderKeep.
+		BlankHeaders BlankHeaderPolicy
+		// Keep the blank header cells after the last named column,
+		// which are otherwise dropped as the sheet dimension often exceeds the data
+		// due to formatted but empty cells.
+		// Blank headers named by BlankHeaders are always kept.
+		// Defaults to false.
+		KeepTrailingBlankHeaders bool
+		// Transformers applied in order to the raw value of every cell before unmarshalling,
+		// e.g. TransformStripInvisible to remove zero-width characters from copy-pasted data.
+		// Defaults to none.
+		Transformers []Transformer
+		// Transformers applied to the columns of the given headers, after Transformers.
+		// Defaults to none.
+		ColumnTransformers map[string][]Transformer
+		// Unmarshal functions for the columns of the given headers, i.e. field tags,
+		// used instead of the unmarshal function of the field type,
+		// e.g. to parse "1,234 kg" into a float64 field.
+		// Pointer fields are allocated, the function sets the value pointed to.
+		// Defaults to none.
+		FieldUnmarshalers map[string]UnmarshalExcelFunc
+		// Number of non-empty rows at the bottom of the sheet which aren't records,
+		// e.g. totals, skipped along with empty rows between them.
+		// Defaults to 0.
+		SkipFooterRows int
+		// Reports whether a row starts the footer of the sheet,
+		// which is skipped with all rows below it, e.g. FooterLabels("Total").
+		// Defaults to nil.
+		FooterDetector func(row *Row) bool
+		// Reports whether a row below the header isn't a record, e.g. repeated titles,
+		// timestamps or disclaimers between the records, which is skipped.
+		// Use MatchRow to skip rows matching a regular expression.
+		// Defaults to nil.
+		SkipRowsMatching func(row *Row) bool
+		// The minimum number of records a sheet must hold, before filtering,
+		// ErrTooFewRows is returned otherwise, e.g. 1 to catch reading the wrong sheet or header row.
+		// Defaults to 0.
+		MinRows int
+		// Allocate records in blocks of this many and reuse the *xlsx.Cell passed to unmarshal functions,
+		// which then must not retain it, reducing allocations and GC pressure for large reads.
+		// The records of a block are only garbage collected together.
+		// Defaults to 0, allocating every record on its own.
+		RecordBlockSize int
+		// Bind every row into one reused record for the filter funcs and copy only the records they keep,
+		// cutting allocations of selective reads. The filter funcs then must not retain the record passed.
+		// Ignored for types with child rows or detail sheets, as their filter funcs see the complete record.
+		// Defaults to false, binding every row into a record of its own.
+		ReuseFilteredRecords bool
+		// Open the file without parsing its styles, themes and number formats,
+		// cutting the open time of heavily formatted files when only the values are needed.
+		// Cells are read as stored then, e.g. a date into a string field as its serial number,
+		// while time.Time fields still read serial numbers as dates.
+		// Ignored if the Backend doesn't implement ValuesOpener.
+		// Defaults to false.
+		ValuesOnly bool
+		// The maximum size of files downloaded by ReadURL in bytes.
+		// Defaults to 0, limiting downloads to DefaultMaxDownloadSize.
+		MaxDownloadSize int64
+		// The number of times ReadURL resumes or restarts an interrupted download,
+		// or retries after a server error.
+		// Defaults to 0, failing on the first error.
+		DownloadRetries int
+		// Verify the signature of workbooks written with this WriteConfig.SigningKey before reading them,
+		// failing with ErrSignatureMismatch for modified and ErrNotSigned for unsigned workbooks, see Verify.
+		// Defaults to nil, not verifying.
+		VerifyKey []byte
+		// Fail with a *TemplateVersionError before reading workbooks of another WriteConfig.TemplateVersion,
+		// e.g. templates downloaded before a column was added, asking the user to download the latest template.
+		// Defaults to "", reading workbooks of any version.
+		ExpectedTemplateVersion string
+		// Report ErrPrecisionLost for numeric cells read into string or integer fields,
+		// if the number probably lost digits, see PrecisionLost.
+		// Bind a field of type Cell to access the stored value and number format instead.
+		// Defaults to false.
+		DetectPrecisionLoss bool
+		// Read text which is no plain number into number fields, if it is one
+		// with thousands separators like "1,234", or a percentage like "12.5%" for float fields, read as 0.125.
+		// Every coerced cell is reported as WarningCoerced by ReadWithReport,
+		// to quantify dirty inputs.
+		// Defaults to false, such text is an unmarshal error.
+		CoerceNumbers bool
+		// Report the values of columns outside the set of their oneof tag option,
+		// like `excel:"Status,oneof=open|closed"`, as one WarningNotOneOf per column
+		// instead of a CategoryError.
+		// Defaults to false, reading fails with a ContentError holding a FieldError per column,
+		// which aggregates the distinct offending values.
+		WarnOneOf bool
+		// Renders the FieldError and ContentError returned by reading,
+		// e.g. in the language of the end user.
+		// Defaults to nil, rendering English messages as DefaultErrorFormatter does.
+		ErrorFormatter ErrorFormatter
+		// Summarize the FieldErrors of a ContentError by column and error kind, see ContentError.Groups:
+		// its message lists the groups with their count and first and last row,
+		// and its ErrorReport holds ErrorReport.Groups and only the first error of each group.
+		// Defaults to false, reporting every field error.
+		GroupErrors bool
+		// Receives the number of rows read, errors and phase durations.
+		// Defaults to nil.
+		Metrics Metrics
+
+		// The options of the Sheet tag of the type, kept by Inherit
+		sheetTag tagOptions
+		// The invalid option of the Sheet tag, returned by Validate
+		tagErr error
+		// The tags of the columns bound by ReadColumns, nil to bind all
+		columns map[string]bool
 	}
 	UnmarshalErrorHandling uint8
+	BlankHeaderPolicy      uint8
 	FieldError             struct {
 		RowIndex     int // 0-based row index. Printed as 1-based row number in error text.
 		ColumnIndex  int // 0-based column index.
 		ColumnHeader string
+		CellRef      string // A1 style reference of the cell, e.g. "C17".
+		Value        string // The cell value as read.
+		ExpectedType string // The Go type of the destination field, e.g. "int".
 		Err          error
+		// SeverityWarning for errors marked with Warn, reported in ReadReport.FieldWarnings.
+		Severity  Severity
+		formatter ErrorFormatter
 	}
 	ContentError struct {
 		FieldErrors  []FieldError
 		LimitReached bool
+		// With ReadConfig.CountErrorsPastLimit, the number of errors including those beyond FieldErrors,
+		// and the number of rows with errors, else 0.
+		TotalErrors int
+		FailedRows  int
+		formatter   ErrorFormatter
+		// Summarize the errors by group, see ReadConfig.GroupErrors
+		grouped bool
 	}
 )
 
@@ -107,7 +288,10 @@ var (
 
 // Error implements error.
 func (e FieldError) Error() string {
-	return fmt.Sprintf("error unmarshalling column \"%s\" in row %d: %s", e.ColumnHeader, e.RowIndex+1, e.Err.Error())
+	if e.formatter != nil {
+		return e.formatter.FormatFieldError(e)
+	}
+	return DefaultErrorFormatter{}.FormatFieldError(e)
 }
 
 // Unwrap
@@ -118,11 +302,10 @@ func (e FieldError) Unwrap() error {
 
 // Error implements error.
 func (e ContentError) Error() string {
-	if e.LimitReached {
-		return fmt.Sprintf("too many (%d) errors reading data from Excel", len(e.FieldErrors))
-	} else {
-		return fmt.Sprintf("%d errors reading data from Excel", len(e.FieldErrors))
+	if e.formatter != nil {
+		return e.formatter.FormatContentError(e)
 	}
+	return DefaultErrorFormatter{}.FormatContentError(e)
 }
 
 // Unwrap
@@ -149,32 +332,42 @@ const (
 	UnmarshalErrorCollect
 )
 
+const (
+	// BlankHeaderKeep
+	// Keep blank headers empty
+	BlankHeaderKeep BlankHeaderPolicy = iota
+	// BlankHeaderNumber
+	// Name columns with a blank header by their 1-based column number, e.g. "Column_3"
+	BlankHeaderNumber
+	// BlankHeaderLetter
+	// Name columns with a blank header by their column letters, e.g. "C"
+	BlankHeaderLetter
+)
+
 var (
-	defaultReadConfig = func() *ReadConfig {
-		return &ReadConfig{
-			TagName:                "excel",
-			DataStartRowIndex:      1,
-			SkipUnknownColumns:     true,
-			UnmarshalErrorHandling: UnmarshalErrorAbort,
-			MaxUnmarshalErrors:     10,
-		}
-	}
 	ErrSheetIndexOutOfRange        = errors.New("exl: sheet index out of range")
 	ErrHeaderRowIndexOutOfRange    = errors.New("exl: header row index out of range")
 	ErrDataStartRowIndexOutOfRange = errors.New("exl: data start row index out of range")
 	ErrNoUnmarshaler               = errors.New("no unmarshaler")
 	ErrNoDestinationField          = errors.New("no destination field with matching tag")
+	ErrOrphanChildRow              = errors.New("exl: child row without parent row")
+	ErrPrecisionLost               = errors.New("exl: number probably lost digits")
+	ErrTooFewRows                  = errors.New("exl: too few rows")
 )
 
-func readStrings(maxCol int, row *xlsx.Row) []string {
-	ls := make([]string, maxCol)
-	for i := 0; i < maxCol; i++ {
-		ls[i] = row.GetCell(i).Value
+func GetUnmarshalFunc(destField reflect.Value) UnmarshalExcelFunc {
+	// Pointers are unmarshalled like the type they point to, into a newly allocated value,
+	// so pointer fields behave the same as value fields for all types.
+	if destField.Kind() == reflect.Ptr {
+		elemFunc := GetUnmarshalFunc(reflect.New(destField.Type().Elem()).Elem())
+		if elemFunc == nil {
+			return nil
+		}
+		return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+			return unmarshalPointer(destValue, cell, params, elemFunc)
+		}
 	}
-	return ls
-}
 
-func GetUnmarshalFunc(destField reflect.Value) UnmarshalExcelFunc {
 	if destField.CanInterface() {
 
 		inf := getFieldInterface(destField)
@@ -189,6 +382,9 @@ func GetUnmarshalFunc(destField reflect.Value) UnmarshalExcelFunc {
 			if destField.Type() == reflect.TypeOf(time.Time{}) {
 				return UnmarshalTime
 			}
+			if destField.Type() == reflect.TypeOf(Cell{}) {
+				return UnmarshalCell
+			}
 
 			// Then utilize TextUnmarshaler, e.g. for things like decimal.Decimal
 			if _, ok := inf.(encoding.TextUnmarshaler); ok {
@@ -199,20 +395,8 @@ func GetUnmarshalFunc(destField reflect.Value) UnmarshalExcelFunc {
 	}
 
 	// And for primitive types, use custom unmarshalling func
-	kind := destField.Type().Kind()
-	isPointer := false
-	if kind == reflect.Ptr {
-		kind = destField.Type().Elem().Kind()
-		isPointer = true
-	}
-	unmarshalFunc, ok := DefaultUnmarshalFuncs[kind]
+	unmarshalFunc, ok := defaultUnmarshalFunc(destField.Kind())
 	if ok {
-		if isPointer {
-			return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
-				reflect.New(destField.Type())
-				return unmarshalPointer(destValue, cell, params, unmarshalFunc)
-			}
-		}
 		return unmarshalFunc
 	}
 
@@ -222,8 +406,9 @@ func GetUnmarshalFunc(destField reflect.Value) UnmarshalExcelFunc {
 func unmarshalPointer(destPointer reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters, unmarshalFunc UnmarshalExcelFunc) error {
 	// Create new pointer to the field value,
 	// as the pointer may be nil
-	elemType := destPointer.Type().Elem()
-	destPointer.Set(reflect.New(elemType))
+	if destPointer.IsNil() {
+		destPointer.Set(reflect.New(destPointer.Type().Elem()))
+	}
 
 	// Unmarshal into that new value
 	destValue := destPointer.Elem()
@@ -239,242 +424,1037 @@ func Read[T ReadConfigurator](reader io.Reader, filterFunc ...func(t T) (add boo
 	}
 }
 
-// ReadFile each row bind to `T`
-func ReadFile[T ReadConfigurator](file string, filterFunc ...func(t T) (add bool)) ([]T, error) {
-	if bytes, err := os.ReadFile(file); err != nil {
-		return []T(nil), err
-	} else {
-		return ReadBinary(bytes, filterFunc...)
+// defaultReadConfig returns the default read config, see SetDefaultReadConfig
+func defaultReadConfig() *ReadConfig {
+	rc := &ReadConfig{
+		TagName:                "excel",
+		DataStartRowIndex:      1,
+		SkipUnknownColumns:     true,
+		UnmarshalErrorHandling: UnmarshalErrorAbort,
+		MaxUnmarshalErrors:     10,
+		Backend:                XLSXBackend{},
+		KeyColumn:              "#",
+	}
+	if configure := readDefaults(); configure != nil {
+		configure(rc)
+	}
+	return rc
+}
+
+// newReadConfig returns the read config of T
+func newReadConfig[T ReadConfigurator]() *ReadConfig {
+	t := configTarget[T]()
+	rc := defaultReadConfig()
+	applySheetTag(reflect.TypeOf(t), rc)
+	t.ReadConfigure(rc)
+	return rc
+}
+
+// dataStartRowIndex returns the absolute index of the first data row, see DataStartRelative
+func (rc *ReadConfig) dataStartRowIndex() int {
+	if rc.DataStartRelative {
+		return rc.HeaderRowIndex + rc.DataStartRowIndex
 	}
+	return rc.DataStartRowIndex
 }
 
 type fieldInfo struct {
 	reflectFieldIndex int
 	header            string
 	unmarshalFunc     UnmarshalExcelFunc
+	// The column is collected into the catch-all map field, see the rest tag option
+	rest bool
+	// The text of nil pointers, see ReadConfig.NilPlaceholder
+	nilAs string
+	// The allowed values, see the oneof tag option
+	oneOf *oneOf
+	// Applied to the cell value before unmarshalling
+	transformers []Transformer
+	// Binds the field from several columns, set for the first of them, see the combine tag option
+	combined *combinedField
+	// The other columns of a combined field, only read
+	combinePart bool
+	// The values must be unique, see the unique tag option
+	unique bool
+	// The known values, see ReadConfig.ReferenceSets
+	references Set
+	// The value is required depending on other columns, see RequiredRule
+	required []requiredCondition
+}
+
+// openBook validates rc and opens bytes with the backend of rc, measuring the open phase,
+// and verifies the signature and template version of the workbook
+func openBook(rc *ReadConfig, bytes []byte) (Spreadsheet, error) {
+	if err := rc.Validate(); err != nil {
+		countError(rc.Metrics, OpRead, err)
+		return nil, err
+	}
+	done := measure(rc.Metrics, OpRead, PhaseOpen)
+	var book Spreadsheet
+	var err error
+	if opener, ok := rc.Backend.(ValuesOpener); ok && rc.ValuesOnly {
+		book, err = opener.OpenValues(bytes)
+	} else {
+		book, err = rc.Backend.Open(bytes)
+	}
+	done()
+	if err == nil && rc.VerifyKey != nil {
+		err = verifyBook(book, rc.VerifyKey)
+	}
+	if err == nil {
+		err = checkTemplateVersion(book, rc)
+	}
+	if err != nil && book != nil {
+		closeBook(book)
+		book = nil
+	}
+	countError(rc.Metrics, OpRead, err)
+	return book, err
 }
 
 // ReadBinary each row bind to `T`
 func ReadBinary[T ReadConfigurator](bytes []byte, filterFunc ...func(t T) (add bool)) ([]T, error) {
-	f, err := xlsx.OpenBinary(bytes)
+	rc := newReadConfig[T]()
+	book, err := openBook(rc, bytes)
 	if err != nil {
 		return nil, err
 	}
-	var t T
-	rc := defaultReadConfig()
-	t.ReadConfigure(rc)
-	haveDropList := rc.DropListMap != nil
+	return readSpreadsheet(book, rc, nil, nil, nil, filterFunc...)
+}
 
-	if rc.SheetIndex < 0 || rc.SheetIndex > len(f.Sheet)-1 {
-		return nil, ErrSheetIndexOutOfRange
+// ReadWithReport is Read, also returning a ReadReport with the warnings,
+// the report is returned even if reading failed.
+func ReadWithReport[T ReadConfigurator](reader io.Reader, filterFunc ...func(t T) (add bool)) ([]T, *ReadReport, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, &ReadReport{Warnings: []Warning{}}, err
 	}
-	sheet := f.Sheets[rc.SheetIndex]
-	if rc.HeaderRowIndex < 0 || rc.HeaderRowIndex > sheet.MaxRow-1 {
-		return nil, ErrHeaderRowIndexOutOfRange
+	return ReadBinaryWithReport(bs, filterFunc...)
+}
+
+// ReadBinaryWithReport is ReadBinary, also returning a ReadReport with the warnings,
+// the report is returned even if reading failed.
+func ReadBinaryWithReport[T ReadConfigurator](bytes []byte, filterFunc ...func(t T) (add bool)) ([]T, *ReadReport, error) {
+	report := &ReadReport{Warnings: []Warning{}}
+	rc := newReadConfig[T]()
+	book, err := openBook(rc, bytes)
+	if err != nil {
+		return nil, report, err
 	}
-	if rc.DataStartRowIndex < 0 || rc.DataStartRowIndex > sheet.MaxRow-1 {
-		return nil, ErrDataStartRowIndexOutOfRange
+	ts, err := readSpreadsheet(book, rc, report, nil, nil, filterFunc...)
+	return ts, report, err
+}
+
+// headerNames returns the headers of the columns, naming blank headers as configured
+// and dropping trailing blank headers unless they are named or KeepTrailingBlankHeaders is set
+func headerNames(headerRow *Row, rc *ReadConfig) []string {
+	if headerRow == nil {
+		return nil
+	}
+	headers := headerRow.Strings()
+	if !rc.KeepTrailingBlankHeaders && rc.BlankHeaders == BlankHeaderKeep {
+		for len(headers) > 0 && strings.TrimSpace(headers[len(headers)-1]) == "" {
+			headers = headers[:len(headers)-1]
+		}
 	}
-	headerRow, _ := sheet.Row(rc.HeaderRowIndex)
-	maxCol := sheet.MaxCol
-	headers := readStrings(maxCol, headerRow)
+	for i, header := range headers {
+		if strings.TrimSpace(header) != "" {
+			continue
+		}
+		switch rc.BlankHeaders {
+		case BlankHeaderNumber:
+			headers[i] = "Column_" + strconv.Itoa(i+1)
+		case BlankHeaderLetter:
+			headers[i] = ColumnLetter(i)
+		}
+	}
+	return headers
+}
 
+// tagFields maps the column names in the tags of typ to the field indexes
+func tagFields(typ reflect.Type, rc *ReadConfig) map[string]int {
 	// Key: Header / Tag name
 	// Value: Reflection field index
 	tagToFieldMap := make(map[string]int)
-	// Key: Column Index
-	// Value: Unmarshalling Info
-	columnFields := make([]fieldInfo, len(headers))
-
-	typ := reflect.TypeOf(t).Elem()
 	for i := 0; i < typ.NumField(); i++ {
 		if ta := typ.Field(i).Tag; ta != "" {
 			if tt, have := ta.Lookup(rc.TagName); have {
-				tagToFieldMap[tt] = i
+				if name, opts := parseTag(tt); name != "-" && !noColumn(opts) && combineHeaders(opts) == nil {
+					tagToFieldMap[name] = i
+				}
 			}
 		}
 	}
+	return tagToFieldMap
+}
 
-	{
-		val := reflect.New(typ).Elem()
+// restField returns the index of the map[string]string field tagged with the rest option, or -1
+func restField(typ reflect.Type, tagName string) int {
+	for i := 0; i < typ.NumField(); i++ {
+		_, opts := parseTag(typ.Field(i).Tag.Get(tagName))
+		if opts.Contains("rest") && typ.Field(i).Type == reflect.TypeOf(map[string]string(nil)) {
+			return i
+		}
+	}
+	return -1
+}
 
-		for columnIndex, header := range headers {
-			reflectFieldIndex, have := tagToFieldMap[header]
-			if !have {
-				if rc.SkipUnknownColumns {
-					// Skip reading this field
-					columnFields[columnIndex] = fieldInfo{
-						reflectFieldIndex: reflectFieldIndex,
-						header:            header,
-						unmarshalFunc:     nil,
-					}
-					continue
-				} else {
-					return nil, fmt.Errorf("%w for column \"%s\" at index %d", ErrNoDestinationField, header, columnIndex)
+// childrenField returns the index of the slice field tagged with the children option, or -1
+func childrenField(typ reflect.Type, tagName string) int {
+	for i := 0; i < typ.NumField(); i++ {
+		_, opts := parseTag(typ.Field(i).Tag.Get(tagName))
+		if opts.Contains("children") && typ.Field(i).Type.Kind() == reflect.Slice {
+			return i
+		}
+	}
+	return -1
+}
+
+// mapColumns returns the unmarshalling info of typ for each column.
+// Columns of headers contained in known are skipped even if SkipUnknownColumns is false.
+func mapColumns(typ reflect.Type, headers []string, rc *ReadConfig, known map[string]int, report *ReadReport) ([]fieldInfo, error) {
+	tagToFieldMap := tagFields(typ, rc)
+	restIndex := restField(typ, rc.TagName)
+	currencies := currencyColumns(typ, rc.TagName)
+	combined := combinedColumns(typ, headers, rc)
+	// Key: Column Index
+	// Value: Unmarshalling Info
+	columnFields := make([]fieldInfo, len(headers))
+
+	val := reflect.New(typ).Elem()
+
+	for columnIndex, header := range headers {
+		if fi, ok := combined[columnIndex]; ok {
+			columnFields[columnIndex] = fi
+			continue
+		}
+		reflectFieldIndex, have := tagToFieldMap[header]
+		if have && rc.columns != nil && !rc.columns[header] {
+			// Projected out by ReadColumns, skip reading this field
+			columnFields[columnIndex] = fieldInfo{reflectFieldIndex: reflectFieldIndex, header: header}
+			continue
+		}
+		if pair, ok := currencies[header]; ok && !have && (rc.columns == nil || rc.columns[pair.amountTag]) {
+			columnFields[columnIndex] = fieldInfo{reflectFieldIndex: pair.fieldIndex, header: header, unmarshalFunc: unmarshalCurrency}
+			continue
+		}
+		if !have {
+			_, isKnown := known[header]
+			if restIndex >= 0 && !isKnown && strings.TrimSpace(header) != "" {
+				columnFields[columnIndex] = fieldInfo{
+					reflectFieldIndex: restIndex,
+					header:            header,
+					rest:              true,
+				}
+				continue
+			}
+			// Columns with blank headers can't be collected by name, see BlankHeaders
+			if rc.SkipUnknownColumns || isKnown || restIndex >= 0 {
+				if !isKnown && strings.TrimSpace(header) != "" {
+					report.warn(Warning{Kind: WarningUnknownColumn, RowIndex: -1, ColumnIndex: columnIndex, ColumnHeader: header, Message: "no destination field, column skipped"})
+				}
+				// Skip reading this field
+				columnFields[columnIndex] = fieldInfo{
+					reflectFieldIndex: reflectFieldIndex,
+					header:            header,
+					unmarshalFunc:     nil,
 				}
+				continue
+			} else {
+				return nil, fmt.Errorf("%w for column \"%s\" at index %d", ErrNoDestinationField, header, columnIndex)
 			}
+		}
 
-			field := val.Field(reflectFieldIndex)
+		field := val.Field(reflectFieldIndex)
 
-			unmarshaler := GetUnmarshalFunc(field)
-			if unmarshaler == nil {
-				if rc.SkipUnknownTypes {
-					// Skip reading this field
-					columnFields[columnIndex] = fieldInfo{
-						reflectFieldIndex: reflectFieldIndex,
-						header:            header,
-						unmarshalFunc:     nil,
-					}
-					continue
-				} else {
-					return nil, fmt.Errorf("%w for column \"%s\" at index %d", ErrNoUnmarshaler, header, columnIndex)
+		unmarshaler := GetUnmarshalFunc(field)
+		_, opts := parseTag(typ.Field(reflectFieldIndex).Tag.Get(rc.TagName))
+		if opts.Contains("raw") {
+			unmarshaler = rawUnmarshalFunc(field, unmarshaler)
+		}
+		if _, ok := opts.Value("currency"); ok && field.Type() == moneyType {
+			unmarshaler = unmarshalAmount
+		}
+		if layout, loc, ok, err := timeLayout(opts); ok && indirectType(field.Type()) == timeType {
+			if err != nil {
+				return nil, fmt.Errorf("column \"%s\" at index %d: %w", header, columnIndex, err)
+			}
+			if unit := unixUnit(opts); unit != 0 {
+				unmarshaler = indirectUnmarshalFunc(field.Type(), UnixTimeUnmarshalFunc(unit, loc))
+			} else {
+				unmarshaler = indirectUnmarshalFunc(field.Type(), TimeLayoutUnmarshalFunc(layout, loc))
+			}
+		}
+		if custom, have := rc.FieldUnmarshalers[header]; have && custom != nil {
+			unmarshaler = indirectUnmarshalFunc(field.Type(), custom)
+		}
+		if unmarshaler == nil {
+			if rc.SkipUnknownTypes {
+				report.warn(Warning{Kind: WarningUnsupportedType, RowIndex: -1, ColumnIndex: columnIndex, ColumnHeader: header, Message: fmt.Sprintf("no unmarshaler for type %s, column skipped", field.Type())})
+				// Skip reading this field
+				columnFields[columnIndex] = fieldInfo{
+					reflectFieldIndex: reflectFieldIndex,
+					header:            header,
+					unmarshalFunc:     nil,
 				}
+				continue
+			} else {
+				return nil, fmt.Errorf("%w for column \"%s\" at index %d", ErrNoUnmarshaler, header, columnIndex)
 			}
+		}
+
+		columnFields[columnIndex] = fieldInfo{
+			reflectFieldIndex: reflectFieldIndex,
+			header:            header,
+			unmarshalFunc:     unmarshaler,
+			nilAs:             nilPlaceholder(opts, rc.NilPlaceholder),
+			oneOf:             newOneOf(opts),
+			unique:            opts.Contains("unique") || containsString(rc.UniqueColumns, header),
+			references:        rc.ReferenceSets[header],
+			required:          requiredConditions(header, opts, headers, rc),
+		}
+	}
+	for i := range columnFields {
+		columnFields[i].transformers = columnTransformers(columnFields[i].header, rc)
+	}
+	return columnFields, nil
+}
 
-			columnFields[columnIndex] = fieldInfo{
-				reflectFieldIndex: reflectFieldIndex,
-				header:            header,
-				unmarshalFunc:     unmarshaler,
+// rawUnmarshalFunc returns UnmarshalRawString for string fields, or else fallback
+func rawUnmarshalFunc(field reflect.Value, fallback UnmarshalExcelFunc) UnmarshalExcelFunc {
+	switch field.Kind() {
+	case reflect.String:
+		return UnmarshalRawString
+	case reflect.Ptr:
+		if elemFunc := rawUnmarshalFunc(reflect.New(field.Type().Elem()).Elem(), nil); elemFunc != nil {
+			return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+				return unmarshalPointer(destValue, cell, params, elemFunc)
 			}
 		}
 	}
+	return fallback
+}
 
-	unmarshalConfig := &ExcelUnmarshalParameters{
-		TrimSpace:           rc.TrimSpace,
-		Date1904:            f.Date1904,
-		FallbackDateFormats: rc.FallbackDateFormats,
+// indirectUnmarshalFunc returns unmarshalFunc for fields of type t,
+// allocating pointers and passing the value pointed to.
+func indirectUnmarshalFunc(t reflect.Type, unmarshalFunc UnmarshalExcelFunc) UnmarshalExcelFunc {
+	if t.Kind() != reflect.Ptr {
+		return unmarshalFunc
 	}
+	elemFunc := indirectUnmarshalFunc(t.Elem(), unmarshalFunc)
+	return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+		return unmarshalPointer(destValue, cell, params, elemFunc)
+	}
+}
 
-	collectedErrors := make([]FieldError, 0)
+// exactKind reports whether field holds values like IDs, which must not lose digits
+func exactKind(field reflect.Value) bool {
+	switch indirectType(field.Type()).Kind() {
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return true
+	}
+	return false
+}
 
-	ts := make([]T, 0)
-	for rowIndex := 0; rowIndex < sheet.MaxRow; rowIndex++ {
-		if rowIndex >= rc.DataStartRowIndex {
-			val := reflect.New(typ).Elem()
-			if row, _ := sheet.Row(rowIndex); row != nil {
+// indirectType returns the type t points to, following pointer chains like **int
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
 
-				for columnIndex, fi := range columnFields {
-					// If there is no unmarshal function,
-					// this field has been skipped by previous logic.
-					// e.g. no destination field, or unknown type.
-					if fi.unmarshalFunc == nil {
-						continue
-					}
-					cell := row.GetCell(columnIndex)
-					destField := val.Field(fi.reflectFieldIndex)
+// rowBinder unmarshals rows into struct values,
+// handling unmarshalling errors as configured.
+type rowBinder struct {
+	rc              *ReadConfig
+	book            Spreadsheet
+	unmarshalConfig *ExcelUnmarshalParameters
+	collectedErrors []FieldError
+	// Index of the first column in the sheet, for ReadRange
+	columnOffset int
+	// Collects warnings, may be nil
+	report *ReadReport
+	// Reused for unmarshalling if RecordBlockSize is set
+	scratch *xlsx.Cell
+	// The values outside the oneof sets by column header, in order
+	categories    map[string]*categoryViolations
+	categoryOrder []string
+	// Set once MaxUnmarshalErrors errors are collected, see ReadConfig.CountErrorsPastLimit
+	limitReached bool
+	// The number of errors and of rows with errors, including those past the limit
+	errorCount int
+	failedRows int
+	// The row indexes of the values of unique columns by column header
+	uniques map[string]map[string]int
+}
 
-					if rc.PointerCanNil && destField.Kind() == reflect.Ptr && cell.Value == "" {
-						continue
-					}
+// recordAllocator allocates records of typ, in blocks if size is above 1
+type recordAllocator struct {
+	typ   reflect.Type
+	size  int
+	block reflect.Value
+	next  int
+}
 
-					// TODO: need elegant implement to handle pointer.
-					if destField.Type() == reflect.TypeOf(&time.Time{}) && destField.CanSet() {
-						ft, _ := strconv.ParseFloat(cell.Value, 10)
-						t := xlsx.TimeFromExcelTime(ft, f.Date1904)
-						destField.Set(reflect.ValueOf(&t))
-						continue
-					}
+// new returns a pointer to a new zero record
+func (a *recordAllocator) new() reflect.Value {
+	if a.size <= 1 {
+		return reflect.New(a.typ)
+	}
+	if !a.block.IsValid() || a.next == a.block.Len() {
+		a.block = reflect.MakeSlice(reflect.SliceOf(a.typ), a.size, a.size)
+		a.next = 0
+	}
+	val := a.block.Index(a.next).Addr()
+	a.next++
+	return val
+}
 
-					if (destField.Kind() == reflect.Bool || destField.Type() == reflect.TypeOf((*bool)(nil))) && destField.CanSet() {
-						if cell.Value == "是" {
-							b := true
-							if destField.Kind() == reflect.Ptr {
-								destField.Set(reflect.ValueOf(&b))
-							} else {
-								destField.SetBool(b)
-							}
-							continue
-						}
-						if cell.Value == "否" {
-							b := false
-							if destField.Kind() == reflect.Ptr {
-								destField.Set(reflect.ValueOf(&b))
-							} else {
-								destField.SetBool(b)
-							}
-							continue
-						}
-					}
+// xlsxCell returns cell as xlsx cell, reusing the scratch cell if set
+func (b *rowBinder) xlsxCell(cell Cell) *xlsx.Cell {
+	if b.scratch == nil {
+		return cell.XLSX()
+	}
+	*b.scratch = xlsx.Cell{}
+	cell.toXLSX(b.scratch)
+	return b.scratch
+}
 
-					if (destField.Kind() == reflect.String || destField.Type() == reflect.TypeOf((*string)(nil))) && destField.CanSet() {
-						if haveDropList {
-							dropList, have := rc.DropListMap[fi.header]
-							if have {
-								key := ""
-								for _, v := range dropList {
-									if v.Value == cell.Value {
-										key = v.Key
-									}
-								}
-								if destField.Kind() == reflect.Ptr {
-									destField.Set(reflect.ValueOf(&key))
-								} else {
-									destField.SetString(key)
-								}
-								continue
-							}
-						}
-					}
+// bind sets the fields of val from the row.
+// A non-nil error aborts reading.
+func (b *rowBinder) bind(val reflect.Value, row *Row, columnFields []fieldInfo) error {
+	rc := b.rc
+	rowIndex := row.Index
+	errorCount := b.errorCount
+	defer func() {
+		if b.errorCount > errorCount {
+			b.failedRows++
+		}
+	}()
+	for columnIndex, fi := range columnFields {
+		// Skipped by mapColumns, e.g. no destination field, or unknown type
+		if !fi.bound() {
+			continue
+		}
+		cell := row.Cell(columnIndex)
+		if len(fi.transformers) > 0 {
+			value := cell.Value
+			for _, transform := range fi.transformers {
+				cell.Value = transform(cell.Value)
+			}
+			if cell.Value != value {
+				b.report.warn(Warning{Kind: WarningSanitized, RowIndex: rowIndex, ColumnIndex: b.columnOffset + columnIndex, ColumnHeader: fi.header, Message: fmt.Sprintf("value %q changed to %q", value, cell.Value)})
+			}
+		}
+		if fi.rest {
+			b.bindRest(val.Field(fi.reflectFieldIndex), fi.header, cell)
+			continue
+		}
+		if fi.combinePart {
+			continue
+		}
+		// If there is no unmarshal function,
+		// this field has been skipped by previous logic.
+		// e.g. no destination field, or unknown type.
+		if fi.unmarshalFunc == nil && fi.combined == nil {
+			continue
+		}
+		destField := val.Field(fi.reflectFieldIndex)
+		if len(fi.required) > 0 {
+			if err := b.checkRequired(fi.required, row, cell.Value); err != nil {
+				if err = b.fail(b.fieldError(fi, rowIndex, columnIndex, cell, destField, err)); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 
-					err = fi.unmarshalFunc(destField, cell, unmarshalConfig)
-					if err != nil && rc.UnmarshalErrorHandling != UnmarshalErrorIgnore {
-						fer := FieldError{
-							RowIndex:     rowIndex,
-							ColumnIndex:  columnIndex,
-							ColumnHeader: fi.header,
-							Err:          err,
-						}
-						if rc.UnmarshalErrorHandling == UnmarshalErrorAbort {
-							return nil, fer
-						} else {
-							collectedErrors = append(collectedErrors, fer)
-							if rc.MaxUnmarshalErrors > 0 && uint64(len(collectedErrors)) >= rc.MaxUnmarshalErrors {
-								return nil, ContentError{
-									FieldErrors:  collectedErrors,
-									LimitReached: true,
-								}
-							}
+		// Like empty values, nil values are never duplicates or unknown references
+		if destField.Kind() == reflect.Ptr && fi.combined == nil && (rc.PointerCanNil && cell.Value == "" || fi.nilAs != "" && cell.Value == fi.nilAs) {
+			continue
+		}
+		if fi.oneOf != nil {
+			b.checkOneOf(fi, cell.Value, FieldError{
+				RowIndex:     rowIndex,
+				ColumnIndex:  columnIndex,
+				ColumnHeader: fi.header,
+				CellRef:      CellRef(rowIndex, b.columnOffset+columnIndex),
+				Value:        cell.Value,
+				ExpectedType: destField.Type().String(),
+				formatter:    rc.ErrorFormatter,
+			})
+		}
+
+		if (destField.Kind() == reflect.String || destField.Type() == reflect.TypeOf((*string)(nil))) && destField.CanSet() {
+			if rc.DropListMap != nil {
+				dropList, have := rc.DropListMap[fi.header]
+				if have {
+					key := ""
+					for _, v := range dropList {
+						if v.Value == cell.Value {
+							key = v.Key
 						}
 					}
-				}
-				nT := val.Addr().Interface().(T)
-				add := true
-				if filterFunc != nil && len(filterFunc) > 0 {
-					for _, fF := range filterFunc {
-						if fF != nil {
-							add = fF(nT)
-							if !add {
-								break
-							}
+					if destField.Kind() == reflect.Ptr {
+						destField.Set(reflect.ValueOf(&key))
+					} else {
+						destField.SetString(key)
+					}
+					if err := b.checkValue(fi, cell.Value, rowIndex); err != nil {
+						if err = b.fail(b.fieldError(fi, rowIndex, columnIndex, cell, destField, err)); err != nil {
+							return err
 						}
 					}
+					continue
+				}
+			}
+		}
+
+		xc := row.xlsxCell(columnIndex, cell)
+		if xc == nil {
+			xc = b.xlsxCell(cell)
+		}
+		var err error
+		if fi.combined != nil {
+			err = fi.combined.bind(destField, row, b.unmarshalConfig)
+		} else if rc.DetectPrecisionLoss && exactKind(destField) && PrecisionLost(xc) {
+			err = ErrPrecisionLost
+		} else {
+			err = fi.unmarshalFunc(destField, xc, b.unmarshalConfig)
+		}
+		if err != nil && fi.combined == nil && rc.CoerceNumbers && numericKind(destField.Type()) {
+			if value, ok := coerceNumber(cell.Value, indirectType(destField.Type()).Kind()); ok {
+				if fi.unmarshalFunc(destField, b.xlsxCell(StringCell(value)), b.unmarshalConfig) == nil {
+					err = nil
+					b.report.warn(Warning{Kind: WarningCoerced, RowIndex: rowIndex, ColumnIndex: b.columnOffset + columnIndex, ColumnHeader: fi.header, Message: fmt.Sprintf("text %q read as number %s", cell.Value, value)})
+				}
+			}
+		}
+		if err == nil {
+			err = b.checkValue(fi, cell.Value, rowIndex)
+		}
+		if err == nil {
+			continue
+		}
+		if err = b.fail(b.fieldError(fi, rowIndex, columnIndex, cell, destField, err)); err != nil {
+			return err
+		}
+	}
+	if b.errorCount == errorCount {
+		return b.validate(val, row, columnFields)
+	}
+	return nil
+}
+
+// checkValue checks the cell value of the field of fi against the unique tag option and ReadConfig.ReferenceSets
+func (b *rowBinder) checkValue(fi fieldInfo, value string, rowIndex int) error {
+	if fi.unique {
+		if err := b.checkUnique(fi.header, value, rowIndex); err != nil {
+			return err
+		}
+	}
+	if fi.references != nil {
+		return b.checkReference(fi.references, value)
+	}
+	return nil
+}
+
+// fieldError returns the error err of the cell of the field of fi
+func (b *rowBinder) fieldError(fi fieldInfo, rowIndex, columnIndex int, cell Cell, destField reflect.Value, err error) FieldError {
+	return FieldError{
+		RowIndex:     rowIndex,
+		ColumnIndex:  columnIndex,
+		ColumnHeader: fi.header,
+		CellRef:      CellRef(rowIndex, b.columnOffset+columnIndex),
+		Value:        cell.Value,
+		ExpectedType: destField.Type().String(),
+		Err:          err,
+		Severity:     SeverityOf(err),
+		formatter:    b.rc.ErrorFormatter,
+	}
+}
+
+// fail handles the error of a field as configured by UnmarshalErrorHandling,
+// returning a non-nil error to abort reading
+func (b *rowBinder) fail(fer FieldError) error {
+	rc := b.rc
+	if fer.Severity == SeverityWarning {
+		// Warnings keep the value and never abort
+		if b.report != nil {
+			b.report.FieldWarnings = append(b.report.FieldWarnings, fer)
+		}
+		return nil
+	}
+	if rc.UnmarshalErrorHandling != UnmarshalErrorIgnore {
+		if rc.UnmarshalErrorHandling == UnmarshalErrorAbort {
+			return fer
+		} else if b.errorCount++; !b.limitReached {
+			b.collectedErrors = append(b.collectedErrors, fer)
+			if rc.MaxUnmarshalErrors > 0 && uint64(len(b.collectedErrors)) >= rc.MaxUnmarshalErrors {
+				if rc.CountErrorsPastLimit {
+					b.limitReached = true
+					return nil
+				}
+				return ContentError{
+					FieldErrors:  b.collectedErrors,
+					LimitReached: true,
+					formatter:    rc.ErrorFormatter,
+					grouped:      rc.GroupErrors,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// bindRest stores the cell value in the catch-all map field rest
+func (b *rowBinder) bindRest(rest reflect.Value, header string, cell Cell) {
+	if rest.IsNil() {
+		rest.Set(reflect.MakeMap(rest.Type()))
+	}
+	value := cell.Value
+	if b.rc.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	rest.SetMapIndex(reflect.ValueOf(header), reflect.ValueOf(value))
+}
+
+// rowLevel returns the hierarchy level of a row, 0 for parent rows.
+// Rows are classified by the level column if configured,
+// or else as child row if none of the parent columns hold a value.
+func rowLevel(row *Row, levelColumn int, parentFields []fieldInfo) int {
+	if levelColumn >= 0 {
+		level, _ := strconv.Atoi(row.Cell(levelColumn).Value)
+		return level
+	}
+	for columnIndex, fi := range parentFields {
+		if fi.unmarshalFunc != nil && row.Cell(columnIndex).Value != "" {
+			return 0
+		}
+	}
+	return 1
+}
+
+// boundColumns returns the indexes of the columns read by binding rows,
+// which are those of fields, of the catch-all map and of the level and key columns.
+func boundColumns(columnFields, childFields []fieldInfo, levelColumn, keyColumn int) []int {
+	cols := make([]int, 0, len(columnFields))
+	for i, fi := range columnFields {
+		if fi.bound() || (i < len(childFields) && childFields[i].bound()) || i == levelColumn || i == keyColumn {
+			cols = append(cols, i)
+		}
+	}
+	return cols
+}
+
+// bound reports whether binding reads the cell of the column
+func (fi fieldInfo) bound() bool {
+	return fi.unmarshalFunc != nil || fi.rest || len(fi.transformers) > 0 || fi.combined != nil || fi.combinePart
+}
+
+// dataRows iterates the rows of the sheet of rc, reading only the columns cols if possible.
+// Options judging whole rows need all columns.
+func dataRows(book Spreadsheet, rc *ReadConfig, cols []int, fn func(row *Row) error) error {
+	cr, ok := book.(ColumnReader)
+	if !ok || rc.SkipRowsMatching != nil || rc.FooterDetector != nil || rc.SkipFooterRows > 0 {
+		return book.Rows(rc.SheetIndex, fn)
+	}
+	return cr.RowsColumns(rc.SheetIndex, cols, fn)
+}
+
+// readSpreadsheet binds the rows of book to `T`, adding warnings to report if it is not nil,
+// only the records of page are bound if it is not nil
+func readSpreadsheet[T ReadConfigurator](book Spreadsheet, rc *ReadConfig, report *ReadReport, page *recordPage, sink recordSink, filterFunc ...func(t T) (add bool)) ([]T, error) {
+	if rc.Metrics == nil {
+		return bindSpreadsheet(book, rc, report, page, sink, filterFunc...)
+	}
+	if report == nil {
+		// Count the rows
+		report = &ReadReport{}
+	}
+	done := measure(rc.Metrics, OpRead, PhaseBind)
+	ts, err := bindSpreadsheet(book, rc, report, page, sink, filterFunc...)
+	done()
+	rc.Metrics.AddRows(OpRead, report.Rows)
+	countError(rc.Metrics, OpRead, err)
+	return ts, err
+}
+
+// recordSink receives the records of bindSpreadsheet one by one instead of collecting them,
+// or the errors of a row with an invalid value, returning false to stop reading, see Rows
+type recordSink func(rv reflect.Value, err error) bool
+
+func bindSpreadsheet[T ReadConfigurator](book Spreadsheet, rc *ReadConfig, report *ReadReport, page *recordPage, sink recordSink, filterFunc ...func(t T) (add bool)) ([]T, error) {
+	var t T
+
+	if rc.SheetIndex < 0 || rc.SheetIndex > len(book.Sheets())-1 {
+		return nil, ErrSheetIndexOutOfRange
+	}
+	maxRow, _, err := book.Dimension(rc.SheetIndex)
+	if err != nil {
+		return nil, err
+	}
+	if rc.HeaderRowIndex < 0 || rc.HeaderRowIndex > maxRow-1 {
+		return nil, ErrHeaderRowIndexOutOfRange
+	}
+	// A sheet holding only the header row has no records, as written for an empty slice
+	dataStart := rc.dataStartRowIndex()
+	if rc.DataStartRowIndex < 0 || dataStart > maxRow {
+		return nil, ErrDataStartRowIndexOutOfRange
+	}
+	headerRow, err := readRow(book, rc.SheetIndex, rc.HeaderRowIndex)
+	if err != nil {
+		return nil, err
+	}
+	headers := headerNames(headerRow, rc)
+
+	typ := reflect.TypeOf(t).Elem()
+
+	// Nested child rows, see the children tag option
+	childIndex := childrenField(typ, rc.TagName)
+	var childType reflect.Type
+	var childFields []fieldInfo
+	levelColumn := -1
+	childTags := map[string]int{}
+	if childIndex >= 0 {
+		childType = typ.Field(childIndex).Type.Elem()
+		if childType.Kind() == reflect.Ptr {
+			childType = childType.Elem()
+		}
+		childTags = tagFields(childType, rc)
+		if rc.LevelColumn != "" {
+			childTags[rc.LevelColumn] = -1
+			for i, header := range headers {
+				if header == rc.LevelColumn {
+					levelColumn = i
+				}
+			}
+		}
+	}
+
+	// Detail sheets, see the sheet tag option
+	details := detailFields(typ, rc.TagName)
+	if sink != nil && len(details) > 0 {
+		return nil, fmt.Errorf("%w: detail sheet %s when iterating", ErrUnsupported, details[0].sheet)
+	}
+	keyColumn := -1
+	var keyHeader string
+	if len(details) > 0 {
+		var keyIndex int
+		keyIndex, keyHeader = keyField(typ, rc.TagName, rc.KeyColumn)
+		if keyIndex < 0 {
+			childTags[keyHeader] = -1
+		}
+		for i, header := range headers {
+			if header == keyHeader {
+				keyColumn = i
+			}
+		}
+		if keyColumn < 0 {
+			return nil, fmt.Errorf("%w: \"%s\"", ErrNoKeyColumn, keyHeader)
+		}
+	}
+
+	columnFields, err := mapColumns(typ, headers, rc, childTags, report)
+	if err != nil {
+		return nil, err
+	}
+	if childIndex >= 0 {
+		childRC := *rc
+		childRC.SkipUnknownColumns = true
+		if childFields, err = mapColumns(childType, headers, &childRC, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	binder := &rowBinder{
+		rc:   rc,
+		book: book,
+		unmarshalConfig: &ExcelUnmarshalParameters{
+			TrimSpace:           rc.TrimSpace,
+			Date1904:            book.Date1904(),
+			FallbackDateFormats: rc.FallbackDateFormats,
+			BoolFormat:          rc.BoolFormat,
+			Locale:              rc.Locale,
+			UnitParser:          rc.UnitParser,
+			UnitConversions:     rc.UnitConversions,
+		},
+		collectedErrors: make([]FieldError, 0),
+		report:          report,
+	}
+	if rs, ok := book.(*rangeSpreadsheet); ok {
+		binder.columnOffset = rs.r.left
+	}
+	if rc.RecordBlockSize > 0 {
+		binder.scratch = &xlsx.Cell{}
+	}
+	records := &recordAllocator{typ: typ, size: rc.RecordBlockSize}
+	// keep reports whether the filter funcs keep the record t
+	keep := func(t T) bool {
+		for _, fF := range filterFunc {
+			if fF != nil && !fF(t) {
+				return false
+			}
+		}
+		return true
+	}
+	// Filter while reading into a reused record, see ReuseFilteredRecords
+	var scratch reflect.Value
+	if rc.ReuseFilteredRecords && len(filterFunc) > 0 && sink == nil && childIndex < 0 && len(details) == 0 {
+		scratch = reflect.New(typ)
+	}
+
+	// The last parent value, collecting the child rows below it
+	var parent reflect.Value
+	parents := make([]reflect.Value, 0)
+	keys := make([]string, 0)
+	// The keys of the records outside of page, whose detail rows are skipped
+	var skippedKeys map[string]bool
+	// The number of records, including those outside of page
+	total := 0
+	// The last record is outside of page, so are its child rows
+	skipped := false
+	// The sink stopped reading
+	stopped := false
+	// emit passes a record or the error of a row to sink
+	emit := func(rv reflect.Value, err error) error {
+		if stopped = !sink(rv, err); stopped {
+			return errStopRows
+		}
+		return nil
+	}
+	// flush emits the last record, once its child rows are complete
+	flush := func() error {
+		if sink == nil || !parent.IsValid() {
+			return nil
+		}
+		rv := parent
+		parent = reflect.Value{}
+		return emit(rv, nil)
+	}
+	// bind binds a row to val, with a sink only failing for errors of the workbook
+	bind := func(val reflect.Value, row *Row, fields []fieldInfo) (bool, error) {
+		collected := len(binder.collectedErrors)
+		err := binder.bind(val, row, fields)
+		if sink == nil {
+			return err == nil, err
+		}
+		if err == nil && len(binder.collectedErrors) > collected {
+			err = ContentError{FieldErrors: binder.collectedErrors[collected:], formatter: rc.ErrorFormatter, grouped: rc.GroupErrors}
+		}
+		// The errors are emitted per row, not collected
+		binder.collectedErrors = binder.collectedErrors[:collected]
+		if err != nil {
+			return false, emit(reflect.Value{}, err)
+		}
+		return true, nil
+	}
+
+	// bindScratch binds a row into the scratch record, copying it if kept by the filter funcs
+	bindScratch := func(row *Row) error {
+		scratch.Elem().Set(reflect.Zero(typ))
+		if ok, err := bind(scratch.Elem(), row, columnFields); !ok {
+			return err
+		}
+		if report != nil {
+			report.Rows++
+		}
+		if keep(scratch.Interface().(T)) {
+			// The pointers, slices and maps of the fields were allocated for this row
+			val := records.new()
+			val.Elem().Set(scratch.Elem())
+			parents = append(parents, val)
+		}
+		return nil
+	}
+
+	bindRow := func(row *Row) error {
+		if childIndex >= 0 && rowLevel(row, levelColumn, columnFields) > 0 {
+			if skipped {
+				return nil
+			}
+			if !parent.IsValid() {
+				err := fmt.Errorf("%w in row %d", ErrOrphanChildRow, row.Index+1)
+				if sink == nil {
+					return err
 				}
-				if add {
-					ts = append(ts, nT)
+				return emit(reflect.Value{}, err)
+			}
+			child := reflect.New(childType)
+			if ok, err := bind(child.Elem(), row, childFields); !ok {
+				return err
+			}
+			appendElem(parent.Elem().Field(childIndex), child)
+			return nil
+		}
+
+		total++
+		if skipped = !page.contains(total - 1); skipped {
+			if keyColumn >= 0 {
+				if skippedKeys == nil {
+					skippedKeys = make(map[string]bool)
 				}
+				skippedKeys[row.Cell(keyColumn).Value] = true
 			}
+			return nil
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if scratch.IsValid() {
+			return bindScratch(row)
 		}
+		val := records.new()
+		if ok, err := bind(val.Elem(), row, columnFields); !ok {
+			return err
+		}
+		parent = val
+		if sink == nil {
+			parents = append(parents, val)
+		}
+		if report != nil {
+			report.Rows++
+		}
+		if keyColumn >= 0 {
+			keys = append(keys, row.Cell(keyColumn).Value)
+		}
+		return nil
 	}
-	if len(collectedErrors) > 0 {
-		return nil, ContentError{
-			FieldErrors:  collectedErrors,
-			LimitReached: false,
+	// Rows held back as they may be among the last SkipFooterRows non-empty rows
+	var pending []*Row
+	footerRows := 0
+	err = dataRows(book, rc, boundColumns(columnFields, childFields, levelColumn, keyColumn), func(row *Row) error {
+		if row.Index < dataStart {
+			return nil
+		}
+		if rc.SkipRowsMatching != nil && rc.SkipRowsMatching(row) {
+			return nil
+		}
+		if rc.FooterDetector != nil && rc.FooterDetector(row) {
+			return errStopRows
+		}
+		if rc.SkipFooterRows <= 0 {
+			return bindRow(row)
+		}
+		pending = append(pending, row)
+		if !row.IsEmpty() {
+			footerRows++
+		}
+		for footerRows > rc.SkipFooterRows {
+			next := pending[0]
+			pending = pending[1:]
+			if !next.IsEmpty() {
+				footerRows--
+			}
+			if err := bindRow(next); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errStopRows {
+		return nil, err
+	}
+	// The last record is complete
+	if stopped || flush() != nil {
+		return nil, nil
+	}
+	if len(details) > 0 {
+		if err = readDetails(book, binder, parents, keys, skippedKeys, details, keyHeader); err != nil {
+			return nil, err
+		}
+	}
+	categoryErrs := binder.categoryErrors()
+	if len(categoryErrs) > 0 {
+		if sink != nil {
+			_ = emit(reflect.Value{}, ContentError{FieldErrors: categoryErrs, formatter: rc.ErrorFormatter, grouped: rc.GroupErrors})
+			return nil, nil
+		}
+		binder.collectedErrors = append(binder.collectedErrors, categoryErrs...)
+	}
+	if len(binder.collectedErrors) > 0 {
+		ce := ContentError{
+			FieldErrors:  binder.collectedErrors,
+			LimitReached: binder.limitReached,
+			formatter:    rc.ErrorFormatter,
+			grouped:      rc.GroupErrors,
+		}
+		if rc.CountErrorsPastLimit {
+			ce.TotalErrors, ce.FailedRows = binder.errorCount+len(categoryErrs), binder.failedRows
+		}
+		return nil, ce
+	}
+
+	if total < rc.MinRows {
+		return nil, fmt.Errorf("%w: %d records below row %d, expected at least %d", ErrTooFewRows, total, rc.HeaderRowIndex+1, rc.MinRows)
+	}
+	if sink != nil {
+		return nil, nil
+	}
+	if page != nil {
+		page.total = total
+	}
+
+	// Filter after reading, so filter funcs see the complete children
+	ts := make([]T, 0, len(parents))
+	for _, val := range parents {
+		nT := val.Interface().(T)
+		if scratch.IsValid() || keep(nT) {
+			ts = append(ts, nT)
 		}
 	}
 	return ts, nil
 }
 
-// ReadExcel walk func from excel
-func ReadExcel(file string, sheetIndex int, walk func(index int, rows *xlsx.Row)) error {
-	f, err := xlsx.OpenFile(file)
+// WalkRowFunc is called for each row by ReadExcelFrom and ReadExcelSheet,
+// row.Strings returns the values of all cells.
+// Returning an error stops the walk, the error is passed on unless it is ErrStopWalk.
+type WalkRowFunc func(index int, row *Row) error
+
+// ErrStopWalk stops a walk without error when returned by a WalkRowFunc.
+var ErrStopWalk = errors.New("exl: stop walk")
+
+// ReadExcelFrom walks the rows of the sheet at sheetIndex of the excel read from reader
+func ReadExcelFrom(reader io.Reader, sheetIndex int, walk WalkRowFunc) error {
+	book, err := openExcel(reader)
+	if err != nil {
+		return err
+	}
+	if sheetIndex < 0 || sheetIndex > len(book.Sheets())-1 {
+		return ErrSheetIndexOutOfRange
+	}
+	return walkRows(book, sheetIndex, walk)
+}
+
+// ReadExcelSheet walks the rows of the sheet named sheetName of the excel read from reader
+func ReadExcelSheet(reader io.Reader, sheetName string, walk WalkRowFunc) error {
+	book, err := openExcel(reader)
 	if err != nil {
 		return err
 	}
-	sheet := f.Sheets[sheetIndex]
-	for i := 0; i < sheet.MaxRow; i++ {
-		if row, _ := sheet.Row(i); row != nil {
-			walk(i, row)
+	for i, name := range book.Sheets() {
+		if name == sheetName {
+			return walkRows(book, i, walk)
 		}
 	}
-	return nil
+	return fmt.Errorf("%w: %s", ErrSheetNotFound, sheetName)
+}
+
+func openExcel(reader io.Reader) (Spreadsheet, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return XLSXBackend{}.Open(bs)
+}
+
+func walkRows(book Spreadsheet, sheet int, walk WalkRowFunc) error {
+	err := book.Rows(sheet, func(row *Row) error {
+		return walk(row.Index, row)
+	})
+	if err == ErrStopWalk {
+		return nil
+	}
+	return err
 }
@@ -13,10 +13,13 @@
 package exl
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -291,13 +294,13 @@ func TestReadFileErr(t *testing.T) {
 	testFile := "tmp.xlsx"
 	defer func() { _ = os.Remove(testFile) }()
 	_ = WriteFile(testFile, []*writeTmp{{}})
-	if _, err := ReadFile[*readSheetIndexOutOfRange](testFile); err != ErrSheetIndexOutOfRange {
+	if _, err := ReadFile[*readSheetIndexOutOfRange](testFile); !errors.Is(err, ErrSheetIndexOutOfRange) || !errors.Is(err, ErrInvalidConfig) {
 		t.Error("test failed")
 	}
-	if _, err := ReadFile[*readHeaderRowIndexOutOfRange](testFile); err != ErrHeaderRowIndexOutOfRange {
+	if _, err := ReadFile[*readHeaderRowIndexOutOfRange](testFile); !errors.Is(err, ErrHeaderRowIndexOutOfRange) || !errors.Is(err, ErrInvalidConfig) {
 		t.Error("test failed")
 	}
-	if _, err := ReadFile[*readDataStartRowIndexOutOfRange](testFile); err != ErrDataStartRowIndexOutOfRange {
+	if _, err := ReadFile[*readDataStartRowIndexOutOfRange](testFile); !errors.Is(err, ErrDataStartRowIndexOutOfRange) || !errors.Is(err, ErrInvalidConfig) {
 		t.Error("test failed")
 	}
 }
@@ -564,6 +567,16 @@ func (*collectUnmarshalErrorsUnlimited) ReadConfigure(rc *ReadConfig) {
 	rc.MaxUnmarshalErrors = 0
 }
 
+type countUnmarshalErrors struct {
+	Name1 customUnmarshalledString `excel:"Name1"`
+}
+
+func (*countUnmarshalErrors) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.MaxUnmarshalErrors = 2
+	rc.CountErrorsPastLimit = true
+}
+
 func TestUnmarshalErrors(t *testing.T) {
 	testFile := "tmp.xlsx"
 	defer func() { _ = os.Remove(testFile) }()
@@ -593,6 +606,9 @@ func TestUnmarshalErrors(t *testing.T) {
 				RowIndex:     1,
 				ColumnIndex:  0,
 				ColumnHeader: "Name1",
+				Value:        "error please",
+				ExpectedType: "exl.customUnmarshalledString",
+				CellRef:      "A2",
 				Err:          errors.New("excel unmarshalled: unit test error"),
 			}, err)
 			if model != nil {
@@ -611,12 +627,18 @@ func TestUnmarshalErrors(t *testing.T) {
 						RowIndex:     1,
 						ColumnIndex:  0,
 						ColumnHeader: "Name1",
+						Value:        "error please",
+						ExpectedType: "exl.customUnmarshalledString",
+						CellRef:      "A2",
 						Err:          errors.New("excel unmarshalled: unit test error"),
 					},
 					{
 						RowIndex:     2,
 						ColumnIndex:  0,
 						ColumnHeader: "Name1",
+						Value:        "error please",
+						ExpectedType: "exl.customUnmarshalledString",
+						CellRef:      "A3",
 						Err:          errors.New("excel unmarshalled: unit test error"),
 					},
 				},
@@ -638,18 +660,27 @@ func TestUnmarshalErrors(t *testing.T) {
 						RowIndex:     1,
 						ColumnIndex:  0,
 						ColumnHeader: "Name1",
+						Value:        "error please",
+						ExpectedType: "exl.customUnmarshalledString",
+						CellRef:      "A2",
 						Err:          errors.New("excel unmarshalled: unit test error"),
 					},
 					{
 						RowIndex:     2,
 						ColumnIndex:  0,
 						ColumnHeader: "Name1",
+						Value:        "error please",
+						ExpectedType: "exl.customUnmarshalledString",
+						CellRef:      "A3",
 						Err:          errors.New("excel unmarshalled: unit test error"),
 					},
 					{
 						RowIndex:     3,
 						ColumnIndex:  0,
 						ColumnHeader: "Name1",
+						Value:        "error please",
+						ExpectedType: "exl.customUnmarshalledString",
+						CellRef:      "A4",
 						Err:          errors.New("excel unmarshalled: unit test error"),
 					},
 				},
@@ -660,6 +691,17 @@ func TestUnmarshalErrors(t *testing.T) {
 			}
 		}
 	})
+	t.Run("count errors past limit", func(t *testing.T) {
+		_, err := ReadFile[*countUnmarshalErrors](testFile)
+		var ce ContentError
+		equal(t, true, errors.As(err, &ce))
+		equal(t, 2, len(ce.FieldErrors))
+		equal(t, true, ce.LimitReached)
+		equal(t, 3, ce.TotalErrors)
+		equal(t, 3, ce.FailedRows)
+		equal(t, "3 errors in 3 rows reading data from Excel, the first 2 reported", err.Error())
+		equal(t, 3, ToReport(err).FailedRows)
+	})
 }
 
 func TestReadFilterFunc(t *testing.T) {
@@ -733,3 +775,464 @@ func TestBasic(t *testing.T) {
 	_ = testBasic(100)
 	_ = testBasic(10000)
 }
+
+func TestReadExcelFrom(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}})
+	data := buf.Bytes()
+
+	var rows [][]string
+	err := ReadExcelFrom(bytes.NewReader(data), 0, func(index int, row *Row) error {
+		rows = append(rows, row.Strings())
+		if index == 1 {
+			return ErrStopWalk
+		}
+		return nil
+	})
+	equal(t, nil, err)
+	equal(t, [][]string{{"a", "b"}, {"c", "d"}}, rows)
+
+	errWalk := errors.New("walk")
+	err = ReadExcelSheet(bytes.NewReader(data), "Sheet1", func(int, *Row) error { return errWalk })
+	equal(t, errWalk, err)
+
+	err = ReadExcelSheet(bytes.NewReader(data), "Sheet2", nil)
+	if !errors.Is(err, ErrSheetNotFound) {
+		t.Errorf("expected ErrSheetNotFound, got %v", err)
+	}
+	equal(t, ErrSheetIndexOutOfRange, ReadExcelFrom(bytes.NewReader(data), 1, nil))
+}
+
+type blankHeaderTmp struct {
+	Name  string `excel:"Name"`
+	Third string `excel:"Column_3"`
+	D     string `excel:"D"`
+}
+
+func (*blankHeaderTmp) ReadConfigure(rc *ReadConfig) { rc.BlankHeaders = BlankHeaderNumber }
+
+type blankLetterTmp blankHeaderTmp
+
+func (*blankLetterTmp) ReadConfigure(rc *ReadConfig) { rc.BlankHeaders = BlankHeaderLetter }
+
+func TestReadBlankHeaders(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Qty", "", " "}, {"apple", "2", "c", "d"}})
+	data := buf.Bytes()
+
+	ts, err := ReadBinary[*blankHeaderTmp](data)
+	equal(t, nil, err)
+	equal(t, []*blankHeaderTmp{{Name: "apple", Third: "c"}}, ts)
+
+	ls, err := ReadBinary[*blankLetterTmp](data)
+	equal(t, nil, err)
+	equal(t, []*blankLetterTmp{{Name: "apple", D: "d"}}, ls)
+}
+
+type restTmp struct {
+	Name   string            `excel:"Name"`
+	Extras map[string]string `excel:",rest"`
+}
+
+func (*restTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipUnknownColumns = false
+	rc.TrimSpace = true
+}
+
+func TestReadRest(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Color", "", "Origin"}, {"apple", " red ", "x", ""}, {"pear", "green", "", "CN"}})
+	ts, err := Read[*restTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*restTmp{
+		{Name: "apple", Extras: map[string]string{"Color": "red", "Origin": ""}},
+		{Name: "pear", Extras: map[string]string{"Color": "green", "Origin": "CN"}},
+	}, ts)
+}
+
+type rawTmp struct {
+	Zip     string  `excel:"Zip,raw"`
+	Display string  `excel:"Display"`
+	Phone   *string `excel:"Phone,raw"`
+}
+
+func (*rawTmp) ReadConfigure(_ *ReadConfig) {}
+
+func TestReadRaw(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	appendXLSXRow(sheet, NewRow("Zip", "Display", "Phone"))
+	row := sheet.AddRow()
+	for i := 0; i < 2; i++ {
+		c := row.AddCell()
+		c.SetFloatWithFormat(123, "00000")
+	}
+	row.AddCell().SetString("0049 30 123")
+	buf := &bytes.Buffer{}
+	_ = f.Write(buf)
+
+	ts, err := Read[*rawTmp](buf)
+	equal(t, nil, err)
+	phone := "0049 30 123"
+	equal(t, []*rawTmp{{Zip: "123", Display: "00123", Phone: &phone}}, ts)
+}
+
+type precisionTmp struct {
+	ID    string `excel:"ID"`
+	Count int    `excel:"Count"`
+	Raw   Cell   `excel:"Raw"`
+}
+
+func (*precisionTmp) ReadConfigure(rc *ReadConfig) {
+	rc.DetectPrecisionLoss = true
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+}
+
+func TestReadPrecisionLoss(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	appendXLSXRow(sheet, NewRow("ID", "Count", "Raw"))
+	row := sheet.AddRow()
+	row.AddCell().SetFloatWithFormat(1.23e12, "0.00E+00")
+	row.AddCell().SetInt64(1234567890123456789)
+	row.AddCell().SetFloatWithFormat(1.23e12, "0.00E+00")
+	appendXLSXRow(sheet, NewRow("A-1", 42, 1))
+	buf := &bytes.Buffer{}
+	_ = f.Write(buf)
+
+	_, err := Read[*precisionTmp](bytes.NewReader(buf.Bytes()))
+	var ce ContentError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected ContentError, got %v", err)
+	}
+	equal(t, 2, len(ce.FieldErrors))
+	equal(t, ErrPrecisionLost, ce.FieldErrors[0].Err)
+	equal(t, "Count", ce.FieldErrors[1].ColumnHeader)
+
+	ts, err := ReadBinary[*precisionTmp](buf.Bytes(), func(t *precisionTmp) bool { return t.ID == "A-1" })
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	equal(t, []*precisionTmp(nil), ts)
+}
+
+func TestUnmarshalCellField(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	appendXLSXRow(sheet, NewRow("Raw"))
+	sheet.AddRow().AddCell().SetFloatWithFormat(1.23e12, "0.00E+00")
+	buf := &bytes.Buffer{}
+	_ = f.Write(buf)
+	ts, err := Read[*precisionTmp](buf)
+	equal(t, nil, err)
+	equal(t, Cell{Type: CellTypeNumber, Value: "1230000000000", NumFmt: "0.00E+00"}, ts[0].Raw)
+}
+
+type pointerTmp struct {
+	Flag     *bool          `excel:"Flag"`
+	Chinese  *bool          `excel:"Chinese"`
+	Serial   *time.Time     `excel:"Serial"`
+	Date     *time.Time     `excel:"Date"`
+	Text     *roundTripText `excel:"Text"`
+	Code     *roundTripCode `excel:"Code"`
+	Negative bool           `excel:"Negative"`
+}
+
+func (*pointerTmp) ReadConfigure(rc *ReadConfig) { rc.FallbackDateFormats = []string{"2006-01-02"} }
+
+func TestReadPointers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelAnyTo(buf, [][]any{
+		{"Flag", "Chinese", "Serial", "Date", "Text", "Code", "Negative"},
+		{true, "否", 44927, "2023-01-02", "a/b", "xyz", "否"},
+	})
+	ts, err := Read[*pointerTmp](buf)
+	equal(t, nil, err)
+	yes, no := true, false
+	serial := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	code := roundTripCode(3)
+	equal(t, []*pointerTmp{{
+		Flag:    &yes,
+		Chinese: &no,
+		Serial:  &serial,
+		Date:    &date,
+		Text:    &roundTripText{"a", "b"},
+		Code:    &code,
+	}}, ts)
+}
+
+type doublePointerTmp struct {
+	Count   **int           `excel:"Count"`
+	Zip     **string        `excel:"Zip,raw"`
+	Text    **roundTripText `excel:"Text"`
+	Missing **int           `excel:"Missing"`
+}
+
+func (*doublePointerTmp) ReadConfigure(rc *ReadConfig)  { rc.PointerCanNil = true }
+func (*doublePointerTmp) WriteConfigure(_ *WriteConfig) {}
+
+func TestDoublePointers(t *testing.T) {
+	count, zip, text := 3, "01234", &roundTripText{"a", "b"}
+	countPtr, zipPtr := &count, &zip
+	nilCount := (*int)(nil)
+	records := []*doublePointerTmp{{Count: &countPtr, Zip: &zipPtr, Text: &text, Missing: &nilCount}}
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, records); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "3", row.GetCell(0).Value)
+	equal(t, "", row.GetCell(3).Value)
+
+	ts, err := Read[*doublePointerTmp](buf)
+	equal(t, nil, err)
+	records[0].Missing = nil
+	equal(t, records, ts)
+}
+
+type fieldUnmarshalerTmp struct {
+	Weight  float64  `excel:"Weight"`
+	Gross   *float64 `excel:"Gross"`
+	Comment string   `excel:"Comment"`
+}
+
+func (*fieldUnmarshalerTmp) ReadConfigure(rc *ReadConfig) {
+	kg := func(destValue reflect.Value, cell *xlsx.Cell, _ *ExcelUnmarshalParameters) error {
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSuffix(cell.Value, " kg"), ",", ""), 64)
+		if err != nil {
+			return err
+		}
+		destValue.SetFloat(f)
+		return nil
+	}
+	rc.FieldUnmarshalers = map[string]UnmarshalExcelFunc{"Weight": kg, "Gross": kg}
+}
+
+func TestReadFieldUnmarshalers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Weight", "Gross", "Comment"}, {"1,234 kg", "1,300.5 kg", "12 kg"}})
+	ts, err := Read[*fieldUnmarshalerTmp](buf)
+	equal(t, nil, err)
+	gross := 1300.5
+	equal(t, []*fieldUnmarshalerTmp{{Weight: 1234, Gross: &gross, Comment: "12 kg"}}, ts)
+}
+
+type skipRowsTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*skipRowsTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipRowsMatching = MatchRow(regexp.MustCompile(`^(Report|Page \d+)`))
+}
+
+func TestReadSkipRowsMatching(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelAnyTo(buf, [][]any{{"apple", 2}, {"Page 2"}, {"Report of 2023-01-02"}, {"pear", 3}}, "Name", "Qty")
+	ts, err := Read[*skipRowsTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*skipRowsTmp{{"apple", 2}, {"pear", 3}}, ts)
+}
+
+type dataStartRelativeTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*dataStartRelativeTmp) ReadConfigure(rc *ReadConfig) {
+	rc.HeaderRowIndex = 2
+	rc.DataStartRelative = true
+}
+
+func TestReadDataStartRelative(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Report"}, {}, {"Name", "Qty"}, {"apple", "2"}, {"pear", "3"}})
+	ts, err := Read[*dataStartRelativeTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*dataStartRelativeTmp{{"apple", 2}, {"pear", 3}}, ts)
+}
+
+type trailingBlankHeadersTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*trailingBlankHeadersTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipUnknownColumns = false
+}
+
+type keepTrailingBlankHeadersTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*keepTrailingBlankHeadersTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipUnknownColumns = false
+	rc.KeepTrailingBlankHeaders = true
+}
+
+func TestReadTrailingBlankHeaders(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Qty", "", " "}, {"apple", "2", "", ""}})
+	ts, err := ReadBinary[*trailingBlankHeadersTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*trailingBlankHeadersTmp{{"apple", 2}}, ts)
+	if _, err = ReadBinary[*keepTrailingBlankHeadersTmp](buf.Bytes()); !errors.Is(err, ErrNoDestinationField) {
+		t.Errorf("expected ErrNoDestinationField, got %v", err)
+	}
+}
+
+type minRowsTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*minRowsTmp) ReadConfigure(rc *ReadConfig) { rc.MinRows = 2 }
+
+func TestReadMinRows(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name"}, {"apple"}, {"pear"}})
+	ts, err := ReadBinary[*minRowsTmp](buf.Bytes(), func(t *minRowsTmp) bool { return t.Name == "pear" })
+	equal(t, nil, err)
+	equal(t, []*minRowsTmp{{"pear"}}, ts)
+
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Name"}, {"apple"}})
+	_, err = ReadBinary[*minRowsTmp](buf.Bytes())
+	if !errors.Is(err, ErrTooFewRows) {
+		t.Fatalf("expected ErrTooFewRows, got %v", err)
+	}
+	equal(t, "exl: too few rows: 1 records below row 1, expected at least 2", err.Error())
+}
+
+type recordBlockTmp struct {
+	Name  string    `excel:"Name"`
+	Qty   int       `excel:"Qty"`
+	Price *float64  `excel:"Price"`
+	Date  time.Time `excel:"Date"`
+}
+
+func (*recordBlockTmp) ReadConfigure(rc *ReadConfig) {
+	rc.RecordBlockSize = 2
+	rc.PointerCanNil = true
+}
+
+func (*recordBlockTmp) WriteConfigure(*WriteConfig) {}
+
+func TestReadRecordBlockSize(t *testing.T) {
+	price := 1.5
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := []*recordBlockTmp{{"apple", 2, &price, date}, {"pear", 3, nil, date}, {"plum", 4, &price, time.Time{}}}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, expected)
+	ts, err := ReadBinary[*recordBlockTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, expected, ts)
+	ts[0].Name = "fig"
+	equal(t, "pear", ts[1].Name)
+}
+
+type reuseFilteredTmp recordBlockTmp
+
+func (*reuseFilteredTmp) ReadConfigure(rc *ReadConfig) {
+	rc.ReuseFilteredRecords = true
+	rc.PointerCanNil = true
+}
+
+type allocateFilteredTmp recordBlockTmp
+
+func (*allocateFilteredTmp) ReadConfigure(rc *ReadConfig) { rc.PointerCanNil = true }
+
+func TestReadReuseFilteredRecords(t *testing.T) {
+	price := 1.5
+	ts := make([]*recordBlockTmp, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		ts = append(ts, &recordBlockTmp{Name: fmt.Sprint(i), Qty: i, Price: &price})
+	}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, ts)
+	data := buf.Bytes()
+
+	tens := func(t *reuseFilteredTmp) bool { return t.Qty%100 == 0 }
+	read, report, err := ReadBinaryWithReport[*reuseFilteredTmp](data, tens)
+	equal(t, nil, err)
+	equal(t, 1000, report.Rows)
+	equal(t, 10, len(read))
+	equal(t, "900", read[9].Name)
+	// The kept records don't share pointers
+	*read[0].Price = 2
+	equal(t, 1.5, *read[1].Price)
+
+	reused := testing.AllocsPerRun(3, func() { _, _ = ReadBinary[*reuseFilteredTmp](data, tens) })
+	allocated := testing.AllocsPerRun(3, func() {
+		_, _ = ReadBinary[*allocateFilteredTmp](data, func(t *allocateFilteredTmp) bool { return t.Qty%100 == 0 })
+	})
+	if reused >= allocated {
+		t.Errorf("expected fewer allocations reusing the record, got %v, not reusing %v", reused, allocated)
+	}
+}
+
+type valuesOnlyTmp struct {
+	Zip  string    `excel:"Zip"`
+	Date time.Time `excel:"Date"`
+}
+
+func (*valuesOnlyTmp) ReadConfigure(rc *ReadConfig) { rc.ValuesOnly = true }
+
+type formattedTmp valuesOnlyTmp
+
+func (*formattedTmp) ReadConfigure(_ *ReadConfig) {}
+
+func TestReadValuesOnly(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	appendXLSXRow(sheet, NewRow("Zip", "Date"))
+	row := sheet.AddRow()
+	row.AddCell().SetFloatWithFormat(123, "00000")
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	row.AddCell().SetDate(date)
+	buf := &bytes.Buffer{}
+	_ = f.Write(buf)
+
+	ts, err := ReadBinary[*formattedTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*formattedTmp{{Zip: "00123", Date: date}}, ts)
+	// The number format is skipped, the date is read from its serial number
+	vs, err := ReadBinary[*valuesOnlyTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*valuesOnlyTmp{{Zip: "123", Date: date}}, vs)
+}
+
+// linkTmp reads the link of a hyperlink cell, and whether it is bold
+type linkTmp struct {
+	Bold bool
+	Link string
+	Row  int
+}
+
+func (l *linkTmp) UnmarshalExcel(cell *xlsx.Cell, _ *ExcelUnmarshalParameters) error {
+	l.Bold, l.Link, l.Row = cell.GetStyle().Font.Bold, cell.Hyperlink.Link, cell.Row.GetCoordinate()
+	return nil
+}
+
+type linkRowTmp struct {
+	Site linkTmp `excel:"Site"`
+}
+
+func (*linkRowTmp) ReadConfigure(*ReadConfig) {}
+
+func TestReadSourceCell(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	sheet.AddRow().AddCell().SetString("Site")
+	cell := sheet.AddRow().AddCell()
+	cell.SetHyperlink("https://example.com", "Example", "")
+	cell.GetStyle().Font.Bold = true
+	buf := &bytes.Buffer{}
+	equal(t, nil, f.Write(buf))
+
+	ts, err := ReadBinary[*linkRowTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*linkRowTmp{{linkTmp{true, "https://example.com", 1}}}, ts)
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Redaction of sensitive columns.
+//
+// Fields tagged with the mask option, e.g. `excel:"SSN,mask=last4"`,
+// are written masked if WriteConfig.Redact is set,
+// so the same struct serves exports for audiences with and without access to the data.
+// The masked value is written as text cell, empty values stay empty.
+//
+// Pseudonymization works the same way for fields tagged with the pseudonym option,
+// e.g. `excel:"Customer,pseudonym=sha256"`, written as hash or token if WriteConfig.Pseudonymize is set,
+// before masking, for datasets handed to analytics partners.
+
+type (
+	// Redactor masks the text of a cell.
+	Redactor func(s string) string
+	// Pseudonymizer replaces the text of a cell with a deterministic hash or token,
+	// so pseudonymized columns can still be joined and counted.
+	Pseudonymizer func(s string) string
+)
+
+var (
+	// ErrUnknownRedactor is returned for mask tag options naming no redactor.
+	ErrUnknownRedactor = errors.New("exl: unknown redactor")
+	// ErrUnknownPseudonymizer is returned for pseudonym tag options naming no pseudonymizer.
+	ErrUnknownPseudonymizer = errors.New("exl: unknown pseudonymizer")
+)
+
+// defaultRedactors are the redactors available to the mask tag option by name
+var defaultRedactors = map[string]Redactor{
+	"all":    MaskAll,
+	"last4":  MaskLast4,
+	"first1": MaskFirst1,
+	"email":  MaskEmail,
+}
+
+// defaultPseudonymizers are the pseudonymizers available to the pseudonym tag option by name
+var defaultPseudonymizers = map[string]Pseudonymizer{
+	"sha256": HashSHA256,
+}
+
+// MaskAll replaces every character with *, the redactor of `mask=all` or just `mask`.
+func MaskAll(s string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(s))
+}
+
+// MaskLast4 replaces all but the last 4 characters with *, the redactor of `mask=last4`.
+func MaskLast4(s string) string {
+	return maskKeep(s, 0, 4)
+}
+
+// MaskFirst1 replaces all but the first character with *, the redactor of `mask=first1`.
+func MaskFirst1(s string) string {
+	return maskKeep(s, 1, 0)
+}
+
+// MaskEmail keeps the first character and the domain of an email address,
+// e.g. "a***@example.com", the redactor of `mask=email`.
+// Text without @ is masked completely.
+func MaskEmail(s string) string {
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok {
+		return MaskAll(s)
+	}
+	return MaskFirst1(local) + "@" + domain
+}
+
+// maskKeep replaces the characters of s with *, except for the first head and the last tail ones.
+func maskKeep(s string, head, tail int) string {
+	rs := []rune(s)
+	for i := range rs {
+		if i >= head && i < len(rs)-tail {
+			rs[i] = '*'
+		}
+	}
+	return string(rs)
+}
+
+// HashSHA256 returns the hex encoded SHA-256 hash, the pseudonymizer of `pseudonym=sha256`.
+// Values with few possibilities like birthdays or phone numbers can be recovered
+// from plain hashes by trying them all, prefer HMACSHA256 for these.
+func HashSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// HMACSHA256 returns a pseudonymizer writing the hex encoded HMAC-SHA256 with key,
+// which can't be recovered without the key,
+// for use in WriteConfig.Pseudonymizers.
+func HMACSHA256(key []byte) Pseudonymizer {
+	return func(s string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+}
+
+// protectValue applies the mask and pseudonym tag options of col to the value written for it
+func protectValue(value any, col writeColumn, wc *WriteConfig) (any, error) {
+	if name, have := col.opts.Value("pseudonym"); have && wc.Pseudonymize {
+		pseudonymizer, have := wc.Pseudonymizers[name]
+		if !have {
+			pseudonymizer, have = defaultPseudonymizers[name]
+		}
+		if !have || pseudonymizer == nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownPseudonymizer, name)
+		}
+		value = replaceText(value, pseudonymizer)
+	}
+	if name, have := col.opts.Value("mask"); have && wc.Redact {
+		if name == "" {
+			name = "all"
+		}
+		redactor, have := wc.Redactors[name]
+		if !have {
+			redactor, have = defaultRedactors[name]
+		}
+		if !have || redactor == nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownRedactor, name)
+		}
+		value = replaceText(value, redactor)
+	}
+	return value, nil
+}
+
+// replaceText returns a text cell with the text of value replaced by fn, keeping empty values empty
+func replaceText(value any, fn func(string) string) any {
+	var s string
+	switch v := value.(type) {
+	case nil:
+	case Cell:
+		s = v.Value
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" {
+		return ""
+	}
+	return StringCell(fn(s))
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+func TestMask(t *testing.T) {
+	equal(t, "****", MaskAll("abcd"))
+	equal(t, "*****6789", MaskLast4("123456789"))
+	equal(t, "123", MaskLast4("123"))
+	equal(t, "中**", MaskFirst1("中文字"))
+	equal(t, "a**@example.com", MaskEmail("ann@example.com"))
+	equal(t, "***", MaskEmail("ann"))
+}
+
+type redactTmp struct {
+	Name  string  `excel:"Name"`
+	SSN   string  `excel:"SSN,mask=last4"`
+	Email *string `excel:"Email,mask=email"`
+	PIN   int     `excel:"PIN,mask"`
+	IBAN  string  `excel:"IBAN,mask=iban"`
+}
+
+func (*redactTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Redactors = map[string]Redactor{"iban": func(s string) string { return s[:2] + MaskAll(s[2:]) }}
+}
+
+type redactOnTmp redactTmp
+
+func (*redactOnTmp) WriteConfigure(wc *WriteConfig) {
+	(*redactTmp)(nil).WriteConfigure(wc)
+	wc.Redact = true
+}
+
+type redactUnknownTmp struct {
+	SSN string `excel:"SSN,mask=middle"`
+}
+
+func (*redactUnknownTmp) WriteConfigure(wc *WriteConfig) { wc.Redact = true }
+
+func TestWriteRedact(t *testing.T) {
+	email := "ann@example.com"
+	records := []*redactTmp{{"Ann", "123-45-6789", &email, 1234, "DE89370400440532013000"}, {Name: "Bob"}}
+	cells := func(buf *bytes.Buffer) []string {
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		row, _ := f.Sheets[0].Row(1)
+		var ls []string
+		_ = row.ForEachCell(func(c *xlsx.Cell) error {
+			ls = append(ls, c.Value)
+			return nil
+		})
+		return ls
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, records); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []string{"Ann", "123-45-6789", "ann@example.com", "1234", "DE89370400440532013000"}, cells(buf))
+
+	buf.Reset()
+	on := []*redactOnTmp{(*redactOnTmp)(records[0]), (*redactOnTmp)(records[1])}
+	if err := WriteTo(buf, on); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []string{"Ann", "*******6789", "a**@example.com", "****", "DE" + strings.Repeat("*", 20)}, cells(buf))
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(2)
+	equal(t, "", row.GetCell(1).Value)
+
+	err := WriteTo(&bytes.Buffer{}, []*redactUnknownTmp{{"1"}})
+	equal(t, true, errors.Is(err, ErrUnknownRedactor))
+}
+
+type pseudonymTmp struct {
+	Customer string `excel:"Customer,pseudonym=sha256"`
+	Email    string `excel:"Email,pseudonym=token,mask=first1"`
+	Country  string `excel:"Country"`
+}
+
+func (*pseudonymTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Pseudonymize = true
+	wc.Redact = true
+	wc.Pseudonymizers = map[string]Pseudonymizer{"token": HMACSHA256([]byte("secret"))}
+}
+
+func TestWritePseudonymize(t *testing.T) {
+	equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", HashSHA256("hello"))
+	equal(t, "9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b", HMACSHA256([]byte("key"))("hello"))
+
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*pseudonymTmp{{"Ann", "ann@example.com", "DE"}, {"Ann", "", "FR"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	first, _ := f.Sheets[0].Row(1)
+	second, _ := f.Sheets[0].Row(2)
+	equal(t, HashSHA256("Ann"), first.GetCell(0).Value)
+	equal(t, first.GetCell(0).Value, second.GetCell(0).Value)
+	token := HMACSHA256([]byte("secret"))("ann@example.com")
+	equal(t, token[:1]+strings.Repeat("*", len(token)-1), first.GetCell(1).Value)
+	equal(t, "", second.GetCell(1).Value)
+	equal(t, "DE", first.GetCell(2).Value)
+}
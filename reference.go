@@ -0,0 +1,57 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownReference is matched by the errors of values missing in their ReadConfig.ReferenceSets.
+var ErrUnknownReference = errors.New("exl: unknown reference")
+
+type (
+	// Set is a set of known values, see ReadConfig.ReferenceSets.
+	// It may be backed by a database, Contains is called once per non-empty cell.
+	Set interface {
+		Contains(value string) bool
+	}
+	// StringSet is a Set of strings held in memory.
+	StringSet map[string]struct{}
+)
+
+// NewStringSet returns the set of values.
+func NewStringSet(values ...string) StringSet {
+	s := make(StringSet, len(values))
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// Contains implements Set.
+func (s StringSet) Contains(value string) bool {
+	_, ok := s[value]
+	return ok
+}
+
+// checkReference returns an error matching ErrUnknownReference unless value is empty or contained in refs
+func (b *rowBinder) checkReference(refs Set, value string) error {
+	if b.rc.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" || refs.Contains(value) {
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrUnknownReference, value)
+}
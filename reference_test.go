@@ -0,0 +1,81 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type referenceTmp struct {
+	Customer string `excel:"Customer"`
+	SKU      int    `excel:"SKU"`
+}
+
+func (*referenceTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.TrimSpace = true
+	rc.ReferenceSets = map[string]Set{
+		"Customer": NewStringSet("C1", "C2"),
+		"SKU":      NewStringSet("100", "200"),
+	}
+}
+
+func TestReadReferenceSets(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Customer", "SKU"},
+		{"C1", "100"},
+		{" C2 ", "200"},
+		{"C3", "300"},
+		{"", "200"},
+	})
+	_, err := ReadBinary[*referenceTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 2, len(ce.FieldErrors))
+	equal(t, "A4", ce.FieldErrors[0].CellRef)
+	equal(t, true, errors.Is(ce.FieldErrors[0], ErrUnknownReference))
+	equal(t, `error unmarshalling column "SKU" in row 4: exl: unknown reference "300"`, ce.FieldErrors[1].Error())
+
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Customer", "SKU"}, {"C2", "200"}})
+	ts, err := ReadBinary[*referenceTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*referenceTmp{{"C2", 200}}, ts)
+}
+
+type referenceDropListTmp struct {
+	Country string `excel:"Country"`
+}
+
+func (*referenceDropListTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.ReferenceSets = map[string]Set{"Country": NewStringSet("United States")}
+	rc.DropListMap = map[string][]struct {
+		Key   string
+		Value string
+	}{"Country": {{"US", "United States"}, {"CA", "Canada"}}}
+}
+
+func TestReadReferenceDropList(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Country"}, {"United States"}, {"Canada"}})
+	ts, err := ReadBinary[*referenceDropListTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 1, len(ce.FieldErrors))
+	equal(t, "A3", ce.FieldErrors[0].CellRef)
+	equal(t, true, errors.Is(ce.FieldErrors[0], ErrUnknownReference))
+	equal(t, 0, len(ts))
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type (
+	// ErrorReport is the JSON serializable form of a read error,
+	// e.g. to return structured import errors from an API.
+	ErrorReport struct {
+		Message      string `json:"message"`
+		LimitReached bool   `json:"limitReached"`
+		// See ContentError.TotalErrors and ContentError.FailedRows
+		TotalErrors int                `json:"totalErrors,omitempty"`
+		FailedRows  int                `json:"failedRows,omitempty"`
+		Errors      []FieldErrorReport `json:"errors"`
+		// The error groups with ReadConfig.GroupErrors, Errors then holds the first error of each.
+		Groups []ErrorGroupReport `json:"groups,omitempty"`
+	}
+	// FieldErrorReport is the JSON serializable form of a FieldError.
+	FieldErrorReport struct {
+		Row          int    `json:"row"`    // 1-based row number
+		Column       int    `json:"column"` // 1-based column number
+		Cell         string `json:"cell"`
+		Header       string `json:"header"`
+		Value        string `json:"value"`
+		ExpectedType string `json:"expectedType"`
+		Message      string `json:"message"`
+		Severity     string `json:"severity,omitempty"` // "warning" for warnings, else empty
+	}
+)
+
+type (
+	// ReadReport describes a read beyond the returned records,
+	// see ReadWithReport.
+	ReadReport struct {
+		// Number of data rows bound, including rows dropped by filter funcs.
+		Rows     int       `json:"rows"`
+		Warnings []Warning `json:"warnings"`
+		// The errors of unmarshalers marked with Warn, which didn't fail their rows.
+		FieldWarnings []FieldError `json:"fieldWarnings,omitempty"`
+	}
+	// WarningKind classifies a Warning.
+	WarningKind string
+	// Warning reports an anomaly which did not prevent reading,
+	// RowIndex is -1 for warnings about a whole column.
+	Warning struct {
+		Kind         WarningKind `json:"kind"`
+		RowIndex     int         `json:"row"`    // 0-based row index, -1 for the whole column.
+		ColumnIndex  int         `json:"column"` // 0-based column index.
+		ColumnHeader string      `json:"header"`
+		CellRef      string      `json:"cell,omitempty"`
+		Message      string      `json:"message"`
+	}
+)
+
+const (
+	// WarningUnknownColumn
+	// A column was skipped as no field matches its header
+	WarningUnknownColumn WarningKind = "unknown_column"
+	// WarningUnsupportedType
+	// A column was skipped as its field type has no unmarshaler
+	WarningUnsupportedType WarningKind = "unsupported_type"
+	// WarningSanitized
+	// A cell value was changed by a Transformer
+	WarningSanitized WarningKind = "sanitized"
+	// WarningCoerced
+	// A text cell was read as number, see ReadConfig.CoerceNumbers
+	WarningCoerced WarningKind = "coerced"
+	// WarningNotOneOf
+	// A column holds values outside the set of its oneof tag option, see ReadConfig.WarnOneOf
+	WarningNotOneOf WarningKind = "not_one_of"
+)
+
+// warn adds a warning, it is a no-op on a nil report.
+func (r *ReadReport) warn(w Warning) {
+	if r == nil {
+		return
+	}
+	if w.RowIndex >= 0 && w.CellRef == "" {
+		w.CellRef = CellRef(w.RowIndex, w.ColumnIndex)
+	}
+	r.Warnings = append(r.Warnings, w)
+}
+
+// String implements fmt.Stringer.
+func (w Warning) String() string {
+	if w.RowIndex < 0 {
+		return fmt.Sprintf("column \"%s\": %s", w.ColumnHeader, w.Message)
+	}
+	return fmt.Sprintf("column \"%s\" in row %d: %s", w.ColumnHeader, w.RowIndex+1, w.Message)
+}
+
+var (
+	// Ensure the errors marshal to their report
+	_ json.Marshaler = FieldError{}
+	_ json.Marshaler = ContentError{}
+)
+
+// ToReport returns the serializable form of the error.
+func (e FieldError) ToReport() FieldErrorReport {
+	r := FieldErrorReport{
+		Row:          e.RowIndex + 1,
+		Column:       e.ColumnIndex + 1,
+		Cell:         e.CellRef,
+		Header:       e.ColumnHeader,
+		Value:        e.Value,
+		ExpectedType: e.ExpectedType,
+		Message:      e.Err.Error(),
+	}
+	if e.Severity != SeverityError {
+		r.Severity = e.Severity.String()
+	}
+	return r
+}
+
+// ToReport returns the serializable form of the error.
+func (e ContentError) ToReport() *ErrorReport {
+	r := &ErrorReport{
+		Message:      e.Error(),
+		LimitReached: e.LimitReached,
+		TotalErrors:  e.TotalErrors,
+		FailedRows:   e.FailedRows,
+		Errors:       make([]FieldErrorReport, len(e.FieldErrors)),
+	}
+	if e.grouped {
+		r.Errors = r.Errors[:0]
+		for _, g := range e.Groups() {
+			r.Errors = append(r.Errors, g.First.ToReport())
+			r.Groups = append(r.Groups, g.ToReport())
+		}
+		return r
+	}
+	for i, fe := range e.FieldErrors {
+		r.Errors[i] = fe.ToReport()
+	}
+	return r
+}
+
+// MarshalJSON implements json.Marshaler, marshalling the report of the error.
+func (e FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.ToReport())
+}
+
+// MarshalJSON implements json.Marshaler, marshalling the report of the error.
+func (e ContentError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.ToReport())
+}
+
+// ToReport returns the serializable form of any error returned by reading,
+// field errors are listed in ErrorReport.Errors, or nil for a nil error.
+func ToReport(err error) *ErrorReport {
+	if err == nil {
+		return nil
+	}
+	var ce ContentError
+	if errors.As(err, &ce) {
+		return ce.ToReport()
+	}
+	r := &ErrorReport{Message: err.Error(), Errors: []FieldErrorReport{}}
+	var fe FieldError
+	if errors.As(err, &fe) {
+		r.Errors = append(r.Errors, fe.ToReport())
+	}
+	return r
+}
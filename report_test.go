@@ -0,0 +1,73 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type reportTmp struct {
+	Qty int `excel:"Qty"`
+}
+
+func (*reportTmp) ReadConfigure(rc *ReadConfig) { rc.UnmarshalErrorHandling = UnmarshalErrorCollect }
+
+func TestErrorReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Qty"}, {"apple", "many"}})
+	_, err := Read[*reportTmp](buf)
+	bs, jerr := json.Marshal(err)
+	equal(t, nil, jerr)
+	equal(t, `{"message":"1 errors reading data from Excel","limitReached":false,"errors":[`+
+		`{"row":2,"column":2,"cell":"B2","header":"Qty","value":"many","expectedType":"int",`+
+		`"message":"error parsing cell as integer value: strconv.ParseInt: parsing \"many\": invalid syntax"}]}`, string(bs))
+
+	equal(t, err.(ContentError).ToReport(), ToReport(err))
+	equal(t, &ErrorReport{Message: ErrSheetNotFound.Error(), Errors: []FieldErrorReport{}}, ToReport(ErrSheetNotFound))
+	equal(t, (*ErrorReport)(nil), ToReport(nil))
+	fe := FieldError{RowIndex: 4, ColumnIndex: 1, CellRef: "B5", Err: ErrOverflow}
+	equal(t, 1, len(ToReport(fe).Errors))
+}
+
+type warningTmp struct {
+	Name string         `excel:"Name"`
+	Ch   chan int       `excel:"Channel"`
+	Map  map[string]int `excel:"-"`
+}
+
+func (*warningTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipUnknownTypes = true
+	rc.Transformers = []Transformer{TransformTrimSpace}
+}
+
+func TestReadWithReport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Channel", "Color"}, {" apple", "", "red"}, {"pear", "", ""}})
+	ts, report, err := ReadWithReport[*warningTmp](buf)
+	equal(t, nil, err)
+	equal(t, 2, len(ts))
+	equal(t, 2, report.Rows)
+	equal(t, []Warning{
+		{Kind: WarningUnsupportedType, RowIndex: -1, ColumnIndex: 1, ColumnHeader: "Channel", Message: "no unmarshaler for type chan int, column skipped"},
+		{Kind: WarningUnknownColumn, RowIndex: -1, ColumnIndex: 2, ColumnHeader: "Color", Message: "no destination field, column skipped"},
+		{Kind: WarningSanitized, RowIndex: 1, ColumnIndex: 0, ColumnHeader: "Name", CellRef: "A2", Message: `value " apple" changed to "apple"`},
+	}, report.Warnings)
+	equal(t, `column "Name" in row 2: value " apple" changed to "apple"`, report.Warnings[2].String())
+
+	_, report, err = ReadBinaryWithReport[*warningTmp]([]byte("no excel"))
+	if err == nil {
+		t.Error("expected error")
+	}
+	equal(t, 0, len(report.Warnings))
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRequired is matched by the errors of empty cells required by a RequiredRule.
+var ErrRequired = errors.New("exl: value required")
+
+// RequiredRule requires the cells of column Column to be non-empty depending on the value of column When
+// in the same row, see ReadConfig.RequiredRules.
+// The rule holds if Condition returns true for the value, or else if the value is one of Equals,
+// or else if the value is non-empty.
+// Rules are also set by the requiredif tag option, e.g. `excel:"State,requiredif=Country=US|CA"`
+// requiring State for the countries US and CA, or `excel:"Reason,requiredif=Refund"` requiring Reason
+// whenever Refund is set.
+type RequiredRule struct {
+	Column    string
+	When      string
+	Equals    []string
+	Condition func(value string) bool
+}
+
+// holds reports whether the rule requires a value if column When has value
+func (r RequiredRule) holds(value string) bool {
+	switch {
+	case r.Condition != nil:
+		return r.Condition(value)
+	case r.Equals != nil:
+		return containsString(r.Equals, value)
+	}
+	return value != ""
+}
+
+// requiredCondition is a RequiredRule of a column, with the index of column When,
+// -1 if the sheet has none, which is read as empty
+type requiredCondition struct {
+	rule  RequiredRule
+	index int
+}
+
+// requiredIfRule returns the rule of the requiredif option of the field of column header
+func requiredIfRule(header string, opts tagOptions) (RequiredRule, bool) {
+	value, ok := opts.Value("requiredif")
+	if !ok {
+		return RequiredRule{}, false
+	}
+	r := RequiredRule{Column: header}
+	if when, equals, ok := strings.Cut(value, "="); ok {
+		r.When, r.Equals = when, strings.Split(equals, "|")
+	} else {
+		r.When = value
+	}
+	return r, true
+}
+
+// requiredConditions returns the conditions of column header, by the requiredif option and ReadConfig.RequiredRules
+func requiredConditions(header string, opts tagOptions, headers []string, rc *ReadConfig) []requiredCondition {
+	var conds []requiredCondition
+	add := func(r RequiredRule) {
+		index := -1
+		for i, h := range headers {
+			if h == r.When {
+				index = i
+				break
+			}
+		}
+		conds = append(conds, requiredCondition{rule: r, index: index})
+	}
+	if r, ok := requiredIfRule(header, opts); ok {
+		add(r)
+	}
+	for _, r := range rc.RequiredRules {
+		if r.Column == header {
+			add(r)
+		}
+	}
+	return conds
+}
+
+// checkRequired returns an error matching ErrRequired if value is empty but required by any of conds in row
+func (b *rowBinder) checkRequired(conds []requiredCondition, row *Row, value string) error {
+	if strings.TrimSpace(value) != "" {
+		return nil
+	}
+	for _, c := range conds {
+		other := row.Cell(c.index).Value
+		if b.rc.TrimSpace {
+			other = strings.TrimSpace(other)
+		}
+		if c.rule.holds(other) {
+			return fmt.Errorf("%w when %s is %q", ErrRequired, c.rule.When, other)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type requiredTmp struct {
+	Country string  `excel:"Country"`
+	State   string  `excel:"State,requiredif=Country=US|CA"`
+	Refund  *int    `excel:"Refund"`
+	Reason  *string `excel:"Reason"`
+}
+
+func (*requiredTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.PointerCanNil = true
+	rc.RequiredRules = []RequiredRule{{Column: "Reason", When: "Refund"}}
+}
+
+func TestReadRequiredRules(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Country", "State", "Refund", "Reason"},
+		{"US", "NY", "", ""},
+		{"DE", "", "5", "damaged"},
+		{"CA", " ", "", ""},
+		{"FR", "", "10", ""},
+	})
+	_, err := ReadBinary[*requiredTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 2, len(ce.FieldErrors))
+	equal(t, "B4", ce.FieldErrors[0].CellRef)
+	equal(t, true, errors.Is(ce.FieldErrors[0], ErrRequired))
+	equal(t, `error unmarshalling column "Reason" in row 5: exl: value required when Refund is "10"`, ce.FieldErrors[1].Error())
+
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Country", "State"}, {"CA", "QC"}, {"DE", ""}})
+	ts, err := ReadBinary[*requiredTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, 2, len(ts))
+	equal(t, "QC", ts[0].State)
+}
+
+func TestRequiredRuleCondition(t *testing.T) {
+	r := RequiredRule{Column: "Discount", When: "Total", Condition: func(value string) bool { return len(value) > 3 }}
+	equal(t, true, r.holds("1000"))
+	equal(t, false, r.holds("999"))
+	equal(t, true, RequiredRule{When: "Status", Equals: []string{""}}.holds(""))
+}
@@ -0,0 +1,239 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// roundTripText is a custom type using the text marshaling interfaces
+type roundTripText struct{ A, B string }
+
+func (r roundTripText) MarshalText() ([]byte, error) { return []byte(r.A + "/" + r.B), nil }
+func (r *roundTripText) UnmarshalText(text []byte) error {
+	r.A, r.B, _ = strings.Cut(string(text), "/")
+	return nil
+}
+
+// roundTripCode is a custom type using the excel marshaling interfaces
+type roundTripCode int
+
+func (c roundTripCode) MarshalExcel() (Cell, error) {
+	return TextCell(strings.Repeat("x", int(c))), nil
+}
+func (c *roundTripCode) UnmarshalExcel(cell *xlsx.Cell, _ *ExcelUnmarshalParameters) error {
+	*c = roundTripCode(len(cell.Value))
+	return nil
+}
+
+// roundTripPtrText is a custom type marshaling text by a method with pointer receiver
+type roundTripPtrText struct{ S string }
+
+func (r *roundTripPtrText) MarshalText() ([]byte, error) { return []byte("C-" + r.S), nil }
+func (r *roundTripPtrText) UnmarshalText(text []byte) error {
+	r.S = strings.TrimPrefix(string(text), "C-")
+	return nil
+}
+
+// roundTripPtrCode is a custom type marshaling cells by a method with pointer receiver
+type roundTripPtrCode int
+
+func (c *roundTripPtrCode) MarshalExcel() (Cell, error) {
+	return TextCell(strings.Repeat("y", int(*c))), nil
+}
+func (c *roundTripPtrCode) UnmarshalExcel(cell *xlsx.Cell, _ *ExcelUnmarshalParameters) error {
+	*c = roundTripPtrCode(len(cell.Value))
+	return nil
+}
+
+type roundTripTmp struct {
+	String  string        `excel:"String"`
+	Int     int           `excel:"Int"`
+	Int8    int8          `excel:"Int8"`
+	Int16   int16         `excel:"Int16"`
+	Int32   int32         `excel:"Int32"`
+	Int64   int64         `excel:"Int64"`
+	Uint    uint          `excel:"Uint"`
+	Uint8   uint8         `excel:"Uint8"`
+	Uint16  uint16        `excel:"Uint16"`
+	Uint32  uint32        `excel:"Uint32"`
+	Uint64  uint64        `excel:"Uint64"`
+	Float32 float32       `excel:"Float32"`
+	Float64 float64       `excel:"Float64"`
+	Bool    bool          `excel:"Bool"`
+	Time    time.Time     `excel:"Time"`
+	Text    roundTripText `excel:"Text"`
+	Code    roundTripCode `excel:"Code"`
+
+	PtrText roundTripPtrText `excel:"PtrText"`
+	PtrCode roundTripPtrCode `excel:"PtrCode"`
+
+	StringPtr  *string        `excel:"StringPtr"`
+	IntPtr     *int           `excel:"IntPtr"`
+	Uint64Ptr  *uint64        `excel:"Uint64Ptr"`
+	Float64Ptr *float64       `excel:"Float64Ptr"`
+	BoolPtr    *bool          `excel:"BoolPtr"`
+	TimePtr    *time.Time     `excel:"TimePtr"`
+	TextPtr    *roundTripText `excel:"TextPtr"`
+	CodePtr    *roundTripCode `excel:"CodePtr"`
+
+	PtrTextPtr *roundTripPtrText `excel:"PtrTextPtr"`
+}
+
+func (*roundTripTmp) ReadConfigure(rc *ReadConfig)  { rc.PointerCanNil = true }
+func (*roundTripTmp) WriteConfigure(_ *WriteConfig) {}
+
+type roundTripChineseTmp roundTripTmp
+
+func (*roundTripChineseTmp) ReadConfigure(rc *ReadConfig)   { rc.PointerCanNil = true }
+func (*roundTripChineseTmp) WriteConfigure(wc *WriteConfig) { wc.ChineseBool = true }
+
+// Generate implements quick.Generator, as time.Time can't be generated
+func (roundTripTmp) Generate(r *rand.Rand, size int) reflect.Value {
+	str := func() string {
+		const letters = "abcXYZ 012-_.äß中文"
+		rs := []rune(letters)
+		b := make([]rune, r.Intn(size+1))
+		for i := range b {
+			b[i] = rs[r.Intn(len(rs))]
+		}
+		return string(b)
+	}
+	tm := func() time.Time {
+		if r.Intn(5) == 0 {
+			return time.Time{}
+		}
+		return time.Unix(r.Int63n(4e9), 0).UTC()
+	}
+	v := roundTripTmp{
+		String:  str(),
+		Int:     int(r.Int63() - r.Int63()),
+		Int8:    int8(r.Intn(math.MaxUint8)),
+		Int16:   int16(r.Intn(math.MaxUint16)),
+		Int32:   r.Int31() - r.Int31(),
+		Int64:   r.Int63() - r.Int63(),
+		Uint:    uint(r.Uint64()),
+		Uint8:   uint8(r.Intn(math.MaxUint8)),
+		Uint16:  uint16(r.Intn(math.MaxUint16)),
+		Uint32:  r.Uint32(),
+		Uint64:  r.Uint64(),
+		Float32: float32(r.NormFloat64()),
+		Float64: r.NormFloat64() * math.Pow(10, float64(r.Intn(20)-10)),
+		Bool:    r.Intn(2) == 0,
+		Time:    tm(),
+		Text:    roundTripText{str(), str()},
+		Code:    roundTripCode(r.Intn(size + 1)),
+		PtrText: roundTripPtrText{str()},
+		PtrCode: roundTripPtrCode(r.Intn(size + 1)),
+	}
+	if r.Intn(2) == 0 {
+		s := str() + "s"
+		v.StringPtr = &s
+	}
+	if r.Intn(2) == 0 {
+		i := r.Int() - r.Int()
+		v.IntPtr = &i
+	}
+	if r.Intn(2) == 0 {
+		u := r.Uint64()
+		v.Uint64Ptr = &u
+	}
+	if r.Intn(2) == 0 {
+		f := r.Float64()
+		v.Float64Ptr = &f
+	}
+	if r.Intn(2) == 0 {
+		b := r.Intn(2) == 0
+		v.BoolPtr = &b
+	}
+	if r.Intn(2) == 0 {
+		t := time.Unix(r.Int63n(4e9), 0).UTC()
+		v.TimePtr = &t
+	}
+	if r.Intn(2) == 0 {
+		v.TextPtr = &roundTripText{str(), str()}
+	}
+	if r.Intn(2) == 0 {
+		c := roundTripCode(r.Intn(size) + 1)
+		v.CodePtr = &c
+	}
+	if r.Intn(2) == 0 {
+		v.PtrTextPtr = &roundTripPtrText{str()}
+	}
+	return reflect.ValueOf(v)
+}
+
+func TestRoundTrip(t *testing.T) {
+	check := func(records []roundTripTmp) bool {
+		ts := make([]*roundTripTmp, len(records))
+		for i := range records {
+			ts[i] = &records[i]
+		}
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, ts); err != nil {
+			t.Error(err)
+			return false
+		}
+		read, err := Read[*roundTripTmp](bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		if len(ts) == 0 {
+			return len(read) == 0
+		}
+		if !reflect.DeepEqual(ts, read) {
+			for i := range ts {
+				if !reflect.DeepEqual(ts[i], read[i]) {
+					t.Errorf("wrote %+v, read %+v", *ts[i], *read[i])
+					break
+				}
+			}
+			return false
+		}
+
+		chinese := make([]*roundTripChineseTmp, len(ts))
+		for i := range ts {
+			chinese[i] = (*roundTripChineseTmp)(ts[i])
+		}
+		buf.Reset()
+		if err = WriteTo(buf, chinese); err != nil {
+			t.Error(err)
+			return false
+		}
+		readChinese, err := Read[*roundTripChineseTmp](buf)
+		if err != nil {
+			t.Error(err)
+			return false
+		}
+		return reflect.DeepEqual(chinese, readChinese)
+	}
+	if err := quick.Check(check, &quick.Config{MaxCount: 50}); err != nil {
+		// Don't print the generated records, the failing one has been logged
+		var ce *quick.CheckError
+		if errors.As(err, &ce) {
+			err = fmt.Errorf("round trip failed in run %d", ce.Count)
+		}
+		t.Error(err)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type (
+	// CellType describes the kind of value stored in a Cell.
+	CellType uint8
+	// Cell is the format independent representation of a single spreadsheet cell.
+	Cell struct {
+		Type CellType
+		// The raw value as stored in the file,
+		// e.g. "1" for a true boolean or the serial number of a date.
+		Value string
+		// The number format code of the cell, if any.
+		NumFmt string
+		// The formula of the cell, if any.
+		Formula string
+	}
+	// Row is the format independent representation of a spreadsheet row.
+	// Both the reading and the writing binding engine operate on rows,
+	// so every spreadsheet format only has to convert from and to this model.
+	Row struct {
+		// 0-based index of the row within its sheet.
+		Index int
+		Cells []Cell
+		// Outline level of the row, rows with a level above 0 form collapsible groups.
+		OutlineLevel uint8
+		// The xlsx cells read by column, passed to unmarshalers with their style, hyperlink and row,
+		// nil for other backends
+		src []*xlsx.Cell
+	}
+)
+
+const (
+	// CellTypeEmpty
+	// The cell does not hold any value
+	CellTypeEmpty CellType = iota
+	// CellTypeString
+	// The cell holds text
+	CellTypeString
+	// CellTypeNumber
+	// The cell holds a number, dates and times are numbers with a date format
+	CellTypeNumber
+	// CellTypeBool
+	// The cell holds a boolean, stored as "1" or "0"
+	CellTypeBool
+	// CellTypeError
+	// The cell holds an error value such as #DIV/0!
+	CellTypeError
+)
+
+// StringCell returns a text cell
+func StringCell(s string) Cell { return Cell{Type: CellTypeString, Value: s} }
+
+// TextCell returns a text cell with the text number format "@",
+// so spreadsheet applications keep digits entered later as text too.
+func TextCell(s string) Cell { return Cell{Type: CellTypeString, Value: s, NumFmt: "@"} }
+
+// NumberCell returns a numeric cell with the general number format
+func NumberCell(n float64) Cell {
+	return Cell{Type: CellTypeNumber, Value: strconv.FormatFloat(n, 'f', -1, 64), NumFmt: "general"}
+}
+
+// BoolCell returns a boolean cell
+func BoolCell(b bool) Cell {
+	if b {
+		return Cell{Type: CellTypeBool, Value: "1"}
+	}
+	return Cell{Type: CellTypeBool, Value: "0"}
+}
+
+// TimeCell returns a date cell displayed with the given number format
+func TimeCell(t time.Time, format string) Cell {
+	return timeCell(t, format, false)
+}
+
+// timeCell returns a date cell in the 1904 date system if date1904, see TimeCell
+func timeCell(t time.Time, format string, date1904 bool) Cell {
+	serial := xlsx.TimeToExcelTime(t.In(time.UTC), date1904)
+	return Cell{Type: CellTypeNumber, Value: strconv.FormatFloat(serial, 'f', -1, 64), NumFmt: format}
+}
+
+// NewCell returns a cell holding v, using the cell type matching the Go type of v.
+// Values of unknown types are written as their default text representation,
+// a Cell is returned as it is.
+func NewCell(v any) Cell {
+	switch t := v.(type) {
+	case nil:
+		return StringCell("")
+	case Cell:
+		return t
+	case string:
+		return StringCell(t)
+	case []byte:
+		return StringCell(string(t))
+	case bool:
+		return BoolCell(t)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return Cell{Type: CellTypeNumber, Value: fmt.Sprintf("%d", t), NumFmt: "general"}
+	case float32:
+		return Cell{Type: CellTypeNumber, Value: strconv.FormatFloat(float64(t), 'f', -1, 32), NumFmt: "general"}
+	case float64:
+		return NumberCell(t)
+	case time.Time:
+		return TimeCell(t, xlsx.DefaultDateTimeFormat)
+	default:
+		return StringCell(fmt.Sprintf("%v", t))
+	}
+}
+
+// NewRow returns a row holding one cell per value, see NewCell
+func NewRow(values ...any) *Row {
+	r := &Row{Cells: make([]Cell, len(values))}
+	for i, v := range values {
+		r.Cells[i] = NewCell(v)
+	}
+	return r
+}
+
+// Cell returns the cell at the 0-based column index,
+// or an empty cell if the row is shorter than that.
+func (r *Row) Cell(col int) Cell {
+	if col < 0 || col >= len(r.Cells) {
+		return Cell{}
+	}
+	return r.Cells[col]
+}
+
+// Strings returns the raw values of all cells in the row
+func (r *Row) Strings() []string {
+	ls := make([]string, len(r.Cells))
+	for i, c := range r.Cells {
+		ls[i] = c.Value
+	}
+	return ls
+}
+
+// MatchRow returns a predicate reporting whether re matches the raw values of a row joined by tabs,
+// e.g. for ReadConfig.SkipRowsMatching.
+func MatchRow(re *regexp.Regexp) func(row *Row) bool {
+	return func(row *Row) bool {
+		return re.MatchString(strings.Join(row.Strings(), "\t"))
+	}
+}
+
+// IsEmpty reports whether no cell of the row holds a value
+func (r *Row) IsEmpty() bool {
+	for _, c := range r.Cells {
+		if c.Value != "" || c.Formula != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func cellFromXLSX(c *xlsx.Cell) Cell {
+	cell := Cell{Value: c.Value, NumFmt: c.NumFmt, Formula: c.Formula()}
+	switch c.Type() {
+	case xlsx.CellTypeNumeric:
+		cell.Type = CellTypeNumber
+	case xlsx.CellTypeBool:
+		cell.Type = CellTypeBool
+	case xlsx.CellTypeError:
+		cell.Type = CellTypeError
+	default:
+		cell.Type = CellTypeString
+	}
+	if cell.Type == CellTypeString && cell.Value == "" && cell.Formula == "" {
+		cell.Type = CellTypeEmpty
+	}
+	return cell
+}
+
+func rowFromXLSX(index, maxCol int, row *xlsx.Row) *Row {
+	r := &Row{Index: index, Cells: make([]Cell, maxCol), OutlineLevel: row.GetOutlineLevel(), src: make([]*xlsx.Cell, maxCol)}
+	for i := 0; i < maxCol; i++ {
+		r.src[i] = row.GetCell(i)
+		r.Cells[i] = cellFromXLSX(r.src[i])
+	}
+	return r
+}
+
+// xlsxCell returns the xlsx cell read at col, nil if there is none,
+// or a copy holding the value of cell if it changed since, e.g. by a Transformer
+func (r *Row) xlsxCell(col int, cell Cell) *xlsx.Cell {
+	if col < 0 || col >= len(r.src) || r.src[col] == nil {
+		return nil
+	}
+	src := r.src[col]
+	if cell.Value == src.Value {
+		return src
+	}
+	dest := *src
+	dest.Value = cell.Value
+	return &dest
+}
+
+// cellXLSX returns the cell at col as xlsx cell, the one read if any, see Cell.XLSX
+func (r *Row) cellXLSX(col int) *xlsx.Cell {
+	if xc := r.xlsxCell(col, r.Cell(col)); xc != nil {
+		return xc
+	}
+	return r.Cell(col).XLSX()
+}
+
+// toXLSX copies the cell into the given xlsx cell
+func (c Cell) toXLSX(dest *xlsx.Cell) {
+	switch c.Type {
+	case CellTypeNumber:
+		dest.SetNumeric(c.Value)
+		if c.Formula != "" {
+			dest.SetFormula(c.Formula)
+		}
+	case CellTypeBool:
+		dest.SetBool(c.Value == "1")
+	case CellTypeEmpty:
+		dest.SetString("")
+	default:
+		dest.SetString(c.Value)
+		if c.Formula != "" {
+			dest.SetStringFormula(c.Formula)
+		}
+	}
+	if c.NumFmt != "" {
+		dest.SetFormat(c.NumFmt)
+	}
+	if c.Type == CellTypeError {
+		// xlsx offers no setter for error cells, keep the raw text instead
+		dest.Value = c.Value
+	}
+}
+
+// XLSX returns a detached xlsx cell holding the same value,
+// as expected by UnmarshalExcelFunc implementations.
+// Cells read by the XLSXBackend are unmarshalled from the xlsx cells read instead.
+func (c Cell) XLSX() *xlsx.Cell {
+	dest := &xlsx.Cell{}
+	c.toXLSX(dest)
+	return dest
+}
+
+func appendXLSXRow(sheet *xlsx.Sheet, row *Row) *xlsx.Row {
+	r := sheet.AddRow()
+	if row.OutlineLevel > 0 {
+		r.SetOutlineLevel(row.OutlineLevel)
+	}
+	for _, c := range row.Cells {
+		c.toXLSX(r.AddCell())
+	}
+	return r
+}
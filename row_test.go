@@ -0,0 +1,69 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+func TestNewCell(t *testing.T) {
+	equal(t, Cell{Type: CellTypeString, Value: "abc"}, NewCell("abc"))
+	equal(t, Cell{Type: CellTypeString, Value: ""}, NewCell(nil))
+	equal(t, Cell{Type: CellTypeBool, Value: "1"}, NewCell(true))
+	equal(t, Cell{Type: CellTypeNumber, Value: "-12", NumFmt: "general"}, NewCell(int8(-12)))
+	equal(t, Cell{Type: CellTypeNumber, Value: "12", NumFmt: "general"}, NewCell(uint(12)))
+	equal(t, Cell{Type: CellTypeNumber, Value: "1.5", NumFmt: "general"}, NewCell(1.5))
+	equal(t, Cell{Type: CellTypeString, Value: "[1 2]"}, NewCell([]int{1, 2}))
+	tm := time.Date(2023, time.November, 13, 0, 0, 0, 0, time.UTC)
+	equal(t, Cell{Type: CellTypeNumber, Value: "45243", NumFmt: xlsx.DefaultDateTimeFormat}, NewCell(tm))
+}
+
+func TestRow(t *testing.T) {
+	row := NewRow("a", 1, false)
+	equal(t, []string{"a", "1", "0"}, row.Strings())
+	equal(t, Cell{}, row.Cell(3))
+	equal(t, Cell{}, row.Cell(-1))
+	equal(t, false, row.IsEmpty())
+	equal(t, true, (&Row{Cells: []Cell{{}, StringCell("")}}).IsEmpty())
+	equal(t, true, MatchRow(regexp.MustCompile(`^a\t1\t`))(row))
+	equal(t, false, MatchRow(regexp.MustCompile(`^1`))(row))
+}
+
+func TestCellXLSX(t *testing.T) {
+	t.Run("number", func(t *testing.T) {
+		c := NumberCell(12.5).XLSX()
+		equal(t, xlsx.CellTypeNumeric, c.Type())
+		f, _ := c.Float()
+		equal(t, 12.5, f)
+	})
+	t.Run("bool", func(t *testing.T) {
+		c := BoolCell(true).XLSX()
+		equal(t, xlsx.CellTypeBool, c.Type())
+		equal(t, true, c.Bool())
+	})
+	t.Run("date", func(t *testing.T) {
+		tm := time.Date(2023, time.November, 13, 14, 15, 0, 0, time.UTC)
+		c := TimeCell(tm, xlsx.DefaultDateTimeFormat).XLSX()
+		equal(t, true, c.IsTime())
+		got, _ := c.GetTime(false)
+		equal(t, tm, got)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, cell := range []Cell{StringCell("abc"), BoolCell(false), NumberCell(3), {Type: CellTypeNumber, Value: "3", NumFmt: "general", Formula: "1+2"}} {
+			equal(t, cell, cellFromXLSX(cell.XLSX()))
+		}
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package exl
+
+import (
+	"io"
+	"iter"
+	"reflect"
+)
+
+// Rows returns an iterator over the records of the sheet of reader bound to `T` like Read,
+// for range-over-func loops handling the errors of rows inline:
+//
+//	for t, err := range exl.Rows[*Order](reader, nil) {
+//		if err != nil {
+//			// a FieldError, or ContentError with UnmarshalErrorCollect
+//			continue
+//		}
+//	}
+//
+// rc replaces the read config of T, nil to use it.
+// Each record is yielded once bound, records with child rows once the next record begins,
+// so breaking the loop stops reading the sheet.
+// The errors of a row are yielded with a nil T and the iteration goes on with the next row,
+// other errors, e.g. of opening the file, end the iteration.
+// Types with detail sheets aren't supported.
+func Rows[T ReadConfigurator](reader io.Reader, rc *ReadConfig) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		bs, err := io.ReadAll(reader)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if rc == nil {
+			rc = newReadConfig[T]()
+		}
+		book, err := openBook(rc, bs)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		_, err = readSpreadsheet[T](book, rc, nil, nil, func(rv reflect.Value, err error) bool {
+			if err != nil {
+				return yield(zero, err)
+			}
+			return yield(rv.Interface().(T), nil)
+		})
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestRows(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelAnyTo(buf, [][]any{{1, "a"}, {"x", "b"}, {3, "c"}, {"y", "d"}}, "ID", "Name")
+	data := buf.Bytes()
+
+	var ids []int
+	var errs []error
+	for p, err := range Rows[*pageTmp](bytes.NewReader(data), nil) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, p.ID)
+	}
+	equal(t, []int{1, 3}, ids)
+	equal(t, 2, len(errs))
+	var fe FieldError
+	equal(t, true, errors.As(errs[0], &fe))
+	equal(t, "A3", fe.CellRef)
+
+	// Errors are collected per row
+	rc := newReadConfig[*pageTmp]()
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.MaxUnmarshalErrors = 1
+	errs = errs[:0]
+	for _, err := range Rows[*pageTmp](bytes.NewReader(data), rc) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	equal(t, 2, len(errs))
+	var ce ContentError
+	equal(t, true, errors.As(errs[1], &ce))
+	equal(t, "A5", ce.FieldErrors[0].CellRef)
+
+	// Breaking stops reading
+	n := 0
+	for range Rows[*pageTmp](bytes.NewReader(data), nil) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	equal(t, 2, n)
+
+	orders := []*orderTmp{
+		{ID: "A1", Items: []*orderItemTmp{{"apple", 2}, {"pear", 1}}},
+		{ID: "A2"},
+		{ID: "A3", Items: []*orderItemTmp{{"plum", 5}}},
+	}
+	buf.Reset()
+	_ = WriteTo(buf, orders)
+	var read []*orderTmp
+	for o, err := range Rows[*orderTmp](buf, nil) {
+		equal(t, nil, err)
+		read = append(read, o)
+	}
+	equal(t, orders, read)
+
+	_, err := ReadBinary[*pageTmp]([]byte("no workbook"))
+	for _, rowErr := range Rows[*pageTmp](bytes.NewReader([]byte("no workbook")), nil) {
+		equal(t, err, rowErr)
+	}
+}
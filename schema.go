@@ -0,0 +1,198 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// InferredType is the type of the values of a column, see InferSchema.
+	InferredType string
+	// ColumnSchema describes the values of a column as inferred by InferSchema.
+	ColumnSchema struct {
+		// 0-based index and header of the column.
+		Index  int
+		Header string
+		// The narrowest type holding all values of the column,
+		// TypeString for columns without values.
+		Type InferredType
+		// The number of records with and without a value in the column.
+		Values int
+		Nulls  int
+		// Up to SchemaSamples distinct values in order of appearance.
+		Samples []string
+	}
+)
+
+const (
+	TypeInt    InferredType = "int"
+	TypeFloat  InferredType = "float"
+	TypeDate   InferredType = "date"
+	TypeBool   InferredType = "bool"
+	TypeString InferredType = "string"
+)
+
+// SchemaSamples is the number of sample values collected per column by InferSchema.
+const SchemaSamples = 3
+
+// inferDateFormats are tried for text cells after ReadConfig.FallbackDateFormats
+var inferDateFormats = []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339}
+
+// GoType returns the Go type for fields of the column, e.g. "float64".
+func (t InferredType) GoType() string {
+	switch t {
+	case TypeInt:
+		return "int64"
+	case TypeFloat:
+		return "float64"
+	case TypeDate:
+		return "time.Time"
+	case TypeBool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// Accepts reports whether the value of cell may be stored in a column of type t,
+// applying the rules of InferSchema, e.g. TypeFloat accepts integers and TypeString accepts anything.
+// Empty cells are accepted by all types.
+func (t InferredType) Accepts(cell Cell) bool {
+	value := strings.TrimSpace(cell.Value)
+	if t == TypeString || value == "" {
+		return true
+	}
+	ct := cellType(cell, value, inferDateFormats)
+	return ct == t || (t == TypeFloat && ct == TypeInt)
+}
+
+// InferSchema infers the type of the values of each column of a sheet without binding it to a struct,
+// e.g. to generate struct definitions or to process sheets of unknown layout.
+// The sheet, header and data rows are located as configured by rc, which may be nil to use the defaults.
+// Empty rows and rows skipped by SkipRowsMatching or FooterDetector aren't records.
+func InferSchema(reader io.Reader, rc *ReadConfig) ([]ColumnSchema, error) {
+	if rc == nil {
+		rc = defaultReadConfig()
+	}
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return nil, err
+	}
+	if rc.SheetIndex > len(book.Sheets())-1 {
+		return nil, ErrSheetIndexOutOfRange
+	}
+	headerRow, err := readRow(book, rc.SheetIndex, rc.HeaderRowIndex)
+	if err != nil {
+		return nil, err
+	}
+	if headerRow == nil {
+		return nil, ErrHeaderRowIndexOutOfRange
+	}
+	headers := headerNames(headerRow, rc)
+	columns := make([]ColumnSchema, len(headers))
+	for i, header := range headers {
+		columns[i] = ColumnSchema{Index: i, Header: header}
+	}
+	formats := append(append([]string{}, rc.FallbackDateFormats...), inferDateFormats...)
+	dataStart := rc.dataStartRowIndex()
+	err = book.Rows(rc.SheetIndex, func(row *Row) error {
+		if row.Index < dataStart || row.IsEmpty() {
+			return nil
+		}
+		if rc.SkipRowsMatching != nil && rc.SkipRowsMatching(row) {
+			return nil
+		}
+		if rc.FooterDetector != nil && rc.FooterDetector(row) {
+			return errStopRows
+		}
+		for i := range columns {
+			columns[i].add(row.Cell(i), rc.TrimSpace, formats)
+		}
+		return nil
+	})
+	if err != nil && err != errStopRows {
+		return nil, err
+	}
+	for i := range columns {
+		if columns[i].Type == "" {
+			columns[i].Type = TypeString
+		}
+	}
+	return columns, nil
+}
+
+// add widens the type of the column to hold the value of cell
+func (cs *ColumnSchema) add(cell Cell, trimSpace bool, formats []string) {
+	value := cell.Value
+	if trimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		cs.Nulls++
+		return
+	}
+	cs.Values++
+	if len(cs.Samples) < SchemaSamples && !containsString(cs.Samples, value) {
+		cs.Samples = append(cs.Samples, value)
+	}
+	t := cellType(cell, value, formats)
+	switch {
+	case cs.Type == "" || cs.Type == t:
+		cs.Type = t
+	case (cs.Type == TypeInt && t == TypeFloat) || (cs.Type == TypeFloat && t == TypeInt):
+		cs.Type = TypeFloat
+	default:
+		cs.Type = TypeString
+	}
+}
+
+// cellType returns the narrowest type of the non-empty value of cell
+func cellType(cell Cell, value string, formats []string) InferredType {
+	switch cell.Type {
+	case CellTypeBool:
+		return TypeBool
+	case CellTypeNumber:
+		if cell.XLSX().IsTime() {
+			return TypeDate
+		}
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return TypeInt
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return TypeFloat
+	}
+	if _, err := strconv.ParseBool(value); err == nil {
+		return TypeBool
+	}
+	if _, ok := unmarshalTimeFallback(value, formats, time.UTC); ok {
+		return TypeDate
+	}
+	return TypeString
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type schemaTmp struct {
+	Name    string    `excel:"Name"`
+	Qty     int       `excel:"Qty"`
+	Price   float64   `excel:"Price"`
+	Created time.Time `excel:"Created"`
+	Active  bool      `excel:"Active"`
+	Code    string    `excel:"Code"`
+	Note    string    `excel:"Note"`
+}
+
+func (*schemaTmp) WriteConfigure(*WriteConfig) {}
+
+func TestInferSchema(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, []*schemaTmp{
+		{"apple", 2, 1, created, true, "7", ""},
+		{"pear", 3, 1.5, created, false, "A-1", ""},
+		{"plum", 2, 2.25, created, true, "8", ""},
+		{"fig", 5, 3, created, true, "9", ""},
+	})
+	columns, err := InferSchema(bytes.NewReader(buf.Bytes()), nil)
+	equal(t, nil, err)
+	types := make([]InferredType, 0, len(columns))
+	for _, c := range columns {
+		types = append(types, c.Type)
+	}
+	equal(t, []InferredType{TypeString, TypeInt, TypeFloat, TypeDate, TypeBool, TypeString, TypeString}, types)
+	equal(t, ColumnSchema{Index: 1, Header: "Qty", Type: TypeInt, Values: 4, Samples: []string{"2", "3", "5"}}, columns[1])
+	equal(t, ColumnSchema{Index: 6, Header: "Note", Type: TypeString, Nulls: 4}, columns[6])
+	equal(t, "time.Time", columns[3].Type.GoType())
+
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Report"}, {"When", "Flag"}, {"2023-01-02", "TRUE"}, {"", "false"}})
+	rc := defaultReadConfig()
+	rc.HeaderRowIndex = 1
+	rc.DataStartRelative = true
+	columns, err = InferSchema(buf, rc)
+	equal(t, nil, err)
+	equal(t, []ColumnSchema{
+		{Index: 0, Header: "When", Type: TypeDate, Values: 1, Nulls: 1, Samples: []string{"2023-01-02"}},
+		{Index: 1, Header: "Flag", Type: TypeBool, Values: 2, Samples: []string{"TRUE", "false"}},
+	}, columns)
+}
+
+func TestInferredTypeAccepts(t *testing.T) {
+	equal(t, true, TypeFloat.Accepts(NumberCell(2)))
+	equal(t, true, TypeFloat.Accepts(StringCell("2.5")))
+	equal(t, false, TypeInt.Accepts(StringCell("2.5")))
+	equal(t, true, TypeInt.Accepts(StringCell(" ")))
+	equal(t, true, TypeDate.Accepts(TimeCell(time.Now(), "yyyy-mm-dd")))
+	equal(t, false, TypeDate.Accepts(NumberCell(2)))
+	equal(t, true, TypeBool.Accepts(BoolCell(true)))
+	equal(t, true, TypeString.Accepts(NumberCell(2)))
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "errors"
+
+// Severity classifies the errors of unmarshalers, see FieldError.Severity.
+type Severity uint8
+
+const (
+	// SeverityError
+	// The cell can't be read, handled as configured by ReadConfig.UnmarshalErrorHandling
+	SeverityError Severity = iota
+	// SeverityWarning
+	// The cell was read, but breaks a soft rule like "date in the future".
+	// Warnings never abort reading, they are reported in ReadReport.FieldWarnings
+	SeverityWarning
+)
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// severityError is an error of another severity than SeverityError, see Warn
+type severityError struct {
+	err      error
+	severity Severity
+}
+
+func (e *severityError) Error() string      { return e.err.Error() }
+func (e *severityError) Unwrap() error      { return e.err }
+func (e *severityError) Severity() Severity { return e.severity }
+
+// Warn marks err as warning: returned by an unmarshaler, the value it set is kept
+// and err is reported in ReadReport.FieldWarnings, instead of failing the row.
+// Errors may also implement Severity() Severity themselves.
+func Warn(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &severityError{err: err, severity: SeverityWarning}
+}
+
+// SeverityOf returns the severity of err, SeverityError unless err wraps an error
+// implementing Severity() Severity, like the errors returned by Warn.
+func SeverityOf(err error) Severity {
+	var s interface{ Severity() Severity }
+	if errors.As(err, &s) {
+		return s.Severity()
+	}
+	return SeverityError
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+var errFutureYear = errors.New("year in the future")
+
+type yearTmp int
+
+func (y *yearTmp) UnmarshalExcel(cell *xlsx.Cell, _ *ExcelUnmarshalParameters) error {
+	n, err := strconv.Atoi(cell.Value)
+	if err != nil {
+		return err
+	}
+	*y = yearTmp(n)
+	if n > 2100 {
+		return Warn(errFutureYear)
+	}
+	return nil
+}
+
+type severityTmp struct {
+	Name string  `excel:"Name"`
+	Year yearTmp `excel:"Year"`
+}
+
+func (*severityTmp) ReadConfigure(*ReadConfig) {}
+
+func TestReadFieldWarnings(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Year"}, {"a", "2023"}, {"b", "2999"}})
+	ts, report, err := ReadBinaryWithReport[*severityTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*severityTmp{{"a", 2023}, {"b", 2999}}, ts)
+	equal(t, 1, len(report.FieldWarnings))
+	fw := report.FieldWarnings[0]
+	equal(t, SeverityWarning, fw.Severity)
+	equal(t, "B3", fw.CellRef)
+	equal(t, true, errors.Is(fw, errFutureYear))
+	js, _ := json.Marshal(fw)
+	equal(t, `{"row":3,"column":2,"cell":"B3","header":"Year","value":"2999","expectedType":"exl.yearTmp","message":"year in the future","severity":"warning"}`, string(js))
+
+	// Errors still fail
+	buf.Reset()
+	_ = WriteExcelTo(buf, [][]string{{"Name", "Year"}, {"a", "soon"}})
+	_, err = ReadBinary[*severityTmp](buf.Bytes())
+	var fe FieldError
+	equal(t, true, errors.As(err, &fe))
+	equal(t, SeverityError, fe.Severity)
+
+	equal(t, SeverityError, SeverityOf(errFutureYear))
+	equal(t, nil, Warn(nil))
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSheetNameLength is the maximum number of characters of a sheet name.
+const MaxSheetNameLength = 31
+
+// forbiddenSheetNameChars are the characters Excel doesn't allow in sheet names
+const forbiddenSheetNameChars = `[]:*?/\`
+
+// ErrInvalidSheetName is matched by the SheetNameError of names Excel doesn't accept.
+var ErrInvalidSheetName = errors.New("exl: invalid sheet name")
+
+// SheetNameError is returned with WriteConfig.StrictSheetNames for names Excel doesn't accept,
+// see CheckSheetName.
+type SheetNameError struct {
+	Name   string
+	Reason string
+}
+
+// Error implements error.
+func (e *SheetNameError) Error() string {
+	return fmt.Sprintf("exl: invalid sheet name %q: %s", e.Name, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidSheetName.
+func (e *SheetNameError) Is(target error) bool {
+	return target == ErrInvalidSheetName
+}
+
+// CheckSheetName returns a *SheetNameError if Excel doesn't accept name as sheet name:
+// blank names, names longer than MaxSheetNameLength, containing any of []:*?/\,
+// starting or ending with ' and the reserved name History.
+func CheckSheetName(name string) error {
+	reason := ""
+	switch {
+	case strings.TrimSpace(name) == "":
+		reason = "is blank"
+	case utf8.RuneCountInString(name) > MaxSheetNameLength:
+		reason = fmt.Sprintf("is longer than %d characters", MaxSheetNameLength)
+	case strings.ContainsAny(name, forbiddenSheetNameChars):
+		reason = "contains any of " + forbiddenSheetNameChars
+	case strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'"):
+		reason = "starts or ends with '"
+	case strings.EqualFold(name, "History"):
+		reason = "is reserved"
+	default:
+		return nil
+	}
+	return &SheetNameError{Name: name, Reason: reason}
+}
+
+// SanitizeSheetName returns a name Excel accepts for name, see CheckSheetName:
+// the forbidden characters are removed, leading and trailing ' and spaces trimmed,
+// and the name is truncated to MaxSheetNameLength characters.
+// Blank names become "Sheet", and History becomes "History_".
+func SanitizeSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(forbiddenSheetNameChars, r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, "' ")
+	if utf8.RuneCountInString(name) > MaxSheetNameLength {
+		name = strings.TrimRight(string([]rune(name)[:MaxSheetNameLength]), "' ")
+	}
+	switch {
+	case name == "":
+		return "Sheet"
+	case strings.EqualFold(name, "History"):
+		return name + "_"
+	}
+	return name
+}
+
+// uniqueSheetName returns name, suffixed with " (2)", " (3)" and so on
+// if a sheet of the same name exists, ignoring case as Excel does
+func uniqueSheetName(name string, existing []string) string {
+	taken := func(name string) bool {
+		for _, e := range existing {
+			if strings.EqualFold(e, name) {
+				return true
+			}
+		}
+		return false
+	}
+	unique := name
+	for n := 2; taken(unique); n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		base := []rune(name)
+		if len(base)+len(suffix) > MaxSheetNameLength {
+			base = base[:MaxSheetNameLength-len(suffix)]
+		}
+		unique = string(base) + suffix
+	}
+	return unique
+}
+
+// newSheetName returns the name of a sheet added to book:
+// with strict, name must be valid and unique, otherwise it is sanitized and deduplicated
+func newSheetName(book Spreadsheet, name string, strict bool) (string, error) {
+	if !strict {
+		return uniqueSheetName(SanitizeSheetName(name), book.Sheets()), nil
+	}
+	if err := CheckSheetName(name); err != nil {
+		return "", err
+	}
+	if uniqueSheetName(name, book.Sheets()) != name {
+		return "", &SheetNameError{Name: name, Reason: "is used by another sheet"}
+	}
+	return name, nil
+}
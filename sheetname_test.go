@@ -0,0 +1,66 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeSheetName(t *testing.T) {
+	equal(t, "Q1 2023", SanitizeSheetName("Q1 2023"))
+	equal(t, "Sales 202301", SanitizeSheetName("Sales [2023/01]"))
+	equal(t, "Sheet", SanitizeSheetName(" *?: "))
+	equal(t, "quoted", SanitizeSheetName("'quoted'"))
+	equal(t, "history_", SanitizeSheetName("history"))
+	equal(t, strings.Repeat("ä", 31), SanitizeSheetName(strings.Repeat("ä", 40)))
+	equal(t, nil, CheckSheetName(SanitizeSheetName("a/b")))
+
+	err := CheckSheetName("a/b")
+	equal(t, true, errors.Is(err, ErrInvalidSheetName))
+	equal(t, `exl: invalid sheet name "a/b": contains any of []:*?/\`, err.Error())
+	equal(t, nil, CheckSheetName(strings.Repeat("a", 31)))
+	equal(t, true, CheckSheetName(strings.Repeat("a", 32)) != nil)
+
+	equal(t, "Sales (3)", uniqueSheetName("Sales", []string{"Sales", "sales (2)"}))
+	long := strings.Repeat("a", 31)
+	equal(t, strings.Repeat("a", 27)+" (2)", uniqueSheetName(long, []string{long}))
+}
+
+type longSheetTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*longSheetTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "Regional sales: North/East, 2023"
+}
+
+type strictSheetTmp longSheetTmp
+
+func (*strictSheetTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "Regional sales: North/East, 2023"
+	wc.StrictSheetNames = true
+}
+
+func TestWriteSheetNames(t *testing.T) {
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*longSheetTmp{{"a"}}))
+	info, _ := Inspect(bytes.NewReader(buf.Bytes()))
+	equal(t, "Regional sales NorthEast, 2023", info.Sheets[0].Name)
+
+	var sne *SheetNameError
+	err := WriteTo(buf, []*strictSheetTmp{{"a"}})
+	equal(t, true, errors.As(err, &sne))
+	equal(t, "Regional sales: North/East, 2023", sne.Name)
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// SheetTagName is the tag name of the embedded Sheet field.
+const SheetTagName = "exl"
+
+// Sheet configures the sheet of a record type by the tag of an embedded Sheet field,
+// so simple types neither implement ReadConfigurator nor WriteConfigurator:
+//
+//	type Order struct {
+//		exl.Sheet `exl:"sheet=Orders,header=2"`
+//		ID        int `excel:"ID"`
+//	}
+//
+// The options are comma separated:
+//   - sheet=Name sets WriteConfig.SheetName
+//   - sheetIndex=1 sets ReadConfig.SheetIndex
+//   - header=2 sets ReadConfig.HeaderRowIndex, reading the data from the row below
+//   - data=4 sets ReadConfig.DataStartRowIndex
+//   - trim sets ReadConfig.TrimSpace
+//
+// The options are applied to the defaults, before the ReadConfigure or WriteConfigure method
+// of a type embedding Sheet and implementing them itself, which keeps them when starting from
+// a base config with ReadConfig.Inherit or WriteConfig.Inherit.
+type Sheet struct{}
+
+// ReadConfigure keeps the config of the Sheet tag.
+func (*Sheet) ReadConfigure(*ReadConfig) {}
+
+// WriteConfigure keeps the config of the Sheet tag.
+func (*Sheet) WriteConfigure(*WriteConfig) {}
+
+var sheetType = reflect.TypeOf(Sheet{})
+
+// sheetTag returns the options of the Sheet field of typ, a pointer to a struct
+func sheetTag(typ reflect.Type) (tagOptions, bool) {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return "", false
+	}
+	typ = typ.Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		if isSheetField(typ.Field(i)) {
+			return tagOptions(typ.Field(i).Tag.Get(SheetTagName)), true
+		}
+	}
+	return "", false
+}
+
+// configTarget returns the value to call the ReadConfigure or WriteConfigure method of T on,
+// a nil T, or a pointer to a zero struct for types embedding Sheet,
+// as the promoted methods of Sheet can't be called on a nil pointer
+func configTarget[T any]() T {
+	var t T
+	if _, ok := sheetTag(reflect.TypeOf(t)); ok {
+		return reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
+	}
+	return t
+}
+
+// isSheetField reports whether fe is an embedded Sheet, which is no column
+func isSheetField(fe reflect.StructField) bool {
+	return fe.Anonymous && fe.Type == sheetType
+}
+
+// applySheetTag sets the options of the Sheet field of typ in rc
+func applySheetTag(typ reflect.Type, rc *ReadConfig) {
+	opts, _ := sheetTag(typ)
+	applySheetOptions(opts, rc)
+}
+
+// applySheetOptions sets the options of a Sheet tag in rc, keeping them for ReadConfig.Inherit
+func applySheetOptions(opts tagOptions, rc *ReadConfig) {
+	rc.sheetTag, rc.tagErr = opts, nil
+	if v, ok := opts.Value("sheetIndex"); ok {
+		rc.SheetIndex, rc.tagErr = sheetTagInt("sheetIndex", v)
+	}
+	if v, ok := opts.Value("header"); ok && rc.tagErr == nil {
+		rc.HeaderRowIndex, rc.tagErr = sheetTagInt("header", v)
+		rc.DataStartRowIndex, rc.DataStartRelative = rc.HeaderRowIndex+1, false
+	}
+	if v, ok := opts.Value("data"); ok && rc.tagErr == nil {
+		rc.DataStartRowIndex, rc.tagErr = sheetTagInt("data", v)
+	}
+	if opts.Contains("trim") {
+		rc.TrimSpace = true
+	}
+}
+
+// applySheetWriteTag sets the options of the Sheet field of typ in wc
+func applySheetWriteTag(typ reflect.Type, wc *WriteConfig) {
+	opts, _ := sheetTag(typ)
+	applySheetWriteOptions(opts, wc)
+}
+
+// applySheetWriteOptions sets the options of a Sheet tag in wc, keeping them for WriteConfig.Inherit
+func applySheetWriteOptions(opts tagOptions, wc *WriteConfig) {
+	wc.sheetTag = opts
+	if v, ok := opts.Value("sheet"); ok {
+		wc.SheetName = v
+	}
+}
+
+// sheetTagInt parses the value of an integer option of the Sheet tag
+func sheetTagInt(option, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ConfigError{Field: "Sheet tag", Reason: fmt.Sprintf("option %s=%q is no integer", option, value)}
+	}
+	return n, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type (
+	sheetTagTmp struct {
+		Sheet `exl:"sheet=Orders,trim"`
+		ID    int    `excel:"ID"`
+		Name  string `excel:"Name"`
+	}
+	sheetTagHeaderTmp struct {
+		Sheet `exl:"header=2"`
+		ID    int `excel:"ID"`
+	}
+	sheetTagOverrideTmp struct {
+		Sheet `exl:"header=2,data=4"`
+		ID    int `excel:"ID"`
+	}
+	sheetTagInvalidTmp struct {
+		Sheet `exl:"header=two"`
+		ID    int `excel:"ID"`
+	}
+)
+
+func (*sheetTagOverrideTmp) ReadConfigure(rc *ReadConfig) { rc.DataStartRowIndex = 3 }
+
+func TestSheetTag(t *testing.T) {
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*sheetTagTmp{{ID: 1, Name: " a "}}))
+	plan, err := PlanWrite([]*sheetTagTmp{}, nil)
+	equal(t, nil, err)
+	equal(t, "Orders", plan.Sheets[0].Name)
+	// The Sheet field is no column
+	equal(t, []string{"ID", "Name"}, plan.Sheets[0].Header)
+	ts, err := ReadBinary[*sheetTagTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*sheetTagTmp{{ID: 1, Name: "a"}}, ts)
+
+	rc := newReadConfig[*sheetTagHeaderTmp]()
+	equal(t, 2, rc.HeaderRowIndex)
+	equal(t, 3, rc.DataStartRowIndex)
+	// The ReadConfigure method of the type is applied last
+	equal(t, 3, newReadConfig[*sheetTagOverrideTmp]().DataStartRowIndex)
+
+	_, err = ReadBinary[*sheetTagInvalidTmp](buf.Bytes())
+	equal(t, true, errors.Is(err, ErrInvalidConfig))
+	equal(t, `exl: invalid config: Sheet tag option header="two" is no integer`, err.Error())
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SignatureSheetName is the name of the hidden sheet written with WriteConfig.SigningKey.
+const SignatureSheetName = "_signature"
+
+const (
+	// signatureAlgorithm labels the signature in the signature sheet
+	signatureAlgorithm = "HMAC-SHA256"
+	// signatureSheet labels the names of the signed sheets in the signature sheet
+	signatureSheet = "Sheet"
+)
+
+var (
+	// ErrSignatureMismatch is returned by Verify if the content of a workbook changed after signing,
+	// or it was signed with another key.
+	ErrSignatureMismatch = errors.New("exl: signature mismatch")
+	// ErrNotSigned is returned by Verify for workbooks without signature sheet.
+	ErrNotSigned = errors.New("exl: workbook not signed")
+)
+
+// Verify checks the signature of the workbook of reader written with WriteConfig.SigningKey key,
+// returning ErrSignatureMismatch if any cell value, type, number format or formula, or the name of a signed sheet
+// changed since. Sheets added after signing aren't checked, other formatting, e.g. column widths, isn't signed.
+// Set ReadConfig.VerifyKey to verify workbooks when reading them.
+func Verify(reader io.Reader, key []byte) error {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	book, err := XLSXBackend{}.Open(bs)
+	if err != nil {
+		return err
+	}
+	defer closeBook(book)
+	return verifyBook(book, key)
+}
+
+// verifyBook checks the signature of book, see Verify
+func verifyBook(book Spreadsheet, key []byte) error {
+	sheet := -1
+	for i, name := range book.Sheets() {
+		if name == SignatureSheetName {
+			sheet = i
+		}
+	}
+	if sheet < 0 {
+		return ErrNotSigned
+	}
+	var signature string
+	var signed []string
+	err := book.Rows(sheet, func(row *Row) error {
+		switch row.Cell(0).Value {
+		case signatureAlgorithm:
+			signature = row.Cell(1).Value
+		case signatureSheet:
+			signed = append(signed, row.Cell(1).Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || len(expected) == 0 {
+		return fmt.Errorf("%w: invalid signature %q", ErrSignatureMismatch, signature)
+	}
+	mac, err := contentMAC(book, key, signed)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(expected, mac) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// writeSignature appends the hidden signature sheet to book, signing the sheets written before
+// and listing their names
+func writeSignature(book Spreadsheet, key []byte) error {
+	hider, ok := book.(SheetHider)
+	if !ok {
+		return ErrUnsupported
+	}
+	var signed []string
+	for _, name := range book.Sheets() {
+		if name != SignatureSheetName {
+			signed = append(signed, name)
+		}
+	}
+	// Plans don't hold cell values
+	mac := []byte{}
+	if _, ok = book.(*planBook); !ok {
+		var err error
+		if mac, err = contentMAC(book, key, signed); err != nil {
+			return err
+		}
+	}
+	// Verify needs the exact name
+	name, err := newSheetName(book, SignatureSheetName, true)
+	if err != nil {
+		return err
+	}
+	sheet, err := book.AddSheet(name)
+	if err != nil {
+		return err
+	}
+	if err = book.AppendRow(sheet, &Row{Cells: []Cell{StringCell(signatureAlgorithm), StringCell(hex.EncodeToString(mac))}}); err != nil {
+		return err
+	}
+	for i, name := range signed {
+		if err = book.AppendRow(sheet, &Row{Index: i + 1, Cells: []Cell{StringCell(signatureSheet), StringCell(name)}}); err != nil {
+			return err
+		}
+	}
+	return hider.HideSheet(sheet)
+}
+
+// contentMAC returns the HMAC-SHA256 of the names and cells of the sheets of book named signed, in order,
+// ErrSignatureMismatch if one is missing
+func contentMAC(book Spreadsheet, key []byte, signed []string) ([]byte, error) {
+	indexes := make(map[string]int)
+	for i, name := range book.Sheets() {
+		indexes[name] = i
+	}
+	mac := hmac.New(sha256.New, key)
+	for _, name := range signed {
+		index, ok := indexes[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: sheet %q missing", ErrSignatureMismatch, name)
+		}
+		_, _ = fmt.Fprintf(mac, "sheet %q\n", name)
+		err := book.Rows(index, func(row *Row) error {
+			// Trailing empty cells depend on the widest row, empty rows on the backend
+			cells := make([]Cell, len(row.Cells))
+			for i, c := range row.Cells {
+				cells[i] = signedCell(c)
+			}
+			n := len(cells)
+			for n > 0 && cells[n-1] == (Cell{}) {
+				n--
+			}
+			if n == 0 {
+				return nil
+			}
+			_, _ = fmt.Fprintf(mac, "row %d %d\n", row.Index, n)
+			for col, c := range cells[:n] {
+				_, _ = fmt.Fprintf(mac, "%d %d %q %q %q\n", col, c.Type, c.NumFmt, c.Formula, c.Value)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mac.Sum(nil), nil
+}
+
+// signedCell returns c as signed, with the general number format as none,
+// as written files state it for every cell
+func signedCell(c Cell) Cell {
+	if strings.EqualFold(c.NumFmt, "general") {
+		c.NumFmt = ""
+	}
+	return c
+}
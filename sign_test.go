@@ -0,0 +1,141 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+var signingKey = []byte("secret")
+
+type signedTmp struct {
+	Name    string    `excel:"Name"`
+	Amount  float64   `excel:"Amount"`
+	Paid    bool      `excel:"Paid"`
+	Created time.Time `excel:"Created"`
+}
+
+func (*signedTmp) ReadConfigure(*ReadConfig) {}
+func (*signedTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SigningKey = signingKey
+	wc.Audit = true
+	wc.Footer.Sum = true
+}
+
+func TestVerify(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts := []*signedTmp{{" padded ", 1.25, true, created}, {"b", 1e-7, false, created}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	signed := append([]byte(nil), buf.Bytes()...)
+
+	equal(t, nil, Verify(bytes.NewReader(signed), signingKey))
+	equal(t, ErrSignatureMismatch, Verify(bytes.NewReader(signed), []byte("other")))
+	read, err := ReadBinary[*signedTmp](signed)
+	equal(t, nil, err)
+	// followed by the sum row
+	equal(t, ts, read[:2])
+
+	// Changing a cell breaks the signature
+	f, err := xlsx.OpenBinary(signed)
+	equal(t, nil, err)
+	cell, _ := f.Sheets[0].Cell(1, 1)
+	cell.SetFloat(2.5)
+	buf.Reset()
+	equal(t, nil, f.Write(buf))
+	equal(t, ErrSignatureMismatch, Verify(buf, signingKey))
+
+	// So does changing the number format, the formula or the type of a cell, or emptying it
+	for _, tamper := range []func(f *xlsx.File){
+		func(f *xlsx.File) { c, _ := f.Sheets[0].Cell(1, 1); c.SetFormat("0.00") },
+		func(f *xlsx.File) { c, _ := f.Sheets[0].Cell(3, 1); c.SetFormula("SUM(B2:B2)") },
+		func(f *xlsx.File) { c, _ := f.Sheets[0].Cell(1, 2); c.SetString("1") },
+		func(f *xlsx.File) { c, _ := f.Sheets[0].Cell(2, 0); c.SetString("") },
+	} {
+		f, _ = xlsx.OpenBinary(signed)
+		tamper(f)
+		buf.Reset()
+		equal(t, nil, f.Write(buf))
+		equal(t, ErrSignatureMismatch, Verify(buf, signingKey))
+	}
+
+	stream := &bytes.Buffer{}
+	sw, err := NewStreamWriter[*signedTmp](stream)
+	equal(t, nil, err)
+	equal(t, nil, sw.Write(ts...))
+	equal(t, nil, sw.Close())
+	equal(t, nil, Verify(stream, signingKey))
+
+	buf.Reset()
+	_ = WriteTo(buf, []*pageTmp{{1, "a"}})
+	equal(t, ErrNotSigned, Verify(buf, signingKey))
+
+	// The signature sheet name is reserved
+	w := NewWriter()
+	equal(t, nil, WriteSheet(w, ts))
+	if err = WriteSheet(w, ts); !errors.Is(err, ErrInvalidSheetName) {
+		t.Errorf("expected ErrInvalidSheetName, got %v", err)
+	}
+}
+
+type verifiedTmp signedTmp
+
+func (*verifiedTmp) ReadConfigure(rc *ReadConfig) { rc.VerifyKey = signingKey }
+func (*verifiedTmp) WriteConfigure(*WriteConfig)  {}
+
+func TestReadVerifyKey(t *testing.T) {
+	ts := []*signedTmp{{"a", 1, true, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, ts)
+	read, err := ReadBinary[*verifiedTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, 2, len(read))
+
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	cell, _ := f.Sheets[0].Cell(1, 0)
+	cell.SetString("b")
+	tampered := &bytes.Buffer{}
+	_ = f.Write(tampered)
+	_, err = ReadBinary[*verifiedTmp](tampered.Bytes())
+	equal(t, ErrSignatureMismatch, err)
+
+	buf.Reset()
+	_ = WriteTo(buf, []*verifiedTmp{{Name: "a"}})
+	_, err = ReadBinary[*verifiedTmp](buf.Bytes())
+	equal(t, ErrNotSigned, err)
+}
+
+func TestVerifyExtendedWorkbook(t *testing.T) {
+	ts := []*signedTmp{{"a", 1, true, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	w := NewWriter()
+	equal(t, nil, WriteSheet(w, ts))
+	equal(t, nil, WriteSheet(w, []*pageTmp{{1, "a"}}))
+	GoWriteSheet(w, []*tabTmp{{1}})
+	buf := &bytes.Buffer{}
+	if _, err := w.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	// Only the sheets written before the signature are signed
+	equal(t, nil, Verify(bytes.NewReader(buf.Bytes()), signingKey))
+	equal(t, []string{"Tab", "Sheet1", AuditSheetName, SignatureSheetName, "Sheet1 (2)"}, w.book.Sheets())
+
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	f.Sheets[1].Name = "Renamed"
+	buf.Reset()
+	_ = f.Write(buf)
+	equal(t, true, errors.Is(Verify(buf, signingKey), ErrSignatureMismatch))
+}
@@ -0,0 +1,262 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// spillFilePattern names the temporary files of sealedCellStore
+const spillFilePattern = "exlspill*"
+
+// sealedCellStore is an xlsx.CellStore keeping the rows of a sheet in a temporary file,
+// each sealed with AES-GCM under a random key held in memory only, see XLSXBackend.EncryptSpill
+type sealedCellStore struct {
+	aead cipher.AEAD
+	file *os.File
+	// The sealed rows in file by row number, size 0 for none
+	rows []sealedRow
+	end  int64
+	// Counts the rows sealed, the nonce of the next one
+	sealed uint64
+	// Makes the rows, which xlsx only allows for its own stores
+	mem xlsx.CellStore
+	// The row read last and its plaintext, as xlsx writes every row read again
+	read      *xlsx.Row
+	readPlain []byte
+}
+
+// sealedRow locates a sealed row in the file of a sealedCellStore
+type sealedRow struct {
+	offset int64
+	size   int
+}
+
+// spilledRow is the plaintext of a sealed row
+type spilledRow struct {
+	Hidden       bool          `json:",omitempty"`
+	Height       float64       `json:",omitempty"`
+	OutlineLevel uint8         `json:",omitempty"`
+	Cells        []spilledCell `json:",omitempty"`
+}
+
+// spilledCell is a cell of a spilledRow, as marshalled by xlsx but for the style and rich text
+type spilledCell struct {
+	Data     []byte
+	Style    *xlsx.Style        `json:",omitempty"`
+	RichText []xlsx.RichTextRun `json:",omitempty"`
+}
+
+// newSealedCellStore is an xlsx.CellStoreConstructor creating a sealedCellStore with a new key
+func newSealedCellStore() (xlsx.CellStore, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	mem, err := xlsx.NewMemoryCellStore()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp("", spillFilePattern)
+	if err != nil {
+		return nil, err
+	}
+	return &sealedCellStore{aead: aead, file: file, mem: mem}, nil
+}
+
+// MakeRow implements xlsx.CellStore.
+func (cs *sealedCellStore) MakeRow(sheet *xlsx.Sheet) *xlsx.Row {
+	return cs.mem.MakeRow(sheet)
+}
+
+// MakeRowWithLen implements xlsx.CellStore.
+func (cs *sealedCellStore) MakeRowWithLen(sheet *xlsx.Sheet, n int) *xlsx.Row {
+	return cs.mem.MakeRowWithLen(sheet, n)
+}
+
+// ReadRow implements xlsx.CellStore, decrypting the row of key.
+func (cs *sealedCellStore) ReadRow(key string, sheet *xlsx.Sheet) (*xlsx.Row, error) {
+	num, err := rowKeyNumber(key)
+	if err != nil {
+		return nil, err
+	}
+	if num >= len(cs.rows) || cs.rows[num].size == 0 {
+		return nil, xlsx.NewRowNotFoundError(key, "No such row")
+	}
+	sealed := make([]byte, cs.rows[num].size)
+	if _, err = cs.file.ReadAt(sealed, cs.rows[num].offset); err != nil {
+		return nil, err
+	}
+	nonceSize := cs.aead.NonceSize()
+	plain, err := cs.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], rowAAD(num))
+	if err != nil {
+		return nil, fmt.Errorf("exl: spilled row %d: %w", num, err)
+	}
+	var sr spilledRow
+	if err = json.Unmarshal(plain, &sr); err != nil {
+		return nil, err
+	}
+	row := cs.mem.MakeRow(sheet)
+	if err = setRowNumber(row, num); err != nil {
+		return nil, err
+	}
+	cs.read, cs.readPlain = row, plain
+	row.Hidden = sr.Hidden
+	if sr.Height != 0 {
+		row.SetHeight(sr.Height)
+	}
+	if sr.OutlineLevel > 0 {
+		row.SetOutlineLevel(sr.OutlineLevel)
+	}
+	for _, sc := range sr.Cells {
+		c := &xlsx.Cell{}
+		if err = c.UnmarshalBinary(sc.Data); err != nil {
+			return nil, err
+		}
+		c.Row = row
+		c.RichText = sc.RichText
+		if sc.Style != nil {
+			c.SetStyle(sc.Style)
+		}
+		// Merge only marks the cell modified, so xlsx saves cells without value, e.g. formulas, like before
+		c.Merge(c.HMerge, c.VMerge)
+		row.PushCell(c)
+	}
+	return row, nil
+}
+
+// WriteRow implements xlsx.CellStore, appending the encrypted row to the file.
+func (cs *sealedCellStore) WriteRow(r *xlsx.Row) error {
+	if r == nil {
+		return nil
+	}
+	sr := spilledRow{Hidden: r.Hidden, Height: r.GetHeight(), OutlineLevel: r.GetOutlineLevel()}
+	err := r.ForEachCell(func(c *xlsx.Cell) error {
+		data, err := c.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		sc := spilledCell{Data: data, RichText: c.RichText}
+		// GetStyle would add a style to every cell
+		if !reflect.ValueOf(c).Elem().FieldByName("style").IsNil() {
+			sc.Style = c.GetStyle()
+		}
+		sr.Cells = append(sr.Cells, sc)
+		return nil
+	}, xlsx.SkipEmptyCells)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(sr)
+	if err != nil {
+		return err
+	}
+	if r == cs.read && bytes.Equal(plain, cs.readPlain) {
+		return nil
+	}
+	num := r.GetCoordinate()
+	nonce := make([]byte, cs.aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce, cs.sealed)
+	cs.sealed++
+	sealed := cs.aead.Seal(nonce, nonce, plain, rowAAD(num))
+	if _, err = cs.file.WriteAt(sealed, cs.end); err != nil {
+		return err
+	}
+	for len(cs.rows) <= num {
+		cs.rows = append(cs.rows, sealedRow{})
+	}
+	cs.rows[num] = sealedRow{offset: cs.end, size: len(sealed)}
+	cs.end += int64(len(sealed))
+	return nil
+}
+
+// MoveRow implements xlsx.CellStore.
+func (cs *sealedCellStore) MoveRow(r *xlsx.Row, index int) error {
+	old := r.GetCoordinate()
+	if index < len(cs.rows) && cs.rows[index].size != 0 {
+		return fmt.Errorf("exl: moving row %d would overwrite row %d", old, index)
+	}
+	if old < len(cs.rows) {
+		cs.rows[old] = sealedRow{}
+	}
+	if err := setRowNumber(r, index); err != nil {
+		return err
+	}
+	cs.read = nil
+	return cs.WriteRow(r)
+}
+
+// RemoveRow implements xlsx.CellStore.
+func (cs *sealedCellStore) RemoveRow(key string) error {
+	num, err := rowKeyNumber(key)
+	if err != nil {
+		return err
+	}
+	if num < len(cs.rows) {
+		cs.rows[num] = sealedRow{}
+	}
+	return nil
+}
+
+// Close implements xlsx.CellStore, removing the file.
+func (cs *sealedCellStore) Close() error {
+	cs.rows, cs.read, cs.readPlain = nil, nil, nil
+	err := cs.file.Close()
+	if rerr := os.Remove(cs.file.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// rowKeyNumber returns the row number of the row key of xlsx, e.g. 12 for "Sheet1:000012"
+func rowKeyNumber(key string) (int, error) {
+	num, err := strconv.Atoi(key[strings.LastIndex(key, ":")+1:])
+	if err != nil || num < 0 {
+		return 0, fmt.Errorf("exl: invalid row key %q", key)
+	}
+	return num, nil
+}
+
+// setRowNumber sets the unexported number of r,
+// which only the MoveRow method of the memory store of xlsx does
+func setRowNumber(r *xlsx.Row, num int) error {
+	scratch, err := xlsx.NewMemoryCellStore()
+	if err != nil {
+		return err
+	}
+	return scratch.MoveRow(r, num)
+}
+
+// rowAAD binds a sealed row to its number, so rows can't be swapped in the file
+func rowAAD(num int) []byte {
+	return strconv.AppendInt(nil, int64(num), 10)
+}
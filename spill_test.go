@@ -0,0 +1,111 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type sealedTmp streamTmp
+
+func (*sealedTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Footer = Footer{Sum: true}
+	wc.Backend = XLSXBackend{EncryptSpill: true}
+}
+
+func (*sealedTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipFooterRows = 1
+}
+
+func TestStreamWriterEncryptSpill(t *testing.T) {
+	pattern := filepath.Join(os.TempDir(), spillFilePattern)
+	before, _ := filepath.Glob(pattern)
+	buf := &bytes.Buffer{}
+	sw, err := NewStreamWriter[*sealedTmp](buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []*sealedTmp
+	for i := 0; i < 300; i++ {
+		expected = append(expected, &sealedTmp{Name: "secret-name", Qty: i})
+	}
+	equal(t, nil, sw.Write(expected...))
+
+	during, _ := filepath.Glob(pattern)
+	equal(t, len(before)+1, len(during))
+	for _, name := range during {
+		bs, _ := os.ReadFile(name)
+		if len(bs) == 0 {
+			t.Errorf("test failed: nothing spilled to %s", name)
+		}
+		if bytes.Contains(bs, []byte("secret-name")) || bytes.Contains(bs, []byte(`"Data"`)) {
+			t.Errorf("test failed: spill file %s holds plaintext", name)
+		}
+	}
+	equal(t, nil, sw.Close())
+	after, _ := filepath.Glob(pattern)
+	equal(t, len(before), len(after))
+
+	ts, err := ReadBinary[*sealedTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, expected, ts)
+	var sum string
+	_ = ReadExcelFrom(bytes.NewReader(buf.Bytes()), 0, func(index int, row *Row) error {
+		if index == 301 {
+			sum = row.Cell(1).Formula
+		}
+		return nil
+	})
+	equal(t, "SUM(B2:B301)", sum)
+}
+
+func TestSealedCellStore(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, err := f.AddSheetWithCellStore("Sheet1", newSealedCellStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sheet.Close()
+	for _, v := range []string{"a", "c"} {
+		r := sheet.AddRow()
+		r.AddCell().SetString(v)
+		r.SetOutlineLevel(1)
+	}
+	r, err := sheet.AddRowAtIndex(1)
+	equal(t, nil, err)
+	bold := xlsx.NewStyle()
+	bold.Font.Bold = true
+	c := r.AddCell()
+	c.SetString("b")
+	c.SetStyle(bold)
+	equal(t, nil, sheet.RemoveRowAtIndex(0))
+
+	var values []string
+	err = sheet.ForEachRow(func(r *xlsx.Row) error {
+		c := r.GetCell(0)
+		values = append(values, c.Value)
+		if c.Value == "b" {
+			equal(t, true, c.GetStyle().Font.Bold)
+		} else {
+			equal(t, uint8(1), r.GetOutlineLevel())
+		}
+		return nil
+	})
+	equal(t, nil, err)
+	equal(t, "b c", strings.Join(values, " "))
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Splitter writes a field as several columns, see WriteConfig.Splitters.
+type Splitter struct {
+	// The headers of the columns, nil for the columns of the combine tag option.
+	Headers []string
+	// Split returns the values of the columns of the field value, one per header,
+	// e.g. SplitDateTime, nil to split like the default CombineFunc reads.
+	Split func(value reflect.Value, headers []string) ([]any, error)
+}
+
+// SplitDateTime splits a time.Time field into its date and time of day, read back with CombineDateTime.
+func SplitDateTime(value reflect.Value, headers []string) ([]any, error) {
+	t, ok := value.Interface().(time.Time)
+	if !ok || len(headers) != 2 {
+		return nil, fmt.Errorf("exl: splitting %s into date and time: %d columns instead of 2", value.Type(), len(headers))
+	}
+	return dateTimeParts(t), nil
+}
+
+// dateTimeParts returns the date and time of day of t, empty for the zero time
+func dateTimeParts(t time.Time) []any {
+	if t.IsZero() {
+		return []any{"", ""}
+	}
+	return []any{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), t.Format("15:04:05")}
+}
+
+// splitHeaders returns the headers of the columns the field named header and tagged with opts is split into,
+// nil if it is written as one column
+func splitHeaders(header string, opts tagOptions, wc *WriteConfig) []string {
+	if s, ok := wc.Splitters[header]; ok && s.Headers != nil {
+		return s.Headers
+	}
+	return combineHeaders(opts)
+}
+
+// splitColumns returns the columns of the headers a split field is written to
+func splitColumns(fieldIndex int, typ reflect.Type, opts tagOptions, headers []string, field string, wc *WriteConfig) []writeColumn {
+	columns := make([]writeColumn, 0, len(headers))
+	for i, header := range headers {
+		if wc.includes(header) {
+			columns = append(columns, writeColumn{fieldIndex: fieldIndex, header: header, tag: header, opts: opts, typ: typ, split: headers, part: i, splitField: field})
+		}
+	}
+	return columns
+}
+
+// splitColumnValue returns the value of the split field v written in col
+func splitColumnValue(v reflect.Value, col writeColumn, wc *WriteConfig) (any, error) {
+	split := wc.Splitters[col.splitField].Split
+	if split == nil {
+		return splitValue(v, len(col.split))[col.part], nil
+	}
+	values, err := split(v, col.split)
+	if err != nil {
+		return nil, fmt.Errorf("exl: split column %q: %w", col.splitField, err)
+	}
+	if len(values) != len(col.split) {
+		return nil, fmt.Errorf("exl: split column %q: %d values for %d columns", col.splitField, len(values), len(col.split))
+	}
+	return values[col.part], nil
+}
+
+// splitValue returns the values of the n columns the field v is split into, the reverse of the default CombineFunc:
+// times are split into the date and the time of day like SplitDateTime if n is 2,
+// texts at spaces, other values are written to the first column
+func splitValue(v reflect.Value, n int) []any {
+	parts := make([]any, n)
+	for i := range parts {
+		parts[i] = ""
+	}
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if n == 2 {
+			parts = dateTimeParts(value)
+		} else if !value.IsZero() {
+			parts[0] = value
+		}
+	case string:
+		for i, s := range strings.SplitN(value, " ", n) {
+			parts[i] = s
+		}
+	default:
+		parts[0] = value
+	}
+	return parts
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type splitTmp struct {
+	ID      int       `excel:"ID"`
+	Created time.Time `excel:"Created"`
+	Address string    `excel:"Address"`
+}
+
+func (*splitTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Splitters = map[string]Splitter{
+		"Created": {Headers: []string{"Date", "Time"}, Split: SplitDateTime},
+		"Address": {Headers: []string{"Street", "City"}, Split: func(value reflect.Value, headers []string) ([]any, error) {
+			street, city, _ := strings.Cut(value.String(), ", ")
+			return []any{street, city}, nil
+		}},
+	}
+}
+
+func TestWriteSplitters(t *testing.T) {
+	ts := []*splitTmp{{1, time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC), "Main St 1, Springfield"}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	values := make([]string, 0, 5)
+	for col := 0; col < 5; col++ {
+		header, _ := f.Sheets[0].Cell(0, col)
+		values = append(values, header.Value)
+	}
+	equal(t, []string{"ID", "Date", "Time", "Street", "City"}, values)
+	street, _ := f.Sheets[0].Cell(1, 3)
+	equal(t, "Main St 1", street.Value)
+	clock, _ := f.Sheets[0].Cell(1, 2)
+	equal(t, "10:30:00", clock.Value)
+
+	// Read back with the combine tag option
+	read, err := ReadBinary[*combineTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC), read[0].Start)
+
+	wc := newWriteConfig[*splitTmp]()
+	wc.Splitters["Address"] = Splitter{Headers: []string{"Street", "City", "Zip"}, Split: wc.Splitters["Address"].Split}
+	err = WriteToConfig(buf, ts, wc)
+	equal(t, `exl: split column "Address": 2 values for 3 columns`, err.Error())
+}
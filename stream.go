@@ -0,0 +1,102 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// ErrStreamClosed is returned when writing to a closed StreamWriter.
+var ErrStreamClosed = errors.New("exl: stream writer closed")
+
+// StreamWriter writes records of T in batches, for exports larger than memory.
+//
+// With the default XLSXBackend, written rows are moved to temporary files
+// instead of being kept in memory. The memory used is bounded by about 1 MB of cached rows,
+// plus the distinct text values, which xlsx shares across the workbook,
+// so exports of mostly numbers, dates and repeating texts stay small.
+// The workbook is written to the destination by Close, which removes the temporary files.
+//
+// The temporary files are written by the disk cell store of xlsx in a directory of os.TempDir
+// readable by the owner only. Set XLSXBackend.EncryptSpill to encrypt them with a key kept in memory
+// when streaming sensitive data on shared hosts.
+//
+// Detail sheets aren't supported, as their records would have to be kept until Close.
+type StreamWriter[T WriteConfigurator] struct {
+	w      io.Writer
+	router *sheetRouter
+	closed bool
+}
+
+// NewStreamWriter returns a StreamWriter writing the workbook to w when closed,
+// using the write config of T.
+func NewStreamWriter[T WriteConfigurator](w io.Writer) (*StreamWriter[T], error) {
+	wc := newWriteConfig[T]()
+	if xb, ok := wc.Backend.(XLSXBackend); ok && !xb.EncryptSpill {
+		xb.Options = append(append([]xlsx.FileOption{}, xb.Options...), xlsx.UseDiskVCellStore)
+		wc.Backend = xb
+	}
+	typ := reflect.TypeOf(new(T)).Elem().Elem()
+	if details := detailFields(typ, wc.TagName); len(details) > 0 {
+		return nil, fmt.Errorf("%w: detail sheet %s when streaming", ErrUnsupported, details[0].sheet)
+	}
+	book := wc.Backend.Create()
+	router, err := newSheetRouter(book, typ, wc, nil)
+	if err != nil {
+		closeBook(book)
+		return nil, err
+	}
+	return &StreamWriter[T]{w: w, router: router}, nil
+}
+
+// Write appends ts below the records written before.
+func (s *StreamWriter[T]) Write(ts ...T) error {
+	if s.closed {
+		return ErrStreamClosed
+	}
+	for _, t := range ts {
+		if err := s.router.write(reflect.ValueOf(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns the number of records written.
+func (s *StreamWriter[T]) Count() int {
+	return s.router.count()
+}
+
+// Close finishes the workbook, writes it to the destination and removes the temporary files.
+func (s *StreamWriter[T]) Close() error {
+	if s.closed {
+		return ErrStreamClosed
+	}
+	s.closed = true
+	defer closeBook(s.router.book)
+	if err := s.router.finish(); err != nil {
+		return err
+	}
+	return s.router.book.Save(s.w)
+}
+
+// closeBook releases the resources of book, e.g. temporary files
+func closeBook(book Spreadsheet) {
+	if c, ok := book.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type streamTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*streamTmp) WriteConfigure(wc *WriteConfig) {
+	wc.Footer = Footer{Sum: true}
+}
+
+func (*streamTmp) ReadConfigure(rc *ReadConfig) {
+	rc.SkipFooterRows = 1
+}
+
+func TestStreamWriter(t *testing.T) {
+	before, _ := filepath.Glob(filepath.Join(os.TempDir(), "cellstore*"))
+	buf := &bytes.Buffer{}
+	sw, err := NewStreamWriter[*streamTmp](buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []*streamTmp
+	for batch := 0; batch < 5; batch++ {
+		ts := make([]*streamTmp, 0, 100)
+		for i := 0; i < 100; i++ {
+			ts = append(ts, &streamTmp{Name: "item", Qty: batch*100 + i})
+		}
+		equal(t, nil, sw.Write(ts...))
+		expected = append(expected, ts...)
+	}
+	equal(t, 500, sw.Count())
+	equal(t, nil, sw.Close())
+	equal(t, ErrStreamClosed, sw.Write(&streamTmp{}))
+	equal(t, ErrStreamClosed, sw.Close())
+
+	ts, err := ReadBinary[*streamTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, expected, ts)
+	var sum string
+	_ = ReadExcelFrom(bytes.NewReader(buf.Bytes()), 0, func(index int, row *Row) error {
+		if index == 501 {
+			sum = row.Cell(1).Formula
+		}
+		return nil
+	})
+	equal(t, "SUM(B2:B501)", sum)
+
+	after, _ := filepath.Glob(filepath.Join(os.TempDir(), "cellstore*"))
+	equal(t, len(before), len(after))
+}
+
+func TestStreamWriterDetails(t *testing.T) {
+	if _, err := NewStreamWriter[*masterTmp](&bytes.Buffer{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+type routedTmp struct {
+	Region string `excel:"Region"`
+	Qty    int    `excel:"Qty"`
+}
+
+func (*routedTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "Other"
+	wc.SheetNameFunc = func(record any) string { return record.(*routedTmp).Region }
+}
+
+func (*routedTmp) ReadConfigure(rc *ReadConfig) { rc.SheetIndex = 1 }
+
+func TestStreamWriterSheetNameFunc(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sw, err := NewStreamWriter[*routedTmp](buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equal(t, nil, sw.Write(&routedTmp{"North", 1}, &routedTmp{"South", 2}))
+	equal(t, nil, sw.Write(&routedTmp{"North", 3}, &routedTmp{"", 4}))
+	equal(t, 4, sw.Count())
+	equal(t, nil, sw.Close())
+
+	info, _ := Inspect(bytes.NewReader(buf.Bytes()))
+	equal(t, 3, len(info.Sheets))
+	equal(t, []string{"North", "South", "Other"}, []string{info.Sheets[0].Name, info.Sheets[1].Name, info.Sheets[2].Name})
+	ts, err := ReadBinary[*routedTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*routedTmp{{"South", 2}}, ts)
+
+	// Without records, the sheet of SheetName is written
+	buf.Reset()
+	equal(t, nil, WriteTo(buf, []*routedTmp{}))
+	info, _ = Inspect(bytes.NewReader(buf.Bytes()))
+	equal(t, "Other", info.Sheets[0].Name)
+}
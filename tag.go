@@ -0,0 +1,45 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "strings"
+
+// tagOptions is the comma separated list of options
+// following the column name in a struct tag, e.g. `excel:"Level,outline"`.
+type tagOptions string
+
+// parseTag splits a struct tag into the column name and its options.
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, tagOptions(opts)
+}
+
+// Contains reports whether the flag option is set.
+func (o tagOptions) Contains(option string) bool {
+	_, ok := o.Value(option)
+	return ok
+}
+
+// Value returns the value of a key=value option,
+// or an empty string for a flag option.
+func (o tagOptions) Value(option string) (string, bool) {
+	s := string(o)
+	for s != "" {
+		var next string
+		next, s, _ = strings.Cut(s, ",")
+		key, value, _ := strings.Cut(next, "=")
+		if key == option {
+			return value, true
+		}
+	}
+	return "", false
+}
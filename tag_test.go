@@ -0,0 +1,28 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "testing"
+
+func TestParseTag(t *testing.T) {
+	name, opts := parseTag("Level,outline,mask=last4")
+	equal(t, "Level", name)
+	equal(t, true, opts.Contains("outline"))
+	equal(t, false, opts.Contains("out"))
+	value, ok := opts.Value("mask")
+	equal(t, "last4", value)
+	equal(t, true, ok)
+
+	name, opts = parseTag("名称")
+	equal(t, "名称", name)
+	equal(t, false, opts.Contains(""))
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// expandTemplate resolves the placeholders of text with data, see WriteConfig.TemplateData.
+// Texts without placeholders are returned as they are, placeholders missing in data are an error.
+func expandTemplate(text string, data map[string]any) (string, error) {
+	if data == nil || !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("exl: template %q: %w", text, err)
+	}
+	var b strings.Builder
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("exl: template %q: %w", text, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"testing"
+)
+
+type templateTmp struct {
+	Region string  `excel:"Region"`
+	Sales  float64 `excel:"Sales {{.Month}}"`
+}
+
+func (*templateTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "Report {{.Month}}"
+}
+
+func TestExpandTemplate(t *testing.T) {
+	s, err := expandTemplate("Report {{.Month}}", nil)
+	equal(t, nil, err)
+	equal(t, "Report {{.Month}}", s)
+	s, err = expandTemplate("Report {{.Month}}", map[string]any{"Month": "2023-01"})
+	equal(t, nil, err)
+	equal(t, "Report 2023-01", s)
+	_, err = expandTemplate("Report {{.Year}}", map[string]any{"Month": "2023-01"})
+	equal(t, true, err != nil)
+	_, err = expandTemplate("Report {{.Month", map[string]any{"Month": "2023-01"})
+	equal(t, true, err != nil)
+}
+
+func TestWriteTemplateData(t *testing.T) {
+	wc := newWriteConfig[*templateTmp]()
+	wc.TemplateData = map[string]any{"Month": "2023-01"}
+	plan, err := PlanWrite([]*templateTmp{{"North", 1}}, wc)
+	equal(t, nil, err)
+	equal(t, "Report 2023-01", plan.Sheets[0].Name)
+	equal(t, []string{"Region", "Sales 2023-01"}, plan.Sheets[0].Header)
+}
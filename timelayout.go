@@ -0,0 +1,167 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+// timeLayouts are the named layouts of the layout tag option
+var timeLayouts = map[string]string{
+	"rfc3339":     time.RFC3339Nano,
+	"rfc3339nano": time.RFC3339Nano,
+	"datetime":    "2006-01-02 15:04:05",
+	"date":        "2006-01-02",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// locations caches the locations of the tz tag option by name
+var locations sync.Map
+
+// timeLayout returns the layout and location of the time field tagged with opts,
+// ok is false for fields without layout, tz, unix and unixms options, loc is nil without tz option.
+//
+// The layout option, e.g. `excel:"Created,layout=rfc3339"`, writes times as text in the layout,
+// keeping their offset, and reads texts in the layout.
+// It is one of rfc3339, rfc3339nano, datetime and date, or a layout of the time package without commas.
+// The tz option, e.g. `excel:"Created,tz=Asia/Shanghai"`, reads and writes dates without offset
+// as wall clock time in the IANA time zone, instead of UTC.
+// Times are written in the time zone, or else with their own offset.
+// The unix and unixms options, e.g. `excel:"Ts,unix"`, read and write times as integer
+// seconds or milliseconds since the Unix epoch instead, see unixUnit.
+func timeLayout(opts tagOptions) (layout string, loc *time.Location, ok bool, err error) {
+	layout, haveLayout := opts.Value("layout")
+	if named, have := timeLayouts[layout]; have {
+		layout = named
+	}
+	name, haveTZ := opts.Value("tz")
+	if !haveLayout && !haveTZ && unixUnit(opts) == 0 {
+		return "", nil, false, nil
+	}
+	loc, err = loadLocation(name)
+	return layout, loc, true, err
+}
+
+// unixUnit returns the unit of the Unix timestamps of the time field tagged with opts,
+// 0 for fields without unix and unixms options
+func unixUnit(opts tagOptions) time.Duration {
+	switch {
+	case opts.Contains("unixms"):
+		return time.Millisecond
+	case opts.Contains("unix"):
+		return time.Second
+	}
+	return 0
+}
+
+// loadLocation returns the location named name, nil for ""
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("exl: tz option: %w", err)
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// TimeLayoutUnmarshalFunc returns an UnmarshalExcelFunc for time.Time fields parsing texts in layout first,
+// and reading dates without offset as wall clock time in loc, see UnmarshalTime.
+// It unmarshals fields tagged with the layout or tz options.
+func TimeLayoutUnmarshalFunc(layout string, loc *time.Location) UnmarshalExcelFunc {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+		if layout != "" && !cell.IsTime() {
+			if val, err := time.ParseInLocation(layout, cell.Value, loc); err == nil {
+				destValue.Set(reflect.ValueOf(val))
+				return nil
+			}
+		}
+		val, err := parseTimeCell(cell, params, loc)
+		if err != nil {
+			return err
+		}
+		destValue.Set(reflect.ValueOf(val))
+		return nil
+	}
+}
+
+// UnixTimeUnmarshalFunc returns an UnmarshalExcelFunc for time.Time fields reading integer Unix timestamps
+// in unit, time.Second or time.Millisecond, as time in loc, nil for UTC.
+// It unmarshals fields tagged with the unix or unixms options.
+func UnixTimeUnmarshalFunc(unit time.Duration, loc *time.Location) UnmarshalExcelFunc {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+		var val time.Time
+		if value := strings.TrimSpace(cell.Value); value != "" {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				// Large numbers may be stored in exponent notation
+				f, ferr := strconv.ParseFloat(value, 64)
+				if ferr != nil || f != math.Trunc(f) {
+					return fmt.Errorf("error parsing cell as unix timestamp: %w", err)
+				}
+				n = int64(f)
+			}
+			if unit == time.Millisecond {
+				val = time.UnixMilli(n).In(loc)
+			} else {
+				val = time.Unix(n, 0).In(loc)
+			}
+		}
+		destValue.Set(reflect.ValueOf(val))
+		return nil
+	}
+}
+
+// timeColumnValue returns the value written for t in a column tagged with the layout, tz, unix or unixms options
+func timeColumnValue(t time.Time, col writeColumn) (any, error) {
+	layout, loc, _, err := timeLayout(col.opts)
+	if err != nil {
+		return nil, fmt.Errorf("exl: column %q: %w", col.header, err)
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+	switch unixUnit(col.opts) {
+	case time.Millisecond:
+		return t.UnixMilli(), nil
+	case time.Second:
+		return t.Unix(), nil
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	if layout != "" {
+		return StringCell(t.Format(layout)), nil
+	}
+	// The serial date is the wall clock time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC), nil
+}
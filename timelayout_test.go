@@ -0,0 +1,124 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+type timeLayoutTmp struct {
+	Exact   time.Time  `excel:"Exact,layout=rfc3339"`
+	Local   time.Time  `excel:"Local,tz=Asia/Shanghai"`
+	Day     *time.Time `excel:"Day,layout=2006/01/02,tz=Asia/Shanghai"`
+	Default time.Time  `excel:"Default"`
+}
+
+func (*timeLayoutTmp) ReadConfigure(*ReadConfig)   {}
+func (*timeLayoutTmp) WriteConfigure(*WriteConfig) {}
+
+func TestTimeLayout(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	equal(t, nil, err)
+	exact := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.FixedZone("", -5*60*60))
+	local := time.Date(2023, 1, 2, 3, 4, 5, 0, shanghai)
+	day := time.Date(2023, 1, 2, 0, 0, 0, 0, shanghai)
+	ts := []*timeLayoutTmp{{Exact: exact, Local: local, Day: &day, Default: exact}}
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	cell, _ := f.Sheets[0].Cell(1, 0)
+	equal(t, "2023-01-02T03:04:05.123456789-05:00", cell.Value)
+	cell, _ = f.Sheets[0].Cell(1, 1)
+	date, _ := cell.GetTime(false)
+	equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), date.Round(time.Millisecond))
+	cell, _ = f.Sheets[0].Cell(1, 2)
+	equal(t, "2023/01/02", cell.Value)
+
+	read, err := ReadBinary[*timeLayoutTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, 1, len(read))
+	equal(t, exact.String(), read[0].Exact.String())
+	equal(t, local, read[0].Local)
+	equal(t, day, *read[0].Day)
+	// Serial dates are written in UTC and lose the offset
+	equal(t, exact.Truncate(time.Millisecond).UTC(), read[0].Default)
+}
+
+type badTimeZoneTmp struct {
+	Created time.Time `excel:"Created,tz=Nowhere/Else"`
+}
+
+func (*badTimeZoneTmp) ReadConfigure(*ReadConfig)   {}
+func (*badTimeZoneTmp) WriteConfigure(*WriteConfig) {}
+
+func TestTimeLayoutUnknownTimeZone(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*badTimeZoneTmp{{time.Now()}}); err == nil {
+		t.Error("expected error writing unknown time zone")
+	}
+	buf.Reset()
+	_ = WriteTo(buf, []*pageTmp{{1, "a"}})
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	cell, _ := f.Sheets[0].Cell(0, 0)
+	cell.SetString("Created")
+	buf.Reset()
+	_ = f.Write(buf)
+	if _, err := ReadBinary[*badTimeZoneTmp](buf.Bytes()); err == nil {
+		t.Error("expected error reading unknown time zone")
+	}
+}
+
+type unixTimeTmp struct {
+	Seconds time.Time  `excel:"Seconds,unix"`
+	Millis  *time.Time `excel:"Millis,unixms"`
+	Local   time.Time  `excel:"Local,unix,tz=Asia/Shanghai"`
+}
+
+func (*unixTimeTmp) ReadConfigure(*ReadConfig)   {}
+func (*unixTimeTmp) WriteConfigure(*WriteConfig) {}
+
+func TestTimeUnix(t *testing.T) {
+	shanghai, _ := time.LoadLocation("Asia/Shanghai")
+	seconds := time.Unix(1700000000, 0).UTC()
+	millis := time.UnixMilli(1700000000123).UTC()
+	ts := []*unixTimeTmp{{seconds, &millis, seconds.In(shanghai)}, {Millis: &time.Time{}}}
+
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "1700000000", row.GetCell(0).Value)
+	equal(t, "1700000000123", row.GetCell(1).Value)
+	equal(t, xlsx.CellTypeNumeric, row.GetCell(1).Type())
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, "", row.GetCell(0).Value)
+
+	read, err := ReadBinary[*unixTimeTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, ts, read)
+
+	cell, _ := f.Sheets[0].Cell(2, 0)
+	cell.SetString("yesterday")
+	buf.Reset()
+	_ = f.Write(buf)
+	_, err = ReadBinary[*unixTimeTmp](buf.Bytes())
+	if err == nil {
+		t.Error("expected error reading text as unix timestamp")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformer sanitizes the raw value of a cell before it is unmarshalled,
+// see ReadConfig.Transformers and ReadConfig.ColumnTransformers.
+type Transformer func(value string) string
+
+var (
+	// TransformTrimSpace removes leading and trailing white space
+	TransformTrimSpace Transformer = strings.TrimSpace
+	// TransformCollapseSpace trims the value and replaces runs of white space,
+	// including non-breaking spaces and line breaks, with a single space
+	TransformCollapseSpace Transformer = func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	}
+	// TransformStripInvisible removes non-printable characters like control characters,
+	// zero-width spaces and byte order marks, white space is kept
+	TransformStripInvisible Transformer = func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsGraphic(r) || unicode.IsSpace(r) {
+				return r
+			}
+			return -1
+		}, value)
+	}
+	// TransformNFC normalizes the value to the unicode normalization form C,
+	// so e.g. decomposed accents compare equal to their precomposed form
+	TransformNFC Transformer = norm.NFC.String
+)
+
+// ChainTransformers returns a Transformer applying ts in order
+func ChainTransformers(ts ...Transformer) Transformer {
+	return func(value string) string {
+		for _, t := range ts {
+			value = t(value)
+		}
+		return value
+	}
+}
+
+// columnTransformers returns the transformers applied to the column of header
+func columnTransformers(header string, rc *ReadConfig) []Transformer {
+	column := rc.ColumnTransformers[header]
+	if len(rc.Transformers) == 0 {
+		return column
+	}
+	ts := make([]Transformer, 0, len(rc.Transformers)+len(column))
+	return append(append(ts, rc.Transformers...), column...)
+}
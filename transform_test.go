@@ -0,0 +1,43 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransformers(t *testing.T) {
+	equal(t, "a b", TransformTrimSpace(" a b\n"))
+	equal(t, "a b c", TransformCollapseSpace(" a   b\n\tc "))
+	equal(t, "12 34", TransformStripInvisible("\ufeff12\u200b 34\x00"))
+	equal(t, "café", TransformNFC("café"))
+	equal(t, "x y", ChainTransformers(TransformStripInvisible, TransformCollapseSpace)("\u200b x \u200b y"))
+}
+
+type transformTmp struct {
+	Qty  int    `excel:"Qty"`
+	Name string `excel:"Name"`
+}
+
+func (*transformTmp) ReadConfigure(rc *ReadConfig) {
+	rc.Transformers = []Transformer{TransformStripInvisible, TransformTrimSpace}
+	rc.ColumnTransformers = map[string][]Transformer{"Name": {TransformCollapseSpace}}
+}
+
+func TestReadTransformers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{{"Qty", "Name"}, {"\u200b42 ", " big \u200b  apple "}})
+	ts, err := Read[*transformTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*transformTmp{{42, "big apple"}}, ts)
+}
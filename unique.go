@@ -0,0 +1,62 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDuplicate is matched by DuplicateError with errors.Is.
+var ErrDuplicate = errors.New("exl: duplicate value")
+
+// DuplicateError is the Err of the FieldError of a value repeated in a unique column,
+// see ReadConfig.UniqueColumns. The FieldError refers to the repetition.
+type DuplicateError struct {
+	Value string
+	// The 0-based index of the row holding the value first. Printed as 1-based row number in error text.
+	FirstRowIndex int
+}
+
+// Error implements error.
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("duplicate value %q, first in row %d", e.Value, e.FirstRowIndex+1)
+}
+
+// Is reports whether target is ErrDuplicate.
+func (e *DuplicateError) Is(target error) bool {
+	return target == ErrDuplicate
+}
+
+// checkUnique returns a *DuplicateError if the unique column of header held value before rowIndex
+func (b *rowBinder) checkUnique(header, value string, rowIndex int) error {
+	if b.rc.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		return nil
+	}
+	if b.uniques == nil {
+		b.uniques = make(map[string]map[string]int)
+	}
+	seen := b.uniques[header]
+	if seen == nil {
+		seen = make(map[string]int)
+		b.uniques[header] = seen
+	}
+	if first, ok := seen[value]; ok {
+		return &DuplicateError{Value: value, FirstRowIndex: first}
+	}
+	seen[value] = rowIndex
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type uniqueTmp struct {
+	ID    int    `excel:"ID"`
+	Email string `excel:"Email,unique"`
+}
+
+func (*uniqueTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.UniqueColumns = []string{"ID"}
+}
+
+func TestReadUnique(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"ID", "Email"},
+		{"1", "a@example.com"},
+		{"2", "b@example.com"},
+		{"3", ""},
+		{"2", "a@example.com"},
+		{"5", ""},
+	})
+	_, err := ReadBinary[*uniqueTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 2, len(ce.FieldErrors))
+	equal(t, "A5", ce.FieldErrors[0].CellRef)
+	equal(t, true, errors.Is(ce.FieldErrors[0], ErrDuplicate))
+	var de *DuplicateError
+	equal(t, true, errors.As(ce.FieldErrors[1], &de))
+	equal(t, &DuplicateError{Value: "a@example.com", FirstRowIndex: 1}, de)
+	equal(t, `error unmarshalling column "Email" in row 5: duplicate value "a@example.com", first in row 2`, ce.FieldErrors[1].Error())
+}
+
+type uniqueDropListTmp struct {
+	Country string  `excel:"Country,unique"`
+	Code    *string `excel:"Code,unique,nilas=-"`
+}
+
+func (*uniqueDropListTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+	rc.DropListMap = map[string][]struct {
+		Key   string
+		Value string
+	}{"Country": {{"US", "United States"}, {"CA", "Canada"}}}
+}
+
+func TestReadUniqueDropList(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Country", "Code"},
+		{"United States", "-"},
+		{"Canada", "-"},
+		{"United States", "x"},
+	})
+	_, err := ReadBinary[*uniqueDropListTmp](buf.Bytes())
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	// Nil values aren't duplicates
+	equal(t, 1, len(ce.FieldErrors))
+	equal(t, "A4", ce.FieldErrors[0].CellRef)
+	equal(t, true, errors.Is(ce.FieldErrors[0], ErrDuplicate))
+}
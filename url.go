@@ -0,0 +1,201 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxDownloadSize is the size limit of ReadURL, see ReadConfig.MaxDownloadSize.
+const DefaultMaxDownloadSize = 100 << 20
+
+var (
+	// ErrDownloadTooLarge is returned by ReadURL for files above ReadConfig.MaxDownloadSize.
+	ErrDownloadTooLarge = errors.New("exl: download too large")
+	// ErrUnexpectedContentType is returned by ReadURL for responses which aren't workbooks,
+	// e.g. the HTML error pages of partner servers.
+	ErrUnexpectedContentType = errors.New("exl: unexpected content type")
+	// ErrHTTPStatus is returned by ReadURL for responses with a status other than 200 OK.
+	ErrHTTPStatus = errors.New("exl: unexpected HTTP status")
+)
+
+// workbookContentTypes are the content types accepted by ReadURL,
+// besides responses without content type
+var workbookContentTypes = map[string]bool{
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"application/vnd.ms-excel":     true,
+	"application/octet-stream":     true,
+	"binary/octet-stream":          true,
+	"application/zip":              true,
+	"application/x-zip-compressed": true,
+}
+
+// ReadURL downloads the workbook at url with client, nil for http.DefaultClient,
+// and binds its rows to `T` like Read.
+// rc replaces the read config of T, nil to use it.
+// The download is limited to ReadConfig.MaxDownloadSize bytes,
+// and responses of other content types than xlsx, zip or octet-stream are rejected.
+// Interrupted downloads are resumed up to ReadConfig.DownloadRetries times with range requests,
+// if the server sends an ETag or Last-Modified date, and restarted otherwise.
+// Failed downloads return a *DownloadError.
+func ReadURL[T ReadConfigurator](ctx context.Context, url string, rc *ReadConfig, client *http.Client) ([]T, error) {
+	if rc == nil {
+		rc = newReadConfig[T]()
+	}
+	if err := rc.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	limit := rc.MaxDownloadSize
+	if limit == 0 {
+		limit = DefaultMaxDownloadSize
+	}
+	d := &downloader{ctx: ctx, client: client, url: url, limit: limit, retries: rc.DownloadRetries}
+	bs, err := d.download()
+	if err != nil {
+		return nil, err
+	}
+	book, err := openBook(rc, bs)
+	if err != nil {
+		return nil, err
+	}
+	return readSpreadsheet[T](book, rc, nil, nil, nil)
+}
+
+// DownloadError is returned by ReadURL if downloading the file failed,
+// telling network and server errors apart from errors of the downloaded content.
+type DownloadError struct {
+	// The number of bytes received before the download failed.
+	Received int64
+	// The number of attempts, see ReadConfig.DownloadRetries.
+	Attempts int
+	Err      error
+}
+
+// Error implements error, omitting the URL which may hold credentials, e.g. of presigned URLs.
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("exl: download failed after %d bytes: %v", e.Received, e.Err)
+}
+
+// Unwrap returns the cause of the failed download.
+func (e *DownloadError) Unwrap() error {
+	return e.Err
+}
+
+// downloader downloads a file, resuming interrupted downloads with range requests
+type downloader struct {
+	ctx     context.Context
+	client  *http.Client
+	url     string
+	limit   int64
+	retries int
+
+	buf bytes.Buffer
+	// The ETag or Last-Modified date of the file, for resuming the download of the same version
+	validator string
+	// The size of the file, -1 if unknown
+	total int64
+}
+
+// download returns the body of the response to a GET request of url, up to limit bytes
+func (d *downloader) download() ([]byte, error) {
+	d.total = -1
+	for attempt := 1; ; attempt++ {
+		retry, err := d.fetch()
+		if err == nil {
+			return d.buf.Bytes(), nil
+		}
+		if !retry || attempt > d.retries || d.ctx.Err() != nil {
+			return nil, &DownloadError{Received: int64(d.buf.Len()), Attempts: attempt, Err: err}
+		}
+	}
+}
+
+// fetch requests the file, or the rest of it if received partially before,
+// reporting whether a failure may be retried
+func (d *downloader) fetch() (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodGet, d.url, nil)
+	if err != nil {
+		return false, err
+	}
+	offset := int64(d.buf.Len())
+	if offset > 0 && d.validator != "" {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		// The server sends the whole file, if it changed in between
+		req.Header.Set("If-Range", d.validator)
+	} else {
+		d.buf.Reset()
+		offset = 0
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		var start, total int64
+		if _, err = fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-", &start); err != nil || start != offset {
+			d.buf.Reset()
+			return true, fmt.Errorf("exl: unexpected content range %q", resp.Header.Get("Content-Range"))
+		}
+		if _, err = fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &total, &total); err == nil && d.total < 0 {
+			d.total = total
+		}
+		if v := validator(resp); v != "" && v != d.validator {
+			d.buf.Reset()
+			return true, errors.New("exl: file changed while downloading")
+		}
+	case resp.StatusCode == http.StatusOK:
+		d.buf.Reset()
+		d.validator, d.total = validator(resp), resp.ContentLength
+		if ct := resp.Header.Get("Content-Type"); ct != "" {
+			if mediaType, _, err := mime.ParseMediaType(ct); err != nil || !workbookContentTypes[mediaType] {
+				return false, fmt.Errorf("%w: %s", ErrUnexpectedContentType, ct)
+			}
+		}
+	default:
+		// Server errors are often temporary, client errors aren't
+		return resp.StatusCode >= 500, fmt.Errorf("%w: %s", ErrHTTPStatus, resp.Status)
+	}
+	if d.total > d.limit {
+		return false, fmt.Errorf("%w: %d bytes above the limit of %d", ErrDownloadTooLarge, d.total, d.limit)
+	}
+	_, err = d.buf.ReadFrom(io.LimitReader(resp.Body, d.limit+1-int64(d.buf.Len())))
+	if err != nil {
+		return true, err
+	}
+	if int64(d.buf.Len()) > d.limit {
+		return false, fmt.Errorf("%w: more than %d bytes", ErrDownloadTooLarge, d.limit)
+	}
+	if d.total >= 0 && int64(d.buf.Len()) != d.total {
+		return true, fmt.Errorf("exl: received %d of %d bytes: %w", d.buf.Len(), d.total, io.ErrUnexpectedEOF)
+	}
+	return false, nil
+}
+
+// validator returns the strong ETag or the Last-Modified date of the response, if any
+func validator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadURL(t *testing.T) {
+	expected := []*pageTmp{{1, "a"}, {2, "b"}}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, expected)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/report.xlsx":
+			w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+			_, _ = w.Write(buf.Bytes())
+		case "/error":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>maintenance</html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	ts, err := ReadURL[*pageTmp](ctx, srv.URL+"/report.xlsx", nil, nil)
+	equal(t, nil, err)
+	equal(t, expected, ts)
+
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/error", nil, srv.Client())
+	equal(t, true, errors.Is(err, ErrUnexpectedContentType))
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/missing", nil, nil)
+	equal(t, true, errors.Is(err, ErrHTTPStatus))
+	equal(t, "exl: download failed after 0 bytes: exl: unexpected HTTP status: 404 Not Found", err.Error())
+	var de *DownloadError
+	equal(t, true, errors.As(err, &de))
+
+	rc := newReadConfig[*pageTmp]()
+	rc.MaxDownloadSize = 100
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/report.xlsx", rc, nil)
+	equal(t, true, errors.Is(err, ErrDownloadTooLarge))
+	rc.MaxDownloadSize = -1
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/report.xlsx", rc, nil)
+	equal(t, true, errors.Is(err, ErrInvalidConfig))
+}
+
+func TestReadURLResume(t *testing.T) {
+	expected := make([]*pageTmp, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		expected = append(expected, &pageTmp{i, strconv.Itoa(i)})
+	}
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, expected)
+	data := buf.Bytes()
+	var requests, ranges int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		switch r.URL.Path {
+		case "/flaky.xlsx", "/changing.xlsx":
+			w.Header().Set("ETag", `"v1"`)
+			n := atomic.AddInt32(&requests, 1)
+			if r.URL.Path == "/changing.xlsx" && n > 1 {
+				w.Header().Set("ETag", `"v2"`)
+			}
+			if r.Header.Get("Range") != "" {
+				atomic.AddInt32(&ranges, 1)
+			}
+			// Drop the connection of the first two requests after a third of the file
+			if n <= 2 {
+				w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+				_, _ = w.Write(data[:len(data)/3])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+		case "/corrupt.xlsx":
+			// The download succeeds, parsing fails
+			_, _ = w.Write(data[:len(data)/3])
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	rc := newReadConfig[*pageTmp]()
+	rc.DownloadRetries = 2
+
+	ts, err := ReadURL[*pageTmp](ctx, srv.URL+"/flaky.xlsx", rc, nil)
+	equal(t, nil, err)
+	equal(t, expected, ts)
+	equal(t, int32(3), requests)
+	equal(t, int32(2), ranges)
+
+	// Without retries the first dropped connection fails
+	requests, ranges = 0, 0
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/flaky.xlsx", nil, nil)
+	var de *DownloadError
+	equal(t, true, errors.As(err, &de))
+	equal(t, int64(len(data)/3), de.Received)
+	equal(t, 1, de.Attempts)
+
+	// A changed file is downloaded again as a whole
+	requests, ranges = 0, 0
+	ts, err = ReadURL[*pageTmp](ctx, srv.URL+"/changing.xlsx", rc, nil)
+	equal(t, nil, err)
+	equal(t, expected, ts)
+
+	_, err = ReadURL[*pageTmp](ctx, srv.URL+"/corrupt.xlsx", nil, nil)
+	equal(t, false, err == nil || errors.As(err, &de))
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import "reflect"
+
+// RowValidator is implemented by record types checking their fields against each other once a row is bound,
+// e.g. an end date before the start date. Rows with unmarshalling errors aren't validated,
+// records with child rows are validated before their child rows are bound.
+// The errors are handled like unmarshalling errors as configured by ReadConfig.UnmarshalErrorHandling.
+type RowValidator interface {
+	ValidateRow(v *RowValidation)
+}
+
+// RowValidation collects the errors found by RowValidator in a row.
+type RowValidation struct {
+	val    reflect.Value
+	row    *Row
+	fields []fieldInfo
+	b      *rowBinder
+	errors []FieldError
+}
+
+// RowIndex returns the 0-based index of the row.
+func (v *RowValidation) RowIndex() int {
+	return v.row.Index
+}
+
+// Fail reports err at the columns of headers, a FieldError per column, e.g. at both "Start" and "End",
+// or at the row without column for no headers.
+// Errors marked with Warn are reported as warnings.
+func (v *RowValidation) Fail(err error, headers ...string) {
+	fer := FieldError{RowIndex: v.row.Index, ColumnIndex: -1, Err: err, Severity: SeverityOf(err), formatter: v.b.rc.ErrorFormatter}
+	if len(headers) == 0 {
+		v.errors = append(v.errors, fer)
+		return
+	}
+	for _, header := range headers {
+		fer := fer
+		fer.ColumnHeader = header
+		for columnIndex, fi := range v.fields {
+			if fi.header != header {
+				continue
+			}
+			fer.ColumnIndex = columnIndex
+			fer.CellRef = CellRef(v.row.Index, v.b.columnOffset+columnIndex)
+			fer.Value = v.row.Cell(columnIndex).Value
+			if fi.bound() && !fi.rest {
+				fer.ExpectedType = v.val.Field(fi.reflectFieldIndex).Type().String()
+			}
+			break
+		}
+		v.errors = append(v.errors, fer)
+	}
+}
+
+// validate calls the RowValidator of the bound struct val, handling its errors like unmarshalling errors
+func (b *rowBinder) validate(val reflect.Value, row *Row, fields []fieldInfo) error {
+	if !val.CanAddr() {
+		return nil
+	}
+	validator, ok := val.Addr().Interface().(RowValidator)
+	if !ok {
+		return nil
+	}
+	v := &RowValidation{val: val, row: row, fields: fields, b: b}
+	validator.ValidateRow(v)
+	for _, fer := range v.errors {
+		if err := b.fail(fer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
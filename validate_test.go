@@ -0,0 +1,71 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var errEndBeforeStart = errors.New("end before start")
+
+type validateTmp struct {
+	Name  string `excel:"Name"`
+	Start int    `excel:"Start"`
+	End   int    `excel:"End"`
+}
+
+func (*validateTmp) ReadConfigure(rc *ReadConfig) {
+	rc.UnmarshalErrorHandling = UnmarshalErrorCollect
+}
+
+func (r *validateTmp) ValidateRow(v *RowValidation) {
+	if r.End < r.Start {
+		v.Fail(errEndBeforeStart, "Start", "End")
+	}
+	if r.Name == "" {
+		v.Fail(Warn(errors.New("no name")), "Name")
+	}
+}
+
+func TestRowValidator(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteExcelTo(buf, [][]string{
+		{"Name", "Start", "End"},
+		{"a", "1", "2"},
+		{"b", "3", "2"},
+		{"", "1", "x"},
+		{"", "1", "1"},
+	})
+	ts, report, err := ReadWithReport[*validateTmp](bytes.NewReader(buf.Bytes()))
+	var ce ContentError
+	equal(t, true, errors.As(err, &ce))
+	equal(t, 0, len(ts))
+	// The row with an unmarshalling error isn't validated
+	equal(t, 3, len(ce.FieldErrors))
+	equal(t, "Start", ce.FieldErrors[0].ColumnHeader)
+	equal(t, "B3", ce.FieldErrors[0].CellRef)
+	equal(t, "3", ce.FieldErrors[0].Value)
+	equal(t, "End", ce.FieldErrors[1].ColumnHeader)
+	equal(t, true, errors.Is(ce.FieldErrors[1], errEndBeforeStart))
+	equal(t, "x", ce.FieldErrors[2].Value)
+	equal(t, 1, len(report.FieldWarnings))
+	equal(t, "A5", report.FieldWarnings[0].CellRef)
+
+	rc := newReadConfig[*validateTmp]()
+	rc.UnmarshalErrorHandling = UnmarshalErrorAbort
+	_, err = ReadColumns[*validateTmp](bytes.NewReader(buf.Bytes()), rc, []string{"Name", "Start", "End"})
+	var fer FieldError
+	equal(t, true, errors.As(err, &fer))
+	equal(t, "Start", fer.ColumnHeader)
+}
@@ -15,11 +15,15 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
 )
 
 var ErrNegativeUInt = errors.New("negative integer provided for unsigned field")
@@ -30,6 +34,12 @@ var ErrNoRecognizedFormat = errors.New("no recognized format")
 // but casting to it at runtime failed for some reason.
 var ErrCannotCastUnmarshaler = errors.New("cannot cast to unmarshaler interface")
 
+// DefaultUnmarshalFuncs are the unmarshal funcs of fields by kind,
+// used for fields which implement neither ExcelUnmarshaler nor encoding.TextUnmarshaler.
+// Reading uses a copy taken before the first read and again by every call of RegisterUnmarshalFunc,
+// so changes made in between are ignored until RegisterUnmarshalFunc is called.
+//
+// Deprecated: Use RegisterUnmarshalFunc, writing to DefaultUnmarshalFuncs races with reading.
 var DefaultUnmarshalFuncs = map[reflect.Kind]UnmarshalExcelFunc{
 	reflect.String:  UnmarshalString,
 	reflect.Bool:    UnmarshalBool,
@@ -48,6 +58,62 @@ var DefaultUnmarshalFuncs = map[reflect.Kind]UnmarshalExcelFunc{
 	reflect.Float64: UnmarshalFloat,
 }
 
+// unmarshalFuncs are the unmarshal funcs in use, a copy of DefaultUnmarshalFuncs
+// with the funcs of RegisterUnmarshalFunc, so reading never races with writes to the exported map
+var unmarshalFuncs struct {
+	mu    sync.RWMutex
+	funcs map[reflect.Kind]UnmarshalExcelFunc
+	// The funcs of RegisterUnmarshalFunc, nil for removed kinds
+	registered map[reflect.Kind]UnmarshalExcelFunc
+}
+
+// copyUnmarshalFuncs copies DefaultUnmarshalFuncs and the registered funcs into unmarshalFuncs,
+// with unmarshalFuncs.mu locked
+func copyUnmarshalFuncs() {
+	funcs := make(map[reflect.Kind]UnmarshalExcelFunc, len(DefaultUnmarshalFuncs))
+	for kind, fn := range DefaultUnmarshalFuncs {
+		funcs[kind] = fn
+	}
+	for kind, fn := range unmarshalFuncs.registered {
+		if fn == nil {
+			delete(funcs, kind)
+		} else {
+			funcs[kind] = fn
+		}
+	}
+	unmarshalFuncs.funcs = funcs
+}
+
+// RegisterUnmarshalFunc sets the unmarshal func of fields of kind, nil to remove it,
+// safely while other goroutines are reading.
+// It also takes up the changes made to DefaultUnmarshalFuncs since the last call.
+func RegisterUnmarshalFunc(kind reflect.Kind, fn UnmarshalExcelFunc) {
+	unmarshalFuncs.mu.Lock()
+	defer unmarshalFuncs.mu.Unlock()
+	if unmarshalFuncs.registered == nil {
+		unmarshalFuncs.registered = make(map[reflect.Kind]UnmarshalExcelFunc)
+	}
+	unmarshalFuncs.registered[kind] = fn
+	copyUnmarshalFuncs()
+}
+
+// defaultUnmarshalFunc returns the unmarshal func of fields of kind
+func defaultUnmarshalFunc(kind reflect.Kind) (UnmarshalExcelFunc, bool) {
+	unmarshalFuncs.mu.RLock()
+	if unmarshalFuncs.funcs == nil {
+		unmarshalFuncs.mu.RUnlock()
+		unmarshalFuncs.mu.Lock()
+		if unmarshalFuncs.funcs == nil {
+			copyUnmarshalFuncs()
+		}
+		unmarshalFuncs.mu.Unlock()
+		unmarshalFuncs.mu.RLock()
+	}
+	defer unmarshalFuncs.mu.RUnlock()
+	fn, ok := unmarshalFuncs.funcs[kind]
+	return fn, ok
+}
+
 type ExcelUnmarshalParameters struct {
 	// See ReadConfig.TrimSpace
 	TrimSpace bool
@@ -55,6 +121,39 @@ type ExcelUnmarshalParameters struct {
 	Date1904 bool
 	// See ReadConfig.FallbackDateFormats
 	FallbackDateFormats []string
+	// See ReadConfig.BoolFormat
+	BoolFormat BoolFormat
+	// See ReadConfig.Locale
+	Locale language.Tag
+	// See ReadConfig.UnitParser
+	UnitParser UnitParser
+	// See ReadConfig.UnitConversions
+	UnitConversions map[string]UnitConversion
+}
+
+// BoolFormat is the text of booleans, like "Yes" and "No", see WriteConfig.BoolFormat.
+type BoolFormat struct {
+	True  string
+	False string
+	// The error message of the drop-down list of boolean columns.
+	// Defaults to "should be <True> or <False>".
+	Message string
+}
+
+// ChineseBoolFormat writes booleans as Chinese 是/否, see WriteConfig.ChineseBool.
+var ChineseBoolFormat = BoolFormat{True: "是", False: "否", Message: "应该为 是或否"}
+
+// isZero reports whether f is unset, writing booleans as TRUE and FALSE
+func (f BoolFormat) isZero() bool {
+	return f.True == "" && f.False == ""
+}
+
+// message returns the error message of the drop-down list of boolean columns
+func (f BoolFormat) message() string {
+	if f.Message != "" {
+		return f.Message
+	}
+	return fmt.Sprintf("should be %s or %s", f.True, f.False)
 }
 
 type ExcelUnmarshaler interface {
@@ -64,7 +163,7 @@ type ExcelUnmarshaler interface {
 type UnmarshalExcelFunc func(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error
 
 func UnmarshalString(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
-	str, err := cell.FormattedValue()
+	str, err := formattedValue(cell)
 	if err != nil {
 		return fmt.Errorf("error formatting string value: %w", err)
 	}
@@ -75,7 +174,60 @@ func UnmarshalString(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnma
 	return nil
 }
 
+// formattedValue returns the value as displayed,
+// keeping the leading zeros of zero padding number formats like "00000",
+// which xlsx doesn't apply.
+func formattedValue(cell *xlsx.Cell) (string, error) {
+	if cell.Type() == xlsx.CellTypeNumeric && cell.NumFmt != "" && strings.Trim(cell.NumFmt, "0") == "" {
+		if n, err := strconv.ParseInt(cell.Value, 10, 64); err == nil && n >= 0 {
+			return fmt.Sprintf("%0*d", len(cell.NumFmt), n), nil
+		}
+	}
+	return cell.FormattedValue()
+}
+
+// UnmarshalRawString sets the value as stored in the file, without applying the number format,
+// e.g. for codes with leading zeros, see the raw tag option.
+func UnmarshalRawString(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	str := cell.Value
+	if params.TrimSpace {
+		str = strings.TrimSpace(str)
+	}
+	destValue.SetString(str)
+	return nil
+}
+
+// UnmarshalCell sets the Cell holding the stored value, type and number format,
+// e.g. to recover or flag numbers mangled by Excel.
+func UnmarshalCell(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	destValue.Set(reflect.ValueOf(cellFromXLSX(cell)))
+	return nil
+}
+
+// PrecisionLost reports whether a numeric cell probably lost digits,
+// as numbers only keep 15 significant digits:
+// integers of 16 and more digits, and numbers shown in scientific notation like "1.23E+12".
+func PrecisionLost(cell *xlsx.Cell) bool {
+	if cell.Type() != xlsx.CellTypeNumeric {
+		return false
+	}
+	if strings.Contains(strings.ToUpper(cell.NumFmt), "E+") {
+		return true
+	}
+	f, err := strconv.ParseFloat(cell.Value, 64)
+	return err == nil && math.Abs(f) >= 1e15 && f == math.Trunc(f)
+}
+
 func UnmarshalBool(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	// Chinese 是/否, see WriteConfig.ChineseBool
+	if cell.Value == "否" {
+		destValue.SetBool(false)
+		return nil
+	}
+	if f := params.BoolFormat; !f.isZero() && (cell.Value == f.True || cell.Value == f.False) {
+		destValue.SetBool(cell.Value == f.True)
+		return nil
+	}
 	destValue.SetBool(cell.Bool())
 	return nil
 }
@@ -93,6 +245,14 @@ func UnmarshalInt(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarsh
 }
 
 func UnmarshalUInt(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	// Values beyond the int64 range, e.g. written as text
+	if uval, err := strconv.ParseUint(cell.Value, 10, 64); err == nil {
+		if destValue.OverflowUint(uval) {
+			return ErrOverflow
+		}
+		destValue.SetUint(uval)
+		return nil
+	}
 	val, err := cell.Int64()
 	if err != nil {
 		return fmt.Errorf("error parsing cell as integer value: %w", err)
@@ -109,43 +269,93 @@ func UnmarshalUInt(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmars
 }
 
 func UnmarshalFloat(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
-	val, err := cell.Float()
-	if err != nil {
-		return fmt.Errorf("error parsing cell as float value: %w", err)
+	value := cell.Value
+	if params.Locale != language.Und && cell.Type() != xlsx.CellTypeNumeric {
+		value = delocalizeNumber(value, params.Locale)
 	}
-	if destValue.OverflowFloat(val) {
+	// Parse with the precision of the field, so float32 values round trip exactly
+	val, err := strconv.ParseFloat(value, destValue.Type().Bits())
+	if errors.Is(err, strconv.ErrRange) {
 		return ErrOverflow
 	}
+	if err != nil {
+		return fmt.Errorf("error parsing cell as float value: %w", err)
+	}
 	destValue.SetFloat(val)
 	return nil
 }
 
+// UnmarshalTime unmarshals date cells, serial date numbers, texts in one of the
+// ReadConfig.FallbackDateFormats and RFC 3339 texts, which keep their offset,
+// e.g. "2023-01-02T03:04:05+08:00". Dates without offset are read in UTC.
 func UnmarshalTime(destValue reflect.Value, cell *xlsx.Cell, params *ExcelUnmarshalParameters) error {
+	val, err := parseTimeCell(cell, params, time.UTC)
+	if err != nil {
+		return err
+	}
+	destValue.Set(reflect.ValueOf(val))
+	return nil
+}
+
+// parseTimeCell returns the time of cell, reading dates without offset in loc
+func parseTimeCell(cell *xlsx.Cell, params *ExcelUnmarshalParameters, loc *time.Location) (time.Time, error) {
 	var val time.Time
+	if cell.Value == "" {
+		// The zero time is written as empty cell
+		return val, nil
+	}
+	text := cell.Value
+	if params.Locale != language.Und {
+		text = englishMonths(text, params.Locale)
+	}
 	if cell.IsTime() {
 		var err error
 		val, err = cell.GetTime(params.Date1904)
+		// Serial numbers lose precision below the millisecond, which Excel doesn't show anyway,
+		// so times read back as written
+		val = inLocation(val.Round(time.Millisecond), loc)
 		if err != nil {
 			var ok bool
-			val, ok = unmarshalTimeFallback(cell.Value, params.FallbackDateFormats)
+			val, ok = parseTimeText(text, params.FallbackDateFormats, loc)
 			if !ok {
-				return fmt.Errorf("error parsing cell as date/time value: %w", err)
+				return val, fmt.Errorf("error parsing cell as date/time value: %w", err)
 			}
 		}
 	} else {
 		var ok bool
-		val, ok = unmarshalTimeFallback(cell.Value, params.FallbackDateFormats)
+		val, ok = parseTimeText(text, params.FallbackDateFormats, loc)
+		if !ok && cell.Type() == xlsx.CellTypeNumeric {
+			// A serial date number without date format
+			if serial, err := strconv.ParseFloat(cell.Value, 64); err == nil {
+				val, ok = inLocation(xlsx.TimeFromExcelTime(serial, params.Date1904).Round(time.Millisecond), loc), true
+			}
+		}
 		if !ok {
-			return fmt.Errorf("error parsing cell as date/time value: %w", ErrNoRecognizedFormat)
+			return val, fmt.Errorf("error parsing cell as date/time value: %w", ErrNoRecognizedFormat)
 		}
 	}
-	destValue.Set(reflect.ValueOf(val))
-	return nil
+	return val, nil
+}
+
+// inLocation returns the wall clock time of the UTC time t in loc
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == time.UTC {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// parseTimeText parses value in one of formats or else as RFC 3339 text
+func parseTimeText(value string, formats []string, loc *time.Location) (time.Time, bool) {
+	if val, ok := unmarshalTimeFallback(value, formats, loc); ok {
+		return val, true
+	}
+	return unmarshalTimeFallback(value, []string{time.RFC3339Nano}, loc)
 }
 
-func unmarshalTimeFallback(value string, formats []string) (time.Time, bool) {
+func unmarshalTimeFallback(value string, formats []string, loc *time.Location) (time.Time, bool) {
 	for _, format := range formats {
-		val, err := time.Parse(format, value)
+		val, err := time.ParseInLocation(format, value, loc)
 		if err == nil {
 			return val, true
 		}
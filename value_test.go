@@ -12,9 +12,11 @@
 package exl
 
 import (
+	"bytes"
 	"errors"
 	"math"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -323,6 +325,17 @@ func TestUnmarshalTime(t *testing.T) {
 			t.Fatal("expected error, got nil")
 		}
 	})
+
+	t.Run("text cell with offset", func(t *testing.T) {
+		cell.SetString("2023-11-13T22:15:00.5+08:00")
+		err := UnmarshalTime(destField, cell, &ExcelUnmarshalParameters{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, true, testTime.Add(500*time.Millisecond).Equal(model.T))
+		_, offset := model.T.Zone()
+		equal(t, 8*60*60, offset)
+	})
 }
 
 func TestUnmarshalExcelUnmarshaler(t *testing.T) {
@@ -385,3 +398,43 @@ func equal(t *testing.T, expected, actual any) {
 		t.Errorf("test failed, expected \"%v\", got \"%v\"", expected, actual)
 	}
 }
+
+func TestRegisterUnmarshalFuncConcurrently(t *testing.T) {
+	buf := &bytes.Buffer{}
+	_ = WriteTo(buf, []*pageTmp{{1, "a"}})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ts, err := ReadBinary[*pageTmp](buf.Bytes())
+			equal(t, nil, err)
+			equal(t, 1, len(ts))
+			equal(t, nil, WriteTo(&bytes.Buffer{}, ts))
+		}()
+		go func() {
+			defer wg.Done()
+			RegisterUnmarshalFunc(reflect.Uint8, UnmarshalUInt)
+		}()
+	}
+	wg.Wait()
+	RegisterUnmarshalFunc(reflect.Complex64, UnmarshalFloat)
+	_, ok := defaultUnmarshalFunc(reflect.Complex64)
+	equal(t, true, ok)
+	RegisterUnmarshalFunc(reflect.Complex64, nil)
+	_, ok = defaultUnmarshalFunc(reflect.Complex64)
+	equal(t, false, ok)
+	// Registering doesn't write to the exported map
+	_, ok = DefaultUnmarshalFuncs[reflect.Complex64]
+	equal(t, false, ok)
+
+	// Changes to the exported map are taken up by registering
+	DefaultUnmarshalFuncs[reflect.Complex128] = UnmarshalFloat
+	RegisterUnmarshalFunc(reflect.Uint8, UnmarshalUInt)
+	_, ok = defaultUnmarshalFunc(reflect.Complex128)
+	equal(t, true, ok)
+	delete(DefaultUnmarshalFuncs, reflect.Complex128)
+	RegisterUnmarshalFunc(reflect.Uint8, UnmarshalUInt)
+	_, ok = defaultUnmarshalFunc(reflect.Complex128)
+	equal(t, false, ok)
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// TemplateVersionSheetName is the name of the hidden sheet written with WriteConfig.TemplateVersion.
+const TemplateVersionSheetName = "_template"
+
+// templateVersionLabel labels the version in the template version sheet
+const templateVersionLabel = "Version"
+
+// ErrTemplateVersion is matched by TemplateVersionError with errors.Is.
+var ErrTemplateVersion = errors.New("exl: outdated template")
+
+// TemplateVersionError is returned when reading a workbook of another template version
+// than ReadConfig.ExpectedTemplateVersion.
+type TemplateVersionError struct {
+	Expected string
+	// The version of the workbook, "" for workbooks written without WriteConfig.TemplateVersion.
+	Actual string
+}
+
+// Error implements error with a message meant for the user uploading the workbook.
+func (e *TemplateVersionError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("exl: the template has no version, please download the latest template (version %s)", e.Expected)
+	}
+	return fmt.Sprintf("exl: the template version %s is outdated, please download the latest template (version %s)", e.Actual, e.Expected)
+}
+
+// Is reports whether target is ErrTemplateVersion.
+func (e *TemplateVersionError) Is(target error) bool {
+	return target == ErrTemplateVersion
+}
+
+// TemplateVersion returns the version of the workbook of reader written with WriteConfig.TemplateVersion,
+// "" if it has none.
+func TemplateVersion(reader io.Reader) (string, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	book, err := XLSXBackend{}.Open(bs)
+	if err != nil {
+		return "", err
+	}
+	defer closeBook(book)
+	return templateVersion(book)
+}
+
+// templateVersion returns the version of book, "" if it has none
+func templateVersion(book Spreadsheet) (string, error) {
+	var version string
+	for i, name := range book.Sheets() {
+		if name != TemplateVersionSheetName {
+			continue
+		}
+		err := book.Rows(i, func(row *Row) error {
+			if row.Cell(0).Value == templateVersionLabel {
+				version = row.Cell(1).Value
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return version, nil
+}
+
+// checkTemplateVersion returns a *TemplateVersionError unless book has the version expected by rc,
+// if any, see ReadConfig.ExpectedTemplateVersion
+func checkTemplateVersion(book Spreadsheet, rc *ReadConfig) error {
+	expected := rc.ExpectedTemplateVersion
+	if expected == "" {
+		return nil
+	}
+	actual, err := templateVersion(book)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return &TemplateVersionError{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// writeTemplateVersion appends the hidden template version sheet to book
+func writeTemplateVersion(book Spreadsheet, version string) error {
+	hider, ok := book.(SheetHider)
+	if !ok {
+		return ErrUnsupported
+	}
+	// Reading needs the exact name
+	name, err := newSheetName(book, TemplateVersionSheetName, true)
+	if err != nil {
+		return err
+	}
+	sheet, err := book.AddSheet(name)
+	if err != nil {
+		return err
+	}
+	if err = book.AppendRow(sheet, &Row{Cells: []Cell{StringCell(templateVersionLabel), StringCell(version)}}); err != nil {
+		return err
+	}
+	return hider.HideSheet(sheet)
+}
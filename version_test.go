@@ -0,0 +1,59 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type (
+	templateV1Tmp struct {
+		Name string `excel:"Name"`
+	}
+	templateV2Tmp struct {
+		Name  string `excel:"Name"`
+		Email string `excel:"Email"`
+	}
+)
+
+func (*templateV1Tmp) ReadConfigure(*ReadConfig)      {}
+func (*templateV1Tmp) WriteConfigure(wc *WriteConfig) { wc.TemplateVersion = "1" }
+func (*templateV2Tmp) ReadConfigure(rc *ReadConfig)   { rc.ExpectedTemplateVersion = "2" }
+func (*templateV2Tmp) WriteConfigure(wc *WriteConfig) { wc.TemplateVersion = "2" }
+
+func TestTemplateVersion(t *testing.T) {
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*templateV1Tmp{{"a"}}))
+	version, err := TemplateVersion(bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	equal(t, "1", version)
+
+	_, err = ReadBinary[*templateV2Tmp](buf.Bytes())
+	equal(t, true, errors.Is(err, ErrTemplateVersion))
+	equal(t, "exl: the template version 1 is outdated, please download the latest template (version 2)", err.Error())
+	var tve *TemplateVersionError
+	equal(t, true, errors.As(err, &tve))
+	equal(t, "1", tve.Actual)
+
+	buf.Reset()
+	_ = WriteTo(buf, []*pageTmp{{1, "a"}})
+	_, err = ReadBinary[*templateV2Tmp](buf.Bytes())
+	equal(t, "exl: the template has no version, please download the latest template (version 2)", err.Error())
+
+	buf.Reset()
+	_ = WriteTo(buf, []*templateV2Tmp{{"a", "a@example.com"}})
+	ts, err := ReadBinary[*templateV2Tmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, []*templateV2Tmp{{"a", "a@example.com"}}, ts)
+}
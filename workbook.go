@@ -0,0 +1,141 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"sync"
+)
+
+// DefaultRowCacheSize is the number of rows cached by a Workbook opened with cacheRows 0.
+const DefaultRowCacheSize = 1024
+
+// ErrRowOutOfRange is returned by Workbook.RowAt for rows below the dimension of the sheet.
+var ErrRowOutOfRange = errors.New("exl: row index out of range")
+
+// Workbook gives random access to the rows of a file,
+// e.g. for pagination UIs seeking within huge files without iterating from the top each time.
+// The most recently used rows are kept in a cache.
+// A Workbook is safe for concurrent use.
+type Workbook struct {
+	mu    sync.Mutex
+	book  Spreadsheet
+	size  int
+	rows  map[rowKey]*list.Element
+	order *list.List // of cachedRow, most recently used first
+}
+
+type (
+	rowKey    struct{ sheet, n int }
+	cachedRow struct {
+		key rowKey
+		row *Row
+	}
+)
+
+// OpenWorkbook opens the file of reader with backend, nil for XLSXBackend,
+// caching up to cacheRows parsed rows, 0 for DefaultRowCacheSize.
+// Pass xlsx.UseDiskVCellStore in the XLSXBackend options to keep the rows of huge files on disk,
+// unencrypted, as xlsx reads files into its own cell stores only, unlike XLSXBackend.EncryptSpill.
+func OpenWorkbook(reader io.Reader, backend SpreadsheetBackend, cacheRows int) (*Workbook, error) {
+	bs, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if backend == nil {
+		backend = XLSXBackend{}
+	}
+	book, err := backend.Open(bs)
+	if err != nil {
+		return nil, err
+	}
+	if cacheRows <= 0 {
+		cacheRows = DefaultRowCacheSize
+	}
+	return &Workbook{book: book, size: cacheRows, rows: map[rowKey]*list.Element{}, order: list.New()}, nil
+}
+
+// Sheets returns the names of all sheets, in workbook order.
+func (w *Workbook) Sheets() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.book.Sheets()
+}
+
+// Dimension returns the number of rows and columns of the sheet at the 0-based index.
+func (w *Workbook) Dimension(sheet int) (rows, cols int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.book.Dimension(sheet)
+}
+
+// RowAt returns the row at the 0-based index n of the sheet at the 0-based index sheet.
+// Rows missing in the file are returned with empty cells, ErrRowOutOfRange is returned
+// for rows below the dimension of the sheet.
+// The returned row is shared with other callers and must not be modified.
+func (w *Workbook) RowAt(sheet, n int) (*Row, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	key := rowKey{sheet, n}
+	if e, ok := w.rows[key]; ok {
+		w.order.MoveToFront(e)
+		return e.Value.(cachedRow).row, nil
+	}
+	rows, cols, err := w.book.Dimension(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n >= rows {
+		return nil, ErrRowOutOfRange
+	}
+	row, err := w.readRow(sheet, n, cols)
+	if err != nil {
+		return nil, err
+	}
+	w.rows[key] = w.order.PushFront(cachedRow{key, row})
+	if w.order.Len() > w.size {
+		delete(w.rows, w.order.Remove(w.order.Back()).(cachedRow).key)
+	}
+	return row, nil
+}
+
+// readRow reads a row with the backend, seeking to it if supported
+func (w *Workbook) readRow(sheet, n, cols int) (*Row, error) {
+	if seeker, ok := w.book.(RowSeeker); ok {
+		return seeker.RowAt(sheet, n)
+	}
+	row := &Row{Index: n, Cells: make([]Cell, cols)}
+	err := w.book.Rows(sheet, func(r *Row) error {
+		if r.Index == n {
+			row = r
+		}
+		if r.Index >= n {
+			return errStopRows
+		}
+		return nil
+	})
+	if err != nil && err != errStopRows {
+		return nil, err
+	}
+	return row, nil
+}
+
+// Close releases the resources of the workbook, e.g. the temporary files of rows stored on disk.
+func (w *Workbook) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rows, w.order = map[rowKey]*list.Element{}, list.New()
+	closeBook(w.book)
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 exl Author. All Rights Reserved.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exl
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx/v3"
+)
+
+func TestWorkbookRowAt(t *testing.T) {
+	f := xlsx.NewFile()
+	sheet, _ := f.AddSheet("Sheet1")
+	for i := 0; i < 10; i++ {
+		appendXLSXRow(sheet, NewRow("row", i))
+	}
+	row, _ := sheet.Row(12)
+	row.AddCell().SetString("last")
+	buf := &bytes.Buffer{}
+	_ = f.Write(buf)
+
+	w, err := OpenWorkbook(buf, nil, 3)
+	equal(t, nil, err)
+	defer func() { _ = w.Close() }()
+	equal(t, []string{"Sheet1"}, w.Sheets())
+	for _, n := range []int{7, 2, 7, 9, 0} {
+		r, err := w.RowAt(0, n)
+		equal(t, nil, err)
+		equal(t, n, r.Index)
+		equal(t, []string{"row", strconv.Itoa(n)}, r.Strings())
+	}
+	// Rows 7, 9 and 0 are cached, 2 was evicted
+	equal(t, 3, w.order.Len())
+	_, cached := w.rows[rowKey{0, 2}]
+	equal(t, false, cached)
+	r1, _ := w.RowAt(0, 9)
+	r2, _ := w.RowAt(0, 9)
+	equal(t, true, r1 == r2)
+
+	r, err := w.RowAt(0, 11)
+	equal(t, nil, err)
+	equal(t, []string{"", ""}, r.Strings())
+	r, err = w.RowAt(0, 12)
+	equal(t, nil, err)
+	equal(t, []string{"last", ""}, r.Strings())
+	_, err = w.RowAt(0, 13)
+	equal(t, ErrRowOutOfRange, err)
+	_, err = w.RowAt(1, 0)
+	equal(t, ErrSheetNotFound, err)
+}
+
+func TestWorkbookRowAtRows(t *testing.T) {
+	// Backends without RowSeeker iterate the rows
+	w, err := OpenWorkbook(strings.NewReader("ID;Name\n1;a\n2;b"), memBackend{}, 0)
+	equal(t, nil, err)
+	r, err := w.RowAt(0, 1)
+	equal(t, nil, err)
+	equal(t, []string{"1", "a"}, r.Strings())
+	_, err = w.RowAt(0, 3)
+	equal(t, ErrRowOutOfRange, err)
+}
@@ -12,12 +12,15 @@
 package exl
 
 import (
+	"encoding"
 	"fmt"
-	"github.com/tealeg/xlsx/v3"
 	"io"
 	"reflect"
 	"strings"
 	"time"
+
+	"github.com/tealeg/xlsx/v3"
+	"golang.org/x/text/language"
 )
 
 type (
@@ -27,61 +30,274 @@ type (
 		TagName   string
 		// Skip when struct field have NOT matched tagName.
 		SkipNoTag bool
+		// The tag names of the only columns written, e.g. the customer facing variant of a report,
+		// fields without tag are named by the field name.
+		// Defaults to nil, writing all columns.
+		IncludeColumns []string
+		// The tag names of the columns not written, e.g. internal notes, applied after IncludeColumns.
+		// Defaults to nil, writing all columns.
+		ExcludeColumns []string
+		// The columns of the sheet in order, with their header, width and number format,
+		// e.g. configured by end users of a report builder, overriding the tags, see ColumnSpec.
+		// Columns not listed aren't written, nor are those excluded by IncludeColumns and ExcludeColumns.
+		// Defaults to nil, writing the columns in field order.
+		ColumnLayout []ColumnSpec
+		// The data of the placeholders in SheetName and the headers, e.g. "Report {{.Month}}"
+		// with TemplateData{"Month": "2023-01"}, in the syntax of text/template.
+		// Defaults to nil, writing names and headers as they are.
+		TemplateData map[string]any
+		// Write fields as several columns by tag name, e.g. time.Time as Date and Time columns
+		// with Splitter{Headers: []string{"Date", "Time"}, Split: SplitDateTime}.
+		// Fields tagged with the combine option are split into its columns by default.
+		// Defaults to none.
+		Splitters map[string]Splitter
 		// Skip when struct field is a nil pointer.
+		// Nil pointers are always written as empty cells, which read back as nil
+		// with ReadConfig.PointerCanNil, so this option has no effect anymore.
 		SkipNilPointer bool
+		// The text written for nil pointers, e.g. "N/A", to tell them from empty text,
+		// overridden per field by the nilas tag option like `excel:"Score,nilas=-"`.
+		// Read it back as nil with ReadConfig.NilPlaceholder.
+		// Defaults to "", an empty cell.
+		NilPlaceholder string
 		// Set dropList and write value which is transformed from key.
 		DropListMap map[string][]struct {
 			Key   string
 			Value string
 		}
-		// Transform TRUE/FALSE to Chinese 是/否.
-		ChineseBool  bool
+		// Transform TRUE/FALSE to Chinese 是/否, a preset of BoolFormat,
+		// which takes precedence if set.
+		ChineseBool bool
+		// The text written for booleans, also offered by the drop-down list of boolean columns,
+		// e.g. BoolFormat{True: "Yes", False: "No"}. Read it back with ReadConfig.BoolFormat.
+		// Defaults to TRUE and FALSE boolean cells.
+		BoolFormat   BoolFormat
 		WriteTimeFmt string
+		// The locale of the users reading the workbook, e.g. language.German,
+		// writing floats as text with its decimal and thousands separators like "1.234,56",
+		// read back with ReadConfig.Locale, and dates in its date format like "31.12.2024",
+		// unless WriteTimeFmt is changed from its default.
+		// Defaults to language.Und, writing floats as numbers.
+		Locale language.Tag
+		// Write dates in the 1904 date system of old Mac workbooks,
+		// which all sheets of a workbook must share.
+		// The backend must implement DateSystemSetter.
+		// Defaults to the 1900 date system.
+		Date1904 bool
+		// The backend used to create the spreadsheet.
+		// Defaults to XLSXBackend.
+		Backend SpreadsheetBackend
+		// Tab color of the written sheet, as hex RGB like "FF0000".
+		// Defaults to no color.
+		TabColor string
+		// 0-based position of the written sheet among the sheets of the workbook,
+		// when writing into a workbook with several sheets, see WriteSheet.
+		// Defaults to -1, appending the sheet after the existing ones.
+		SheetPosition int
+		// Show the written sheet when the workbook is opened.
+		// Defaults to false, showing the first sheet.
+		ActiveSheet bool
+		// Returns the outline level of a record, rows with a level above 0
+		// are grouped below the preceding row of a lower level and can be collapsed.
+		// Alternatively tag an integer field with the outline option, e.g. `excel:"Level,outline"`.
+		// Defaults to nil.
+		GroupLevelFunc func(record any) uint8
+		// Place the collapse button of a row group below its rows, as Excel does by default.
+		// Defaults to false, the parent row above the group is the summary,
+		// which suits hierarchical data.
+		GroupSummaryBelow bool
+		// Header of an additional first column holding the hierarchy level of rows,
+		// 0 for records and 1 for the elements of their children field,
+		// e.g. `excel:",children"` on an Items []*Item field.
+		// Child rows are always indented, leaving the parent columns empty,
+		// and grouped below their parent row.
+		// Defaults to "", no level column.
+		LevelColumn string
+		// Header of the generated key column linking detail sheets to their records,
+		// written for types with a slice field tagged with the sheet option,
+		// e.g. `excel:"Order Items,sheet"`, but without a field tagged with the key option.
+		// Defaults to "#".
+		KeyColumn string
+		// Prefix text cells starting with =, +, -, @, tab or carriage return with ',
+		// so spreadsheet applications don't evaluate user generated content as formula.
+		// Defaults to false.
+		EscapeFormulaInjection bool
+		// Configure how cells exceeding the limits of a worksheet are handled:
+		// text longer than MaxCellLength, more than MaxRows rows or MaxColumns columns,
+		// and integers beyond 2^53, which lose precision as numeric cell.
+		// Defaults to LimitIgnore.
+		LimitHandling LimitHandling
+		// Called for each limit exceeded with LimitHandling LimitTruncate,
+		// err is a LimitError.
+		// Defaults to nil.
+		LimitWarning func(err error)
+		// Write integers with more than 15 digits as text cells,
+		// as numeric cells only keep 15 significant digits, e.g. for large IDs.
+		// Alternatively tag single fields with the text option, e.g. `excel:"ID,text"`.
+		// Defaults to false.
+		LargeIntsAsText bool
+		// Receives the number of rows written, errors and phase durations.
+		// Defaults to nil.
+		Metrics Metrics
+		// Marshal functions for the columns of the given headers, i.e. field tags,
+		// used instead of the default rendering of the field type,
+		// e.g. to mask personal data or format composite values in one report.
+		// Nil pointers are written as empty cells, the function receives the value pointed to.
+		// Defaults to none.
+		FieldMarshalers map[string]MarshalExcelFunc
+		// Mask the columns of fields tagged with the mask option, e.g. `excel:"SSN,mask=last4"`,
+		// for exports to audiences without access to the data.
+		// The built-in redactors are all, last4, first1 and email, see MaskAll.
+		// Defaults to false, writing the values unmasked.
+		Redact bool
+		// Additional redactors by name, for use in mask tag options like `excel:"IBAN,mask=iban"`,
+		// replacing built-in redactors of the same name.
+		// Defaults to none.
+		Redactors map[string]Redactor
+		// Replace the values of fields tagged with the pseudonym option by hashes or tokens,
+		// e.g. `excel:"Customer,pseudonym=sha256"`, before masking.
+		// The built-in pseudonymizer is sha256, see HashSHA256.
+		// Defaults to false, writing the values as they are.
+		Pseudonymize bool
+		// Additional pseudonymizers by name, for use in pseudonym tag options like `excel:"Customer,pseudonym=token"`,
+		// e.g. HMACSHA256 with a secret key or a lookup in a token vault.
+		// Defaults to none.
+		Pseudonymizers map[string]Pseudonymizer
+		// Rows appended below the records, e.g. SUM formulas of numeric columns and a checksum.
+		// Defaults to no footer rows.
+		Footer Footer
+		// The deflate level of the saved zip package,
+		// from 1 (fastest, largest) to 9 (slowest, smallest), see compress/flate.
+		// Defaults to 0, the default level of compress/flate.
+		CompressionLevel int
+		// Parts of the saved zip package stored without compression,
+		// as path.Match patterns of part names, e.g. "xl/worksheets/*.xml".
+		// Defaults to none.
+		StoredParts []string
+		// Returns the name of the sheet a record is written to, e.g. a sheet per region,
+		// the sheets are created when their first record is written, in that order.
+		// The sheet options like TabColor apply to all of them, only the first one is active.
+		// Unlike grouping the records before writing, records can be streamed with StreamWriter.
+		// Types with detail sheets aren't supported.
+		// Defaults to nil, writing all records to SheetName, which is also used for blank names.
+		SheetNameFunc func(record any) string
+		// Return a *SheetNameError for sheet names Excel doesn't accept or which are used by another sheet,
+		// see CheckSheetName.
+		// Defaults to false, sanitizing names with SanitizeSheetName and suffixing duplicates like "Sales (2)".
+		StrictSheetNames bool
+		// Let WriteFile write to a temporary file in the directory of the file,
+		// renamed to the file once complete, so interrupted writes never leave a partial file.
+		// Defaults to false, writing the file in place.
+		AtomicWrite bool
+		// Configure how WriteFile handles an existing file.
+		// Defaults to OverwriteReplace.
+		Overwrite OverwritePolicy
+		// Append a hidden sheet named AuditSheetName recording the exl version, the time of writing,
+		// the number of records per sheet and a hash of this config, for traceability, see ReadAudit.
+		// Writing fails with a *SheetNameError if another sheet is named AuditSheetName.
+		// Defaults to false.
+		Audit bool
+		// Identifies the user recorded in the audit sheet, e.g. the user requesting the export.
+		// Defaults to "", no user.
+		AuditUser string
+		// The version of the template stamped into a hidden sheet named TemplateVersionSheetName,
+		// checked when reading uploaded templates with ReadConfig.ExpectedTemplateVersion.
+		// Defaults to "", no version.
+		TemplateVersion string
+		// Sign the sheet names and cells of the workbook with HMAC-SHA256 using this key,
+		// stored in a hidden sheet named SignatureSheetName with the names of the sheets signed,
+		// so recipients sharing the key can detect modifications with Verify.
+		// Sheets added later, e.g. by WriteSheet, aren't signed and don't affect Verify.
+		// Defaults to nil, not signing.
+		SigningKey []byte
+
+		// The options of the Sheet tag of the type, kept by Inherit
+		sheetTag tagOptions
 	}
 )
 
-var defaultWriteConfig = func() *WriteConfig {
-	return &WriteConfig{SheetName: "Sheet1", TagName: "excel", WriteTimeFmt: xlsx.DefaultDateFormat}
+type (
+	// ExcelMarshaler is implemented by types writing themselves as a cell,
+	// the counterpart of ExcelUnmarshaler.
+	// Types implementing encoding.TextMarshaler are written as text cells otherwise.
+	// Like when reading, the methods may have a pointer receiver.
+	ExcelMarshaler interface {
+		MarshalExcel() (Cell, error)
+	}
+	// MarshalExcelFunc returns the cell written for a field value, see WriteConfig.FieldMarshalers.
+	MarshalExcelFunc func(value reflect.Value) (Cell, error)
+)
+
+// defaultWriteConfig returns the default write config, see SetDefaultWriteConfig
+func defaultWriteConfig() *WriteConfig {
+	wc := &WriteConfig{SheetName: "Sheet1", TagName: "excel", WriteTimeFmt: xlsx.DefaultDateFormat, Backend: XLSXBackend{}, SheetPosition: -1, KeyColumn: "#"}
+	if configure := writeDefaults(); configure != nil {
+		configure(wc)
+	}
+	return wc
 }
 
-func write(sheet *xlsx.Sheet, data []any, wc ...*WriteConfig) {
-	var wConfig *WriteConfig
-	if len(wc) >= 0 {
-		wConfig = wc[0]
+// boolFormat returns the text written for booleans, ok is false for TRUE and FALSE boolean cells
+func (wc *WriteConfig) boolFormat() (f BoolFormat, ok bool) {
+	if !wc.BoolFormat.isZero() {
+		return wc.BoolFormat, true
 	}
-	r := sheet.AddRow()
+	if wc.ChineseBool {
+		return ChineseBoolFormat, true
+	}
+	return BoolFormat{}, false
+}
+
+// setDateSystem switches book to the 1904 date system if date1904 before its first sheet,
+// returning ErrDateSystemMismatch for books with sheets in the other date system
+func setDateSystem(book Spreadsheet, date1904 bool) error {
+	if book.Date1904() == date1904 {
+		return nil
+	}
+	if len(book.Sheets()) > 0 {
+		return ErrDateSystemMismatch
+	}
+	setter, ok := book.(DateSystemSetter)
+	if !ok {
+		return fmt.Errorf("%w: 1904 date system", ErrUnsupported)
+	}
+	return setter.SetDate1904(date1904)
+}
+
+// newRow converts the values of one row to cells
+func newRow(data []any, wc *WriteConfig) *Row {
+	r := &Row{Cells: make([]Cell, 0, len(data))}
 	for _, cell := range data {
-		if reflect.TypeOf(cell) == reflect.TypeOf(time.Time{}) {
-			t := cell.(time.Time)
+		if t, ok := cell.(time.Time); ok {
 			if t.IsZero() {
-				r.AddCell().SetValue("")
+				r.Cells = append(r.Cells, StringCell(""))
 			} else {
-				r.AddCell().SetDateWithOptions(t, xlsx.DateTimeOptions{
-					Location:        xlsx.DefaultDateOptions.Location,
-					ExcelTimeFormat: wConfig.WriteTimeFmt,
-				})
+				r.Cells = append(r.Cells, timeCell(t, wc.timeFormat(), wc.Date1904))
 			}
 		} else {
-			r.AddCell().SetValue(cell)
+			r.Cells = append(r.Cells, NewCell(cell))
+		}
+		if wc.EscapeFormulaInjection {
+			r.Cells[len(r.Cells)-1] = escapeFormula(r.Cells[len(r.Cells)-1])
 		}
 	}
+	return r
 }
 
-func NewFileFromSlice[T WriteConfigurator](ts []T) *xlsx.File {
-	f := xlsx.NewFile()
-	write0(f, ts)
-	return f
+// escapeFormula prefixes text cells which could be evaluated as formula with '
+func escapeFormula(c Cell) Cell {
+	if c.Type == CellTypeString && c.Value != "" && strings.ContainsRune("=+-@\t\r", rune(c.Value[0])) {
+		c.Value = "'" + c.Value
+	}
+	return c
 }
 
-// WriteFile defines write []T to excel file
-//
-// params: file,excel file full path
-//
-// params: typed parameter T, must be implements exl.Bind
-func WriteFile[T WriteConfigurator](file string, ts []T) error {
-	f := xlsx.NewFile()
-	write0(f, ts)
-	return f.Save(file)
+// NewFileFromSlice returns a xlsx file holding ts.
+// The file is always created by XLSXBackend, regardless of WriteConfig.Backend.
+func NewFileFromSlice[T WriteConfigurator](ts []T) *xlsx.File {
+	book := XLSXBackend{}.Create()
+	_ = write0(book, ts, newWriteConfig[T](), nil)
+	return book.(*xlsxSpreadsheet).file
 }
 
 // WriteTo defines write to []T to excel file
@@ -90,173 +306,820 @@ func WriteFile[T WriteConfigurator](file string, ts []T) error {
 //
 // params: typed parameter T, must be implements exl.Bind
 func WriteTo[T WriteConfigurator](w io.Writer, ts []T) error {
-	f := xlsx.NewFile()
-	write0(f, ts)
-	return f.Write(w)
+	return writeTo(w, ts, newWriteConfig[T](), nil)
+}
+
+// WriteToConfig writes ts like WriteTo with wc replacing the write config of T, nil to use it,
+// e.g. to write variants of a report choosing the columns with IncludeColumns and ExcludeColumns.
+func WriteToConfig[T WriteConfigurator](w io.Writer, ts []T, wc *WriteConfig) error {
+	if wc == nil {
+		wc = newWriteConfig[T]()
+	}
+	return writeTo(w, ts, wc, nil)
 }
 
-func write0[T WriteConfigurator](f *xlsx.File, ts []T) {
+// writeTo writes ts below the preamble rows to a new file saved to w, measuring the phases
+func writeTo[T WriteConfigurator](w io.Writer, ts []T, wc *WriteConfig, preamble [][]any) error {
+	book := wc.Backend.Create()
+	done := measure(wc.Metrics, OpWrite, PhaseWrite)
+	err := write0(book, ts, wc, preamble)
+	done()
+	if err != nil {
+		countError(wc.Metrics, OpWrite, err)
+		return err
+	}
+	done = measure(wc.Metrics, OpWrite, PhaseSave)
+	err = book.Save(w)
+	done()
+	countError(wc.Metrics, OpWrite, err)
+	return err
+}
+
+// newWriteConfig returns the write config of T
+func newWriteConfig[T WriteConfigurator]() *WriteConfig {
 	wc := defaultWriteConfig()
-	var nilT T
-	nilT.WriteConfigure(wc)
-	haveDropList := wc.DropListMap != nil
-
-	tT := new(T)
-	if sheet, _ := f.AddSheet(wc.SheetName); sheet != nil {
-		typ := reflect.TypeOf(tT).Elem().Elem()
-		numField := typ.NumField()
-		header := make([]any, 0, numField)
-		for i := 0; i < numField; i++ {
-			fe := typ.Field(i)
-			t := fe.Type
-			if !fe.IsExported() {
-				continue
+	t := configTarget[T]()
+	applySheetWriteTag(reflect.TypeOf(t), wc)
+	t.WriteConfigure(wc)
+	return wc
+}
+
+// writeColumn is a struct field written as column
+type writeColumn struct {
+	fieldIndex int
+	header     string
+	tag        string
+	opts       tagOptions
+	typ        reflect.Type
+	// The header text, width and number format of WriteConfig.ColumnLayout
+	title  string
+	width  float64
+	format string
+	// The currency column of a Money field, see the currency tag option
+	currency bool
+	// The headers of the columns a field is split into and the index of this one,
+	// see WriteConfig.Splitters and the combine tag option
+	split []string
+	part  int
+	// The tag name of the split field
+	splitField string
+}
+
+// writeColumns returns the fields of typ written as columns, in order
+func writeColumns(typ reflect.Type, wc *WriteConfig) []writeColumn {
+	columns := make([]writeColumn, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		fe := typ.Field(i)
+		if !fe.IsExported() || isSheetField(fe) {
+			continue
+		}
+		tt, have := fe.Tag.Lookup(wc.TagName)
+		if !have && wc.SkipNoTag {
+			continue
+		}
+		name, opts := parseTag(tt)
+		if name == "-" || noColumn(opts) {
+			continue
+		}
+		header := name
+		if header == "" {
+			header = fe.Name
+		}
+		if !wc.includes(header) {
+			continue
+		}
+		if split := splitHeaders(header, opts, wc); split != nil {
+			columns = append(columns, splitColumns(i, fe.Type, opts, split, header, wc)...)
+			continue
+		}
+		columns = append(columns, writeColumn{fieldIndex: i, header: header, tag: name, opts: opts, typ: fe.Type})
+		if currency, ok := opts.Value("currency"); ok && fe.Type == moneyType && wc.includes(currency) {
+			columns = append(columns, writeColumn{fieldIndex: i, header: currency, tag: currency, typ: fe.Type, currency: true})
+		}
+	}
+	return columns
+}
+
+// includes reports whether the column named header is written, see IncludeColumns and ExcludeColumns
+func (wc *WriteConfig) includes(header string) bool {
+	if wc.IncludeColumns != nil && !containsString(wc.IncludeColumns, header) {
+		return false
+	}
+	return !containsString(wc.ExcludeColumns, header)
+}
+
+// outlineField returns the index of the field tagged with the outline option, or -1
+func outlineField(typ reflect.Type, wc *WriteConfig) int {
+	for i := 0; i < typ.NumField(); i++ {
+		if _, opts := parseTag(typ.Field(i).Tag.Get(wc.TagName)); opts.Contains("outline") {
+			return i
+		}
+	}
+	return -1
+}
+
+// outlineLevel returns the outline level of the record v
+func outlineLevel(v reflect.Value, levelField int, wc *WriteConfig) uint8 {
+	if wc.GroupLevelFunc != nil {
+		return wc.GroupLevelFunc(v.Interface())
+	}
+	if levelField < 0 {
+		return 0
+	}
+	f := reflect.Indirect(v.Elem().Field(levelField))
+	switch {
+	case f.CanInt() && f.Int() > 0:
+		return uint8(f.Int())
+	case f.CanUint():
+		return uint8(f.Uint())
+	}
+	return 0
+}
+
+// largeInt reports whether v is an integer with more than 15 digits
+func largeInt(v reflect.Value) bool {
+	const limit = 1e15
+	switch {
+	case v.CanInt():
+		return v.Int() >= limit || v.Int() <= -limit
+	case v.CanUint():
+		return v.Uint() >= limit
+	}
+	return false
+}
+
+// recordValues returns the values of the columns of the struct value rv
+func recordValues(rv reflect.Value, columns []writeColumn, wc *WriteConfig) ([]any, error) {
+	data := make([]any, 0, len(columns))
+	for _, col := range columns {
+		value, err := columnValue(rv.Field(col.fieldIndex), col, wc)
+		if err == nil && (wc.Redact || wc.Pseudonymize) {
+			value, err = protectValue(value, col, wc)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if col.format != "" {
+			value = formatValue(value, col.format, wc)
+		}
+		data = append(data, value)
+	}
+	return data, nil
+}
+
+// nilPlaceholder returns the text of nil pointers in the column tagged with opts, see WriteConfig.NilPlaceholder
+func nilPlaceholder(opts tagOptions, placeholder string) string {
+	if nilAs, ok := opts.Value("nilas"); ok {
+		return nilAs
+	}
+	return placeholder
+}
+
+// columnValue returns the value written for the field v
+func columnValue(v reflect.Value, col writeColumn, wc *WriteConfig) (any, error) {
+	// add special data
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nilPlaceholder(col.opts, wc.NilPlaceholder), nil
+		}
+		v = v.Elem()
+	}
+	// Blank zero values of fields tagged with omitzero, like `excel:"Qty,omitzero"`
+	if col.opts.Contains("omitzero") && v.IsZero() {
+		return "", nil
+	}
+	if col.split != nil {
+		return splitColumnValue(v, col, wc)
+	}
+	if m, ok := moneyColumnValue(v, col); ok {
+		return m, nil
+	}
+	if marshal, have := wc.FieldMarshalers[col.header]; have && marshal != nil {
+		c, err := marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("exl: marshal column %q: %w", col.header, err)
+		}
+		return c, nil
+	}
+	if col.opts.Contains("text") || (wc.LargeIntsAsText && largeInt(v)) {
+		return TextCell(fmt.Sprint(v.Interface())), nil
+	}
+	if v.CanInterface() {
+		if m, ok := v.Interface().(time.Time); ok {
+			if _, _, ok, _ := timeLayout(col.opts); ok {
+				return timeColumnValue(m, col)
 			}
-			name := fe.Name
-			tt, have := fe.Tag.Lookup(wc.TagName)
-			if have {
-				name = tt
+			return m, nil
+		}
+		if c, ok, err := marshalValue(v.Interface(), col); ok {
+			return c, err
+		}
+		// Like reading, by the methods with pointer receiver
+		if v.CanAddr() {
+			if c, ok, err := marshalValue(v.Addr().Interface(), col); ok {
+				return c, err
+			}
+		}
+	}
+	if v.Kind() == reflect.Bool {
+		if f, ok := wc.boolFormat(); ok {
+			if v.Bool() {
+				return f.True, nil
 			}
-			if have || !wc.SkipNoTag {
-				header = append(header, name)
+			return f.False, nil
+		}
+		return v.Interface(), nil
+	}
 
-				// add validation
-				basicType := t.Kind()
-				if t.Kind() == reflect.Ptr {
-					basicType = t.Elem().Kind()
+	if v.Kind() == reflect.String && wc.DropListMap != nil {
+		dropList, have := wc.DropListMap[col.tag]
+		if have {
+			key := v.String()
+			value := key
+			for _, v := range dropList {
+				if v.Key == key {
+					value = v.Value
 				}
+			}
+			return value, nil
+		}
+	}
+	return v.Interface(), nil
+}
 
-				rowIndex := 1
-				colIndex := len(header) - 1
-
-				if basicType == reflect.Bool {
-					dd := xlsx.NewDataValidation(rowIndex, colIndex, xlsx.Excel2006MaxRowIndex, colIndex, t.Kind() == reflect.Ptr)
-					if wc.ChineseBool {
-						dd.SetDropList([]string{"是", "否"})
-						errTitle := ""
-						errMsg := "应该为 是或否"
-						dd.SetError(xlsx.StyleStop, &errTitle, &errMsg)
-						sheet.AddDataValidation(dd)
-					} else {
-						dd.SetDropList([]string{"TRUE", "FALSE"})
-						errTitle := ""
-						errMsg := "should be TRUE or FALSE"
-						dd.SetError(xlsx.StyleStop, &errTitle, &errMsg)
-						sheet.AddDataValidation(dd)
-					}
-				}
+// marshalValue marshals m of column col if it implements ExcelMarshaler or encoding.TextMarshaler
+func marshalValue(m any, col writeColumn) (any, bool, error) {
+	switch m := m.(type) {
+	case ExcelMarshaler:
+		c, err := m.MarshalExcel()
+		if err != nil {
+			return nil, true, fmt.Errorf("exl: marshal column %q: %w", col.header, err)
+		}
+		return c, true, nil
+	case encoding.TextMarshaler:
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, true, fmt.Errorf("exl: marshal column %q: %w", col.header, err)
+		}
+		return StringCell(string(text)), true, nil
+	}
+	return nil, false, nil
+}
 
-				if basicType == reflect.String {
-					if haveDropList {
-						dropList, have := wc.DropListMap[tt]
-						if have {
-							dd := xlsx.NewDataValidation(rowIndex, colIndex, xlsx.Excel2006MaxRowIndex, colIndex, t.Kind() == reflect.Ptr)
-							dropListArr := make([]string, 0, len(dropList))
-							for _, v := range dropList {
-								dropListArr = append(dropListArr, v.Value)
-							}
-							dd.SetDropList(dropListArr)
-							errTitle := ""
-							errMsg := fmt.Sprintf("应该为 %s 中之一", strings.Join(dropListArr, "、"))
-							dd.SetError(xlsx.StyleStop, &errTitle, &errMsg)
-							sheet.AddDataValidation(dd)
-						}
-					}
-				}
+// addValidations restricts the values of columns from row index firstRow on,
+// the first column being at column index offset
+func addValidations(book Spreadsheet, sheet, offset, firstRow int, columns []writeColumn, wc *WriteConfig) error {
+	validator, canValidate := book.(DropListValidator)
+	if !canValidate {
+		return nil
+	}
+	for i, col := range columns {
+		// add validation
+		t := col.typ
+		basicType := indirectType(t).Kind()
+
+		rowIndex := firstRow
+		colIndex := offset + i
+
+		if basicType == reflect.Bool {
+			var err error
+			if f, ok := wc.boolFormat(); ok {
+				err = validator.AddDropList(sheet, colIndex, rowIndex, []string{f.True, f.False}, t.Kind() == reflect.Ptr, f.message())
+			} else {
+				err = validator.AddDropList(sheet, colIndex, rowIndex, []string{"TRUE", "FALSE"}, t.Kind() == reflect.Ptr, "should be TRUE or FALSE")
+			}
+			if err != nil {
+				return err
 			}
 		}
-		// write header
-		write(sheet, header, wc)
-
-		if len(ts) > 0 {
-			// write data
-			for _, t := range ts {
-				data := make([]any, 0, numField)
-				for i := 0; i < numField; i++ {
-					v := reflect.ValueOf(t).Elem().Field(i)
-					if !v.CanInterface() {
-						continue
-					}
-					tag, have := reflect.TypeOf(t).Elem().Field(i).Tag.Lookup(wc.TagName)
-					if !have && wc.SkipNoTag {
-						continue
-					}
-
-					// add special data
-					if v.Kind() == reflect.Ptr {
-						if wc.SkipNilPointer && v.IsNil() {
-							data = append(data, "")
-							continue
-						} else if !v.IsNil() {
-							v = v.Elem()
-						}
-					}
-					if v.Kind() == reflect.Bool {
-						if wc.ChineseBool {
-							if v.Bool() {
-								data = append(data, "是")
-							} else {
-								data = append(data, "否")
-							}
-						} else {
-							data = append(data, v.Interface())
-						}
-						continue
-					}
-
-					if v.Kind() == reflect.String {
-						if haveDropList {
-							dropList, have := wc.DropListMap[tag]
-							if have {
-								key := v.String()
-								value := key
-								for _, v := range dropList {
-									if v.Key == key {
-										value = v.Value
-									}
-								}
-								data = append(data, value)
-								continue
-							}
-						}
-					}
-					data = append(data, v.Interface())
 
+		if basicType == reflect.String && wc.DropListMap != nil {
+			dropList, have := wc.DropListMap[col.tag]
+			if have {
+				dropListArr := make([]string, 0, len(dropList))
+				for _, v := range dropList {
+					dropListArr = append(dropListArr, v.Value)
+				}
+				errMsg := fmt.Sprintf("应该为 %s 中之一", strings.Join(dropListArr, "、"))
+				if err := validator.AddDropList(sheet, colIndex, rowIndex, dropListArr, t.Kind() == reflect.Ptr, errMsg); err != nil {
+					return err
 				}
-				write(sheet, data, wc)
 			}
 		}
 	}
+	return nil
+}
+
+// childColumns returns the columns of the elements of the children field of typ,
+// see the children tag option.
+func childColumns(typ reflect.Type, wc *WriteConfig) (int, []writeColumn) {
+	childIndex := childrenField(typ, wc.TagName)
+	if childIndex < 0 {
+		return -1, nil
+	}
+	elem := typ.Field(childIndex).Type.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return childIndex, writeColumns(elem, wc)
+}
+
+// sheetLayout describes the columns of a sheet written for a struct type
+type sheetLayout struct {
+	columns []writeColumn
+	// The children field and the columns of its elements, see the children tag option
+	childIndex int
+	children   []writeColumn
+	withLevel  bool
+	// The detail sheets and their key, see the sheet tag option
+	details   []detailField
+	keyIndex  int
+	keyHeader string
+	withKey   bool
+	levelName string
+}
+
+func newSheetLayout(typ reflect.Type, wc *WriteConfig) *sheetLayout {
+	l := &sheetLayout{columns: arrangeColumns(writeColumns(typ, wc), wc.ColumnLayout), levelName: wc.LevelColumn}
+	l.childIndex, l.children = childColumns(typ, wc)
+	l.withLevel = l.childIndex >= 0 && wc.LevelColumn != ""
+	l.details = detailFields(typ, wc.TagName)
+	l.keyIndex, l.keyHeader = keyField(typ, wc.TagName, wc.KeyColumn)
+	l.withKey = len(l.details) > 0 && l.keyIndex < 0
+	return l
+}
+
+// offset returns the number of columns preceding the struct field columns
+func (l *sheetLayout) offset() int {
+	n := 0
+	if l.withLevel {
+		n++
+	}
+	if l.withKey {
+		n++
+	}
+	return n
+}
+
+// header returns the header row of the sheet
+func (l *sheetLayout) header() []string {
+	header := make([]string, 0, l.offset()+len(l.columns)+len(l.children))
+	if l.withLevel {
+		header = append(header, l.levelName)
+	}
+	if l.withKey {
+		header = append(header, l.keyHeader)
+	}
+	for _, col := range l.columns {
+		if col.title != "" {
+			header = append(header, col.title)
+		} else {
+			header = append(header, col.header)
+		}
+	}
+	for _, col := range l.children {
+		header = append(header, col.header)
+	}
+	return header
 }
 
-// WriteExcel defines write [][]string to excel
+// write0 writes ts to a new sheet of book, below the preamble rows if any
+func write0[T WriteConfigurator](book Spreadsheet, ts []T, wc *WriteConfig, preamble [][]any) error {
+	sheets, err := writeSheets(book, ts, wc, preamble)
+	if err != nil {
+		return err
+	}
+	return writeHiddenSheets(book, wc, sheets)
+}
+
+// writeSheets writes ts to the sheets of book, without the hidden sheets of wc,
+// returning the number of records per sheet
+func writeSheets[T WriteConfigurator](book Spreadsheet, ts []T, wc *WriteConfig, preamble [][]any) ([]AuditSheet, error) {
+	router, err := newSheetRouter(book, reflect.TypeOf(new(T)).Elem().Elem(), wc, preamble)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range ts {
+		if err = router.write(reflect.ValueOf(t)); err != nil {
+			return nil, err
+		}
+	}
+	if err = router.finishSheets(); err != nil {
+		return nil, err
+	}
+	return router.auditSheets(), nil
+}
+
+// sheetRouter writes records to the sheets named by WriteConfig.SheetNameFunc
+type sheetRouter struct {
+	book     Spreadsheet
+	typ      reflect.Type
+	wc       *WriteConfig
+	preamble [][]any
+	sheets   map[string]*recordWriter
+	// The sheets in order of creation
+	writers []*recordWriter
+}
+
+// newSheetRouter returns a router for the records of type typ,
+// beginning the sheet of wc unless the sheets are named by SheetNameFunc
+func newSheetRouter(book Spreadsheet, typ reflect.Type, wc *WriteConfig, preamble [][]any) (*sheetRouter, error) {
+	r := &sheetRouter{book: book, typ: typ, wc: wc, preamble: preamble, sheets: map[string]*recordWriter{}}
+	if wc.SheetNameFunc == nil {
+		_, err := r.writer(wc.SheetName)
+		return r, err
+	}
+	if details := detailFields(typ, wc.TagName); len(details) > 0 {
+		return nil, fmt.Errorf("%w: detail sheet %s with SheetNameFunc", ErrUnsupported, details[0].sheet)
+	}
+	return r, nil
+}
+
+// writer returns the writer of the sheet named name, beginning the sheet if needed
+func (r *sheetRouter) writer(name string) (*recordWriter, error) {
+	if rw, ok := r.sheets[name]; ok {
+		return rw, nil
+	}
+	wc := r.wc
+	if wc.SheetNameFunc != nil {
+		sheetWC := *wc
+		sheetWC.SheetName = name
+		if sheetWC.SheetPosition >= 0 {
+			sheetWC.SheetPosition += len(r.writers)
+		}
+		sheetWC.ActiveSheet = wc.ActiveSheet && len(r.writers) == 0
+		wc = &sheetWC
+	}
+	rw, err := beginSheet(r.book, r.typ, wc, r.preamble)
+	if err != nil {
+		return nil, err
+	}
+	r.sheets[name] = rw
+	r.writers = append(r.writers, rw)
+	return rw, nil
+}
+
+// write writes the record pointed to by ptr to its sheet
+func (r *sheetRouter) write(ptr reflect.Value) error {
+	name := r.wc.SheetName
+	if r.wc.SheetNameFunc != nil {
+		if n := r.wc.SheetNameFunc(ptr.Interface()); strings.TrimSpace(n) != "" {
+			name = n
+		}
+	}
+	rw, err := r.writer(name)
+	if err != nil {
+		return err
+	}
+	return rw.write(ptr)
+}
+
+// count returns the number of records written to all sheets
+func (r *sheetRouter) count() int {
+	n := 0
+	for _, rw := range r.writers {
+		n += rw.count
+	}
+	return n
+}
+
+// finish finishes all sheets, followed by the audit, template version and signature sheets
+func (r *sheetRouter) finish() error {
+	if err := r.finishSheets(); err != nil {
+		return err
+	}
+	return writeHiddenSheets(r.book, r.wc, r.auditSheets())
+}
+
+// finishSheets finishes all sheets, writing the sheet of wc if no record was written
+func (r *sheetRouter) finishSheets() error {
+	if len(r.writers) == 0 {
+		if _, err := r.writer(r.wc.SheetName); err != nil {
+			return err
+		}
+	}
+	for _, rw := range r.writers {
+		if err := rw.finish(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditSheets returns the names and record counts of the sheets written
+func (r *sheetRouter) auditSheets() []AuditSheet {
+	sheets := make([]AuditSheet, 0, len(r.writers))
+	for _, rw := range r.writers {
+		sheets = append(sheets, AuditSheet{Name: rw.name, Records: rw.count})
+	}
+	return sheets
+}
+
+// writeHiddenSheets appends the audit, template version and signature sheets of wc to book,
+// the audit listing sheets
+func writeHiddenSheets(book Spreadsheet, wc *WriteConfig, sheets []AuditSheet) error {
+	if wc.Audit {
+		audit := newAudit(wc)
+		audit.Sheets = sheets
+		if err := writeAudit(book, audit); err != nil {
+			return err
+		}
+	}
+	if wc.TemplateVersion != "" {
+		if err := writeTemplateVersion(book, wc.TemplateVersion); err != nil {
+			return err
+		}
+	}
+	if wc.SigningKey != nil {
+		return writeSignature(book, wc.SigningKey)
+	}
+	return nil
+}
+
+// recordWriter writes the records of type typ to a sheet, see beginSheet
+type recordWriter struct {
+	book       Spreadsheet
+	sheet      int
+	name       string
+	wc         *WriteConfig
+	layout     *sheetLayout
+	sw         *sheetWriter
+	levelField int
+	grouped    bool
+	// The columns written as VLOOKUP formulas
+	lookups []lookupColumn
+	// The number of records written
+	count int
+	// The records and their keys for the detail sheets
+	records []reflect.Value
+	keys    []any
+}
+
+// beginSheet adds the sheet of wc to book and writes the preamble rows, e.g. metadata cells,
+// followed by the header row of typ
+func beginSheet(book Spreadsheet, typ reflect.Type, wc *WriteConfig, preamble [][]any) (*recordWriter, error) {
+	if err := wc.Validate(); err != nil {
+		return nil, err
+	}
+	if err := setDateSystem(book, wc.Date1904); err != nil {
+		return nil, err
+	}
+	name, err := expandTemplate(wc.SheetName, wc.TemplateData)
+	if err != nil {
+		return nil, err
+	}
+	if name, err = newSheetName(book, name, wc.StrictSheetNames); err != nil {
+		return nil, err
+	}
+	sheet, err := book.AddSheet(name)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := newSheetLayout(typ, wc)
+	if err = checkColumnLayout(layout, wc); err != nil {
+		return nil, err
+	}
+	header := make([]any, 0, len(layout.columns)+len(layout.children)+2)
+	for _, h := range layout.header() {
+		if h, err = expandTemplate(h, wc.TemplateData); err != nil {
+			return nil, err
+		}
+		header = append(header, h)
+	}
+	offset, firstRow := layout.offset(), len(preamble)+1
+	if err = addValidations(book, sheet, offset, firstRow, layout.columns, wc); err != nil {
+		return nil, err
+	}
+	if err = addValidations(book, sheet, offset+len(layout.columns), firstRow, layout.children, wc); err != nil {
+		return nil, err
+	}
+	if err = setColumnWidths(book, sheet, offset, layout.columns); err != nil {
+		return nil, err
+	}
+	sw := newSheetWriter(book, sheet, wc)
+	for _, data := range preamble {
+		if err = sw.append(data, 0); err != nil {
+			return nil, err
+		}
+	}
+	sw.top = sw.rows
+	lookups, err := lookupColumns(book, layout)
+	if err != nil {
+		return nil, err
+	}
+	// write header
+	if sw.footer, err = newFooterTracker(layout, wc); err != nil {
+		return nil, err
+	}
+	sw.footer.setTop(sw.top)
+	if err = sw.append(header, 0); err != nil {
+		return nil, err
+	}
+	return &recordWriter{book: book, sheet: sheet, name: name, wc: wc, layout: layout, sw: sw, levelField: outlineField(typ, wc), lookups: lookups}, nil
+}
+
+// write writes the record pointed to by ptr, followed by its children
+func (rw *recordWriter) write(ptr reflect.Value) error {
+	wc, layout := rw.wc, rw.layout
+	columns, children := layout.columns, layout.children
+	rw.count++
+	rv := ptr.Elem()
+	data := make([]any, 0, len(columns)+len(children)+2)
+	if layout.withLevel {
+		data = append(data, 0)
+	}
+	if layout.withKey {
+		data = append(data, rw.count)
+	}
+	values, err := recordValues(rv, columns, wc)
+	if err != nil {
+		return err
+	}
+	data = append(data, values...)
+	for _, l := range rw.lookups {
+		// The value of the record is kept as cached value of the formula
+		cell := NewCell(data[l.col])
+		cell.Formula = l.formula(rw.sw.rows)
+		data[l.col] = cell
+	}
+	if len(layout.details) > 0 {
+		rw.records = append(rw.records, rv)
+		if layout.withKey {
+			rw.keys = append(rw.keys, rw.count)
+		} else {
+			key, err := columnValue(rv.Field(layout.keyIndex), writeColumn{header: layout.keyHeader, tag: layout.keyHeader}, wc)
+			if err != nil {
+				return err
+			}
+			rw.keys = append(rw.keys, key)
+		}
+	}
+	level := outlineLevel(ptr, rw.levelField, wc)
+	rw.grouped = rw.grouped || level > 0
+	if err = rw.sw.append(data, level); err != nil {
+		return err
+	}
+	if layout.childIndex < 0 {
+		return nil
+	}
+
+	// write children indented below the parent row
+	items := rv.Field(layout.childIndex)
+	for i := 0; i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+		if !item.IsValid() {
+			continue
+		}
+		data = data[:0]
+		if layout.withLevel {
+			data = append(data, 1)
+		}
+		if layout.withKey {
+			data = append(data, "")
+		}
+		for range columns {
+			data = append(data, "")
+		}
+		values, err := recordValues(item, children, wc)
+		if err != nil {
+			return err
+		}
+		data = append(data, values...)
+		rw.grouped = true
+		if err = rw.sw.append(data, level+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finish writes the footer and the detail sheets, and arranges the sheet
+func (rw *recordWriter) finish() error {
+	rw.sw.countRows()
+	if err := rw.sw.appendFooter(); err != nil {
+		return err
+	}
+	if err := writeDetails(rw.book, rw.records, rw.keys, rw.layout.details, rw.layout.keyHeader, rw.wc); err != nil {
+		return err
+	}
+	if rw.grouped && !rw.wc.GroupSummaryBelow {
+		grouper, ok := rw.book.(RowGrouper)
+		if !ok {
+			return ErrUnsupported
+		}
+		if err := grouper.SetGroupSummaryBelow(rw.sheet, false); err != nil {
+			return err
+		}
+	}
+	if err := setCompression(rw.book, rw.wc); err != nil {
+		return err
+	}
+	return arrangeSheet(rw.book, rw.sheet, rw.wc)
+}
+
+// setCompression applies the zip options of wc
+func setCompression(book Spreadsheet, wc *WriteConfig) error {
+	if wc.CompressionLevel == 0 && len(wc.StoredParts) == 0 {
+		return nil
+	}
+	compressor, ok := book.(Compressor)
+	if !ok {
+		return ErrUnsupported
+	}
+	return compressor.SetCompression(wc.CompressionLevel, wc.StoredParts)
+}
+
+// arrangeSheet applies the sheet tab options of wc,
+// it has to be called after the sheet is completely written,
+// as moving the sheet changes its index.
+func arrangeSheet(book Spreadsheet, sheet int, wc *WriteConfig) error {
+	if wc.TabColor == "" && !wc.ActiveSheet && wc.SheetPosition < 0 {
+		return nil
+	}
+	arranger, ok := book.(SheetArranger)
+	if !ok {
+		return ErrUnsupported
+	}
+	if wc.TabColor != "" {
+		if err := arranger.SetTabColor(sheet, wc.TabColor); err != nil {
+			return err
+		}
+	}
+	if wc.ActiveSheet {
+		if err := arranger.SetActiveSheet(sheet); err != nil {
+			return err
+		}
+	}
+	if wc.SheetPosition >= 0 && wc.SheetPosition != sheet {
+		position := wc.SheetPosition
+		if n := len(book.Sheets()); position >= n {
+			position = n - 1
+		}
+		return arranger.MoveSheet(sheet, position)
+	}
+	return nil
+}
+
+// WriteExcelTo defines write [][]string to excel
 //
-// params: file, excel file pull path
+// params: w, the dist writer
 //
 // params: data, write data to excel
-func WriteExcel(file string, data [][]string) error {
+func WriteExcelTo(w io.Writer, data [][]string) error {
 	f := xlsx.NewFile()
 	writeExcel0(f, data)
-	return f.Save(file)
+	return f.Write(w)
 }
 
-// WriteExcelTo defines write [][]string to excel
+func writeExcel0(f *xlsx.File, data [][]string) {
+	sheet, _ := f.AddSheet("Sheet1")
+	for _, row := range data {
+		r := &Row{Cells: make([]Cell, len(row))}
+		for j, cell := range row {
+			r.Cells[j] = StringCell(cell)
+		}
+		appendXLSXRow(sheet, r)
+	}
+}
+
+// WriteExcelAnyTo defines write [][]any to excel,
+// numbers, booleans and times are written as typed cells.
 //
 // params: w, the dist writer
 //
 // params: data, write data to excel
-func WriteExcelTo(w io.Writer, data [][]string) error {
+//
+// params: header, optional header row written above data
+func WriteExcelAnyTo(w io.Writer, data [][]any, header ...string) error {
 	f := xlsx.NewFile()
-	writeExcel0(f, data)
+	writeExcelAny0(f, data, header)
 	return f.Write(w)
 }
 
-func writeExcel0(f *xlsx.File, data [][]string) {
+func writeExcelAny0(f *xlsx.File, data [][]any, header []string) {
 	sheet, _ := f.AddSheet("Sheet1")
+	wc := defaultWriteConfig()
+	if len(header) > 0 {
+		r := &Row{Cells: make([]Cell, len(header))}
+		for j, cell := range header {
+			r.Cells[j] = StringCell(cell)
+		}
+		appendXLSXRow(sheet, r)
+	}
 	for _, row := range data {
-		r := sheet.AddRow()
-		for _, cell := range row {
-			r.AddCell().SetString(cell)
+		values := make([]any, len(row))
+		for j, cell := range row {
+			// write the value pointed to, nil pointers as empty cells
+			if v := reflect.ValueOf(cell); v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					cell = nil
+				} else {
+					cell = v.Elem().Interface()
+				}
+			}
+			values[j] = cell
 		}
+		appendXLSXRow(sheet, newRow(values, wc))
 	}
 }
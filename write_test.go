@@ -12,8 +12,19 @@
 package exl
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
 )
 
 type writeTmp struct {
@@ -169,3 +180,545 @@ func TestWriteExcelTo(t *testing.T) {
 		}
 	}
 }
+
+type outlineTmp struct {
+	Name  string `excel:"Name"`
+	Level int    `excel:"-,outline"`
+}
+
+func (*outlineTmp) WriteConfigure(_ *WriteConfig) {}
+
+type outlineFuncTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*outlineFuncTmp) WriteConfigure(wc *WriteConfig) {
+	wc.GroupSummaryBelow = true
+	wc.GroupLevelFunc = func(record any) uint8 {
+		return uint8(len(record.(*outlineFuncTmp).Name) - 1)
+	}
+}
+
+func TestWriteOutline(t *testing.T) {
+	t.Run("outline tag", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, []*outlineTmp{{"Assets", 0}, {"Cash", 1}, {"Bank", 2}, {"Debts", 0}}); err != nil {
+			t.Fatal(err)
+		}
+		f, err := xlsx.OpenBinary(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sheet := f.Sheets[0]
+		equal(t, 1, sheet.MaxCol)
+		levels := make([]uint8, 0)
+		for i := 1; i < sheet.MaxRow; i++ {
+			row, _ := sheet.Row(i)
+			levels = append(levels, row.GetOutlineLevel())
+		}
+		equal(t, []uint8{0, 1, 2, 0}, levels)
+		if !strings.Contains(zipParts(t, buf.Bytes())["xl/worksheets/sheet1.xml"], `<outlinePr summaryBelow="0"/>`) {
+			t.Error("test failed: missing outline summary position")
+		}
+	})
+	t.Run("outline func", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, []*outlineFuncTmp{{"a"}, {"bb"}}); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		row, _ := f.Sheets[0].Row(2)
+		equal(t, uint8(1), row.GetOutlineLevel())
+		if strings.Contains(zipParts(t, buf.Bytes())["xl/worksheets/sheet1.xml"], "outlinePr") {
+			t.Error("test failed: summary below is the default and needs no outlinePr")
+		}
+	})
+}
+
+type orderItemTmp struct {
+	SKU string `excel:"SKU"`
+	Qty int    `excel:"Qty"`
+}
+
+type orderTmp struct {
+	ID    string          `excel:"Order"`
+	Items []*orderItemTmp `excel:",children"`
+}
+
+func (*orderTmp) WriteConfigure(_ *WriteConfig) {}
+func (*orderTmp) ReadConfigure(_ *ReadConfig)   {}
+
+type orderLevelTmp orderTmp
+
+func (*orderLevelTmp) WriteConfigure(wc *WriteConfig) { wc.LevelColumn = "Level" }
+func (*orderLevelTmp) ReadConfigure(rc *ReadConfig)   { rc.LevelColumn = "Level" }
+
+func TestWriteChildren(t *testing.T) {
+	orders := []*orderTmp{
+		{ID: "A1", Items: []*orderItemTmp{{"apple", 2}, {"pear", 1}}},
+		{ID: "A2"},
+		{ID: "A3", Items: []*orderItemTmp{{"plum", 5}}},
+	}
+	t.Run("indented", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := WriteTo(buf, orders); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		sheet := f.Sheets[0]
+		equal(t, 7, sheet.MaxRow)
+		header, _ := sheet.Row(0)
+		equal(t, "SKU", header.GetCell(1).Value)
+		child, _ := sheet.Row(2)
+		equal(t, "", child.GetCell(0).Value)
+		equal(t, "apple", child.GetCell(1).Value)
+		equal(t, uint8(1), child.GetOutlineLevel())
+
+		read, err := Read[*orderTmp](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, orders, read)
+	})
+	t.Run("level column", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		leveled := make([]*orderLevelTmp, len(orders))
+		for i, o := range orders {
+			leveled[i] = (*orderLevelTmp)(o)
+		}
+		if err := WriteTo(buf, leveled); err != nil {
+			t.Fatal(err)
+		}
+		f, _ := xlsx.OpenBinary(buf.Bytes())
+		child, _ := f.Sheets[0].Row(2)
+		equal(t, "1", child.GetCell(0).Value)
+
+		read, err := Read[*orderLevelTmp](buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		equal(t, leveled, read)
+	})
+	t.Run("orphan child", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_ = WriteExcelTo(buf, [][]string{{"Order", "SKU", "Qty"}, {"", "apple", "1"}})
+		_, err := Read[*orderTmp](buf)
+		if !errors.Is(err, ErrOrphanChildRow) {
+			t.Errorf("expected ErrOrphanChildRow, got %v", err)
+		}
+	})
+}
+
+func TestWriteExcelAnyTo(t *testing.T) {
+	buf := &bytes.Buffer{}
+	day := time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)
+	name := "pear"
+	err := WriteExcelAnyTo(buf, [][]any{{"apple", 1.5, 3, true, day}, {&name, nil, (*int)(nil), false, time.Time{}}}, "Name", "Price", "Qty", "Fresh", "Day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet := f.Sheets[0]
+	equal(t, 3, sheet.MaxRow)
+	header, _ := sheet.Row(0)
+	equal(t, "Price", header.GetCell(1).Value)
+	row, _ := sheet.Row(1)
+	equal(t, xlsx.CellTypeNumeric, row.GetCell(1).Type())
+	equal(t, "1.5", row.GetCell(1).Value)
+	equal(t, xlsx.CellTypeBool, row.GetCell(3).Type())
+	got, _ := row.GetCell(4).GetTime(false)
+	equal(t, day, got)
+	row, _ = sheet.Row(2)
+	equal(t, "pear", row.GetCell(0).Value)
+	equal(t, "", row.GetCell(2).Value)
+
+	if err = WriteExcelAny("", nil); err == nil {
+		t.Error("test failed: expected error saving to empty path")
+	}
+}
+
+type injectionTmp struct {
+	Comment string  `excel:"Comment"`
+	Amount  float64 `excel:"Amount"`
+}
+
+func (*injectionTmp) WriteConfigure(wc *WriteConfig) { wc.EscapeFormulaInjection = true }
+
+func TestWriteEscapeFormulaInjection(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteTo(buf, []*injectionTmp{{`=HYPERLINK("http://x","y")`, -1}, {"@SUM(A1)", 2}, {"+1", 3}, {"\tx", 4}, {"fine - ok", 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	var comments []string
+	for i := 1; i < f.Sheets[0].MaxRow; i++ {
+		row, _ := f.Sheets[0].Row(i)
+		comments = append(comments, row.GetCell(0).Value)
+	}
+	equal(t, []string{`'=HYPERLINK("http://x","y")`, "'@SUM(A1)", "'+1", "'\tx", "fine - ok"}, comments)
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "-1", row.GetCell(1).Value)
+}
+
+type largeIntTmp struct {
+	ID    int64  `excel:"ID"`
+	Code  uint64 `excel:"Code"`
+	Phone int    `excel:"Phone,text"`
+}
+
+func (*largeIntTmp) WriteConfigure(wc *WriteConfig) { wc.LargeIntsAsText = true }
+func (*largeIntTmp) ReadConfigure(_ *ReadConfig)    {}
+
+func TestWriteLargeInts(t *testing.T) {
+	ts := []*largeIntTmp{{1234567890123456789, 42, 5551234}, {-999999999999999, 18446744073709551615, 0}}
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, ts); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, xlsx.CellTypeString, row.GetCell(0).Type())
+	equal(t, "1234567890123456789", row.GetCell(0).Value)
+	equal(t, xlsx.CellTypeNumeric, row.GetCell(1).Type())
+	equal(t, xlsx.CellTypeString, row.GetCell(2).Type())
+	equal(t, "@", row.GetCell(2).NumFmt)
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, xlsx.CellTypeNumeric, row.GetCell(0).Type())
+
+	read, err := Read[*largeIntTmp](buf)
+	equal(t, nil, err)
+	equal(t, ts, read)
+}
+
+type leadingZeroTmp struct {
+	Code string `excel:"Code,text"`
+}
+
+func (*leadingZeroTmp) WriteConfigure(_ *WriteConfig) {}
+func (*leadingZeroTmp) ReadConfigure(_ *ReadConfig)   {}
+
+func TestWriteLeadingZeros(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*leadingZeroTmp{{"00123"}}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "@", row.GetCell(0).NumFmt)
+	ts, err := Read[*leadingZeroTmp](buf)
+	equal(t, nil, err)
+	equal(t, []*leadingZeroTmp{{"00123"}}, ts)
+}
+
+type fieldMarshalerTmp struct {
+	Email string   `excel:"Email"`
+	Size  [2]int   `excel:"Size"`
+	Phone *string  `excel:"Phone"`
+	Tags  []string `excel:"Tags"`
+}
+
+var errNoTags = errors.New("no tags")
+
+func (*fieldMarshalerTmp) WriteConfigure(wc *WriteConfig) {
+	mask := func(v reflect.Value) (Cell, error) {
+		s := v.String()
+		return StringCell(s[:1] + strings.Repeat("*", len(s)-1)), nil
+	}
+	wc.FieldMarshalers = map[string]MarshalExcelFunc{
+		"Email": mask,
+		"Phone": mask,
+		"Size": func(v reflect.Value) (Cell, error) {
+			return StringCell(fmt.Sprintf("%dx%d", v.Index(0).Int(), v.Index(1).Int())), nil
+		},
+		"Tags": func(v reflect.Value) (Cell, error) {
+			if v.Len() == 0 {
+				return Cell{}, errNoTags
+			}
+			return StringCell(strings.Join(v.Interface().([]string), ", ")), nil
+		},
+	}
+}
+
+func TestWriteFieldMarshalers(t *testing.T) {
+	phone := "5551234"
+	buf := &bytes.Buffer{}
+	if err := WriteTo(buf, []*fieldMarshalerTmp{{"ann@example.com", [2]int{3, 4}, &phone, []string{"a", "b"}}, {Email: "bob", Tags: []string{"c"}}}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := xlsx.OpenBinary(buf.Bytes())
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, []string{"a**************", "3x4", "5******", "a, b"}, []string{row.GetCell(0).Value, row.GetCell(1).Value, row.GetCell(2).Value, row.GetCell(3).Value})
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, "", row.GetCell(2).Value)
+
+	err := WriteTo(&bytes.Buffer{}, []*fieldMarshalerTmp{{Email: "x"}})
+	equal(t, true, errors.Is(err, errNoTags))
+}
+
+type fastCompressionTmp struct {
+	Name string `excel:"Name"`
+	Qty  int    `excel:"Qty"`
+}
+
+func (*fastCompressionTmp) WriteConfigure(wc *WriteConfig) { wc.CompressionLevel = 1 }
+
+func (*fastCompressionTmp) ReadConfigure(*ReadConfig) {}
+
+type storedPartsTmp fastCompressionTmp
+
+func (*storedPartsTmp) WriteConfigure(wc *WriteConfig) {
+	wc.StoredParts = []string{"xl/worksheets/*.xml"}
+}
+
+func TestWriteCompression(t *testing.T) {
+	fast := make([]*fastCompressionTmp, 0, 2000)
+	stored := make([]*storedPartsTmp, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		fast = append(fast, &fastCompressionTmp{fmt.Sprintf("item %d", i%7), i})
+		stored = append(stored, &storedPartsTmp{fmt.Sprintf("item %d", i%7), i})
+	}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, fast))
+	ts, err := ReadBinary[*fastCompressionTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, fast, ts)
+
+	buf.Reset()
+	equal(t, nil, WriteTo(buf, stored))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	equal(t, nil, err)
+	methods := map[string]uint16{}
+	for _, f := range zr.File {
+		methods[f.Name] = f.Method
+	}
+	equal(t, zip.Store, methods["xl/worksheets/sheet1.xml"])
+	equal(t, zip.Deflate, methods["xl/workbook.xml"])
+}
+
+type boolFormatTmp struct {
+	Paid   bool  `excel:"Paid"`
+	Signed *bool `excel:"Signed"`
+}
+
+func (*boolFormatTmp) WriteConfigure(wc *WriteConfig) {
+	wc.BoolFormat = BoolFormat{True: "Yes", False: "No"}
+	wc.ChineseBool = true
+}
+
+func (*boolFormatTmp) ReadConfigure(rc *ReadConfig) {
+	rc.BoolFormat = BoolFormat{True: "Yes", False: "No"}
+}
+
+func TestWriteBoolFormat(t *testing.T) {
+	yes := true
+	ts := []*boolFormatTmp{{true, &yes}, {false, nil}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "Yes", row.GetCell(0).Value)
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, "No", row.GetCell(0).Value)
+
+	read, err := ReadBinary[*boolFormatTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, true, read[0].Paid && *read[0].Signed)
+	equal(t, false, read[1].Paid)
+
+	plan, err := PlanWrite(ts, nil)
+	equal(t, nil, err)
+	equal(t, []string{"Yes", "No"}, plan.Sheets[0].Validations[0].Values)
+	equal(t, true, plan.Sheets[0].Validations[1].AllowBlank)
+}
+
+type nilPlaceholderTmp struct {
+	Name  *string  `excel:"Name"`
+	Score *float64 `excel:"Score,nilas=-"`
+}
+
+func (*nilPlaceholderTmp) WriteConfigure(wc *WriteConfig) { wc.NilPlaceholder = "N/A" }
+
+func (*nilPlaceholderTmp) ReadConfigure(rc *ReadConfig) { rc.NilPlaceholder = "N/A" }
+
+func TestWriteNilPlaceholder(t *testing.T) {
+	name, score := "", 1.5
+	ts := []*nilPlaceholderTmp{{nil, nil}, {&name, &score}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, "N/A", row.GetCell(0).Value)
+	equal(t, "-", row.GetCell(1).Value)
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, "", row.GetCell(0).Value)
+
+	read, err := ReadBinary[*nilPlaceholderTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, ts, read)
+}
+
+type omitZeroTmp struct {
+	Name  string   `excel:"Name,omitzero"`
+	Qty   int      `excel:"Qty,omitzero"`
+	Price *float64 `excel:"Price,omitzero"`
+	Count int      `excel:"Count"`
+}
+
+func (*omitZeroTmp) WriteConfigure(*WriteConfig) {}
+
+func (*omitZeroTmp) ReadConfigure(*ReadConfig) {}
+
+func TestWriteOmitZero(t *testing.T) {
+	price := 0.0
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, []*omitZeroTmp{{"a", 0, &price, 0}, {"", 2, nil, 3}}))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	row, _ := f.Sheets[0].Row(1)
+	equal(t, []string{"a", "", "", "0"}, []string{row.GetCell(0).Value, row.GetCell(1).Value, row.GetCell(2).Value, row.GetCell(3).Value})
+	row, _ = f.Sheets[0].Row(2)
+	equal(t, []string{"", "2", "", "3"}, []string{row.GetCell(0).Value, row.GetCell(1).Value, row.GetCell(2).Value, row.GetCell(3).Value})
+}
+
+type date1904Tmp struct {
+	Day     time.Time  `excel:"Day"`
+	Created *time.Time `excel:"Created"`
+}
+
+func (*date1904Tmp) WriteConfigure(wc *WriteConfig) { wc.Date1904 = true }
+
+func (*date1904Tmp) ReadConfigure(*ReadConfig) {}
+
+func TestWriteDate1904(t *testing.T) {
+	day := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	ts := []*date1904Tmp{{day, &created}}
+	buf := &bytes.Buffer{}
+	equal(t, nil, WriteTo(buf, ts))
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	equal(t, nil, err)
+	equal(t, true, f.Date1904)
+	cell, _ := f.Sheets[0].Cell(1, 0)
+	equal(t, fmt.Sprint(xlsx.TimeToExcelTime(day, true)), cell.Value)
+
+	read, err := ReadBinary[*date1904Tmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, ts, read)
+
+	// The sheets of a workbook share the date system
+	w := NewWriter()
+	equal(t, nil, WriteSheet(w, ts))
+	equal(t, ErrDateSystemMismatch, WriteSheet(w, []*pageTmp{{1, "a"}}))
+	GoWriteSheet(w, []*pageTmp{{1, "a"}})
+	equal(t, ErrDateSystemMismatch, w.Wait())
+
+	plan, err := PlanWrite(ts, nil)
+	equal(t, nil, err)
+	equal(t, true, plan.Date1904)
+}
+
+type atomicTmp struct {
+	Name string `excel:"Name"`
+}
+
+func (*atomicTmp) WriteConfigure(wc *WriteConfig) { wc.AtomicWrite = true }
+
+type keepFileTmp atomicTmp
+
+func (*keepFileTmp) WriteConfigure(wc *WriteConfig) { wc.Overwrite = OverwriteError }
+
+type atomicKeepTmp atomicTmp
+
+func (*atomicKeepTmp) WriteConfigure(wc *WriteConfig) {
+	wc.AtomicWrite = true
+	wc.Overwrite = OverwriteError
+}
+
+type backupFileTmp atomicTmp
+
+func (*backupFileTmp) WriteConfigure(wc *WriteConfig) {
+	wc.AtomicWrite = true
+	wc.Overwrite = OverwriteBackup
+}
+
+func TestWriteFileOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "out.xlsx")
+	equal(t, nil, WriteFile(file, []*atomicTmp{{"first"}}))
+	equal(t, nil, WriteFile(file, []*atomicTmp{{"second"}}))
+	data, _ := os.ReadFile(file)
+	equal(t, "second", firstCell(t, data))
+
+	err := WriteFile(file, []*keepFileTmp{{"third"}})
+	equal(t, true, errors.Is(err, fs.ErrExist))
+
+	equal(t, nil, WriteFile(file, []*backupFileTmp{{"fourth"}}))
+	previous, _ := os.ReadFile(file + ".bak")
+	equal(t, "second", firstCell(t, previous))
+	current, _ := os.ReadFile(file)
+	equal(t, "fourth", firstCell(t, current))
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	equal(t, 2, len(entries))
+
+	equal(t, nil, WriteFile(filepath.Join(dir, "new.xlsx"), []*keepFileTmp{{"new"}}))
+	err = WriteFile(file, []*atomicKeepTmp{{"fifth"}})
+	equal(t, true, errors.Is(err, fs.ErrExist))
+	equal(t, nil, WriteFile(filepath.Join(dir, "atomic.xlsx"), []*atomicKeepTmp{{"atomic"}}))
+	data, _ = os.ReadFile(filepath.Join(dir, "atomic.xlsx"))
+	equal(t, "atomic", firstCell(t, data))
+	current, _ = os.ReadFile(file)
+	equal(t, "fourth", firstCell(t, current))
+	entries, _ = os.ReadDir(dir)
+	equal(t, 4, len(entries))
+	err = WriteFile(filepath.Join(dir, "missing", "out.xlsx"), []*atomicTmp{{"x"}})
+	equal(t, true, errors.Is(err, fs.ErrNotExist))
+}
+
+func firstCell(t *testing.T, data []byte) string {
+	rows, err := Preview(bytes.NewReader(data), 0, 2)
+	equal(t, nil, err)
+	return rows[1][0]
+}
+
+type includeColumnsTmp struct {
+	ID    int    `excel:"ID"`
+	Name  string `excel:"Name"`
+	Cost  int    `excel:"Cost"`
+	Notes string
+}
+
+func (*includeColumnsTmp) WriteConfigure(*WriteConfig) {}
+
+func TestWriteIncludeColumns(t *testing.T) {
+	ts := []*includeColumnsTmp{{1, "a", 10, "internal"}}
+	headers := func(wc *WriteConfig) []string {
+		buf := &bytes.Buffer{}
+		equal(t, nil, WriteToConfig(buf, ts, wc))
+		f, err := xlsx.OpenBinary(buf.Bytes())
+		equal(t, nil, err)
+		row, _ := f.Sheets[0].Row(0)
+		var hs []string
+		_ = row.ForEachCell(func(c *xlsx.Cell) error {
+			hs = append(hs, c.Value)
+			return nil
+		})
+		return hs
+	}
+	equal(t, []string{"ID", "Name", "Cost", "Notes"}, headers(nil))
+
+	wc := newWriteConfig[*includeColumnsTmp]()
+	wc.ExcludeColumns = []string{"Cost", "Notes"}
+	equal(t, []string{"ID", "Name"}, headers(wc))
+
+	wc = newWriteConfig[*includeColumnsTmp]()
+	wc.IncludeColumns = []string{"Name", "Cost"}
+	wc.ExcludeColumns = []string{"Cost"}
+	equal(t, []string{"Name"}, headers(wc))
+}
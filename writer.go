@@ -22,25 +22,150 @@ import (
 
 // Writer define a writer for exl
 type Writer struct {
-	file      *xlsx.File
+	book      *xlsxSpreadsheet
+	options   []xlsx.FileOption
 	mapHeader []reflect.Value
 	ignore    map[int]struct{}
+	// Sheets built by GoWriteSheet, in call order
+	pending []*sheetJob
+}
+
+// sheetJob is a sheet built in a goroutine of its own, see GoWriteSheet
+type sheetJob struct {
+	done chan struct{}
+	wc   *WriteConfig
+	book *xlsxSpreadsheet
+	// The records per sheet of book, for the audit sheet
+	sheets []AuditSheet
+	err    error
 }
 
 // NewWriter returns new exl writer
 func NewWriter(options ...xlsx.FileOption) *Writer {
-	w := &Writer{file: xlsx.NewFile(options...)}
+	book := XLSXBackend{Options: options}.Create().(*xlsxSpreadsheet)
+	w := &Writer{book: book, options: options}
 	w.reset()
 	return w
 }
 
-// Write or append the param data into sheet
+// WriteSheet writes ts as a new sheet of the writer, configured by the WriteConfig of T.
+// Unlike WriteFile, the WriteConfig.Backend is ignored and the sheet options
+// like WriteConfig.SheetPosition refer to the sheets already written.
+func WriteSheet[T WriteConfigurator](w *Writer, ts []T) error {
+	if err := w.Wait(); err != nil {
+		return err
+	}
+	return write0(w.book, ts, newWriteConfig[T](), nil)
+}
+
+// GoWriteSheet builds the sheet of ts like WriteSheet, but in a goroutine of its own,
+// so the sheets of large workbooks are built in parallel.
+// The sheets are added to the workbook in call order by Wait,
+// which is called by all other methods of the writer.
+// ts must not be modified until then, and the Writer itself must not be used concurrently.
+func GoWriteSheet[T WriteConfigurator](w *Writer, ts []T) {
+	wc := newWriteConfig[T]()
+	job := &sheetJob{done: make(chan struct{}), wc: wc}
+	w.pending = append(w.pending, job)
+	// The sheet options and hidden sheets are applied once the sheet is added to the workbook
+	buildWC := *wc
+	buildWC.TabColor, buildWC.SheetPosition, buildWC.ActiveSheet = "", -1, false
+	book := XLSXBackend{Options: w.options}.Create().(*xlsxSpreadsheet)
+	go func() {
+		defer close(job.done)
+		if job.sheets, job.err = writeSheets(book, ts, &buildWC, nil); job.err == nil {
+			job.book = book
+		}
+	}()
+}
+
+// Wait waits for the sheets built by GoWriteSheet and adds them to the workbook in call order.
+// It returns the first error of building or adding a sheet, the sheets following it are discarded.
+func (w *Writer) Wait() error {
+	pending := w.pending
+	w.pending = nil
+	var firstErr error
+	for _, job := range pending {
+		<-job.done
+		if firstErr != nil {
+			continue
+		}
+		if firstErr = job.err; firstErr == nil {
+			firstErr = w.addSheets(job)
+		}
+	}
+	return firstErr
+}
+
+// addSheets appends the sheets of a job to the workbook and applies its sheet options
+func (w *Writer) addSheets(job *sheetJob) error {
+	first := len(w.book.file.Sheets)
+	if err := setDateSystem(w.book, job.wc.Date1904); err != nil {
+		return err
+	}
+	names := map[string]string{}
+	for _, sheet := range job.book.file.Sheets {
+		name, err := newSheetName(w.book, sheet.Name, job.wc.StrictSheetNames)
+		if err != nil {
+			return err
+		}
+		names[sheet.Name] = name
+		if _, err = w.book.file.AppendSheet(*sheet, name); err != nil {
+			return err
+		}
+		if job.book.summaryAbove[sheet] {
+			if err = w.book.SetGroupSummaryBelow(len(w.book.file.Sheets)-1, false); err != nil {
+				return err
+			}
+		}
+	}
+	if err := arrangeSheet(w.book, first, job.wc); err != nil {
+		return err
+	}
+	for i := range job.sheets {
+		job.sheets[i].Name = names[job.sheets[i].Name]
+	}
+	return writeHiddenSheets(w.book, job.wc, job.sheets)
+}
+
+// SetTabColor colors the tab of the sheet, rgb is a hex color like "FF0000"
+func (w *Writer) SetTabColor(sheet string, rgb string) error {
+	return w.arrange(sheet, func(index int) error { return w.book.SetTabColor(index, rgb) })
+}
+
+// MoveSheet moves the sheet to the 0-based position
+func (w *Writer) MoveSheet(sheet string, position int) error {
+	return w.arrange(sheet, func(index int) error { return w.book.MoveSheet(index, position) })
+}
+
+// SetActiveSheet selects the sheet shown when the file is opened
+func (w *Writer) SetActiveSheet(sheet string) error {
+	return w.arrange(sheet, w.book.SetActiveSheet)
+}
+
+func (w *Writer) arrange(sheet string, fn func(index int) error) error {
+	if err := w.Wait(); err != nil {
+		return err
+	}
+	for i, name := range w.book.Sheets() {
+		if name == sheet {
+			return fn(i)
+		}
+	}
+	return ErrSheetNotFound
+}
+
+// Write or append the param data into sheet, see SanitizeSheetName
 func (w *Writer) Write(sheet string, data any) error {
-	if sht, ok := w.file.Sheet[sheet]; ok {
+	if err := w.Wait(); err != nil {
+		return err
+	}
+	sheet = SanitizeSheetName(sheet)
+	if sht, ok := w.book.file.Sheet[sheet]; ok {
 		w.reset()
 		return w.writeSheet(sht, data)
 	}
-	if sht, err := w.file.AddSheet(sheet); err != nil {
+	if sht, err := w.book.file.AddSheet(sheet); err != nil {
 		return err
 	} else {
 		w.reset()
@@ -48,11 +173,15 @@ func (w *Writer) Write(sheet string, data any) error {
 	}
 }
 
-// SaveTo the buffered binary into dist file
-func (w *Writer) SaveTo(path string) (err error) { return w.file.Save(path) }
-
 // WriteTo the buffered binary into new writer
-func (w *Writer) WriteTo(dw io.Writer) (n int, err error) { return 0, w.file.Write(dw) }
+func (w *Writer) WriteTo(dw io.Writer) (n int, err error) {
+	if err = w.Wait(); err != nil {
+		return 0, err
+	}
+	cw := &countingWriter{w: dw}
+	err = w.book.Save(cw)
+	return cw.n, err
+}
 
 func (w *Writer) writeSheet(sheet *xlsx.Sheet, data any) (err error) {
 	value := w.deepValue(reflect.ValueOf(data))
@@ -152,6 +281,17 @@ func (w *Writer) deepValue(value reflect.Value) reflect.Value {
 	return value
 }
 
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
+
 func (w *Writer) addCell(row *xlsx.Row, value reflect.Value) {
 	if value.CanInterface() {
 		row.AddCell().SetValue(value.Interface())
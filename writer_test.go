@@ -12,9 +12,16 @@
 package exl
 
 import (
+	"archive/zip"
 	"bytes"
+	"errors"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/tealeg/xlsx/v3"
 )
 
 func TestWriter(t *testing.T) {
@@ -56,3 +63,160 @@ func TestWriter(t *testing.T) {
 	_ = w.SaveTo("out.xlsx")
 	_, _ = w.WriteTo(&bytes.Buffer{})
 }
+
+type tabTmp struct {
+	ID int `excel:"ID"`
+}
+
+func (*tabTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "Tab"
+	wc.TabColor = "#00ff00"
+	wc.SheetPosition = 0
+	wc.ActiveSheet = true
+}
+
+func TestWriterArrangeSheets(t *testing.T) {
+	w := NewWriter()
+	_ = w.Write("first", []int{1})
+	_ = w.Write("second", []int{2})
+	if err := WriteSheet(w, []*tabTmp{{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetTabColor("first", "FF0000"); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, ErrInvalidColor, w.SetTabColor("first", "red"))
+	equal(t, ErrSheetNotFound, w.SetActiveSheet("missing"))
+	if err := w.MoveSheet("second", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err := w.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	f, err := xlsx.OpenBinary(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	for _, sheet := range f.Sheets {
+		names = append(names, sheet.Name)
+	}
+	equal(t, []string{"Tab", "second", "first"}, names)
+
+	parts := zipParts(t, buf.Bytes())
+	if !strings.Contains(parts["xl/worksheets/sheet1.xml"], `<tabColor rgb="FF00FF00"/>`) {
+		t.Error("test failed: missing tab color of sheet Tab")
+	}
+	if !strings.Contains(parts["xl/worksheets/sheet3.xml"], `<tabColor rgb="FFFF0000"/>`) {
+		t.Error("test failed: missing tab color of sheet first")
+	}
+	if strings.Contains(parts["xl/workbook.xml"], "activeTab") {
+		t.Error("test failed: first sheet is active, activeTab should be omitted")
+	}
+
+	_ = w.SetActiveSheet("first")
+	buf.Reset()
+	_, _ = w.WriteTo(buf)
+	if !strings.Contains(zipParts(t, buf.Bytes())["xl/workbook.xml"], `activeTab="2"`) {
+		t.Error("test failed: missing activeTab")
+	}
+}
+
+func zipParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+type (
+	regionTmp struct {
+		Region string    `excel:"Region"`
+		Amount float64   `excel:"Amount"`
+		Booked time.Time `excel:"Booked"`
+	}
+	northTmp       regionTmp
+	southTmp       regionTmp
+	strictNorthTmp regionTmp
+)
+
+func (*northTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "North" }
+func (*northTmp) ReadConfigure(*ReadConfig)      {}
+func (*southTmp) WriteConfigure(wc *WriteConfig) { wc.SheetName = "South" }
+func (*southTmp) ReadConfigure(rc *ReadConfig)   { rc.SheetIndex = 3 }
+func (*strictNorthTmp) WriteConfigure(wc *WriteConfig) {
+	wc.SheetName = "North"
+	wc.StrictSheetNames = true
+}
+
+func TestGoWriteSheet(t *testing.T) {
+	booked := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	north := make([]*northTmp, 0, 500)
+	south := make([]*southTmp, 0, 500)
+	for i := 0; i < 500; i++ {
+		north = append(north, &northTmp{"north", float64(i) / 4, booked})
+		south = append(south, &southTmp{"south", float64(i) / 2, booked})
+	}
+	w := NewWriter()
+	_ = w.Write("first", []int{1})
+	GoWriteSheet(w, north)
+	GoWriteSheet(w, []*tabTmp{{1}})
+	GoWriteSheet(w, south)
+	buf := &bytes.Buffer{}
+	if _, err := w.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := Inspect(bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	names := make([]string, 0, len(info.Sheets))
+	for _, s := range info.Sheets {
+		names = append(names, s.Name)
+	}
+	// Tab moves itself to the front
+	equal(t, []string{"Tab", "first", "North", "South"}, names)
+	parts := zipParts(t, buf.Bytes())
+	if !strings.Contains(parts["xl/worksheets/sheet1.xml"], `<tabColor rgb="FF00FF00"/>`) {
+		t.Error("test failed: missing tab color of sheet Tab")
+	}
+	ns, err := ReadBinary[*southTmp](buf.Bytes())
+	equal(t, nil, err)
+	equal(t, south, ns)
+
+	// Duplicate sheet names are suffixed, or fail with StrictSheetNames when the sheets are added
+	w = NewWriter()
+	GoWriteSheet(w, north)
+	GoWriteSheet(w, north)
+	equal(t, nil, w.Wait())
+	equal(t, []string{"North", "North (2)"}, w.book.Sheets())
+	GoWriteSheet(w, []*strictNorthTmp{})
+	GoWriteSheet(w, north)
+	if err = w.Wait(); !errors.Is(err, ErrInvalidSheetName) {
+		t.Errorf("expected duplicate sheet error, got %v", err)
+	}
+	equal(t, []string{"North", "North (2)"}, w.book.Sheets())
+
+	// The hidden sheets are added to the workbook once, listing the sheets as added
+	w = NewWriter()
+	_ = w.Write("North", []int{1})
+	GoWriteSheet(w, []*auditTmp{{"North", 1}, {"South", 2}})
+	buf.Reset()
+	if _, err = w.WriteTo(buf); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, []string{"North", "North (2)", "South", AuditSheetName}, w.book.Sheets())
+	audit, err := ReadAudit(bytes.NewReader(buf.Bytes()))
+	equal(t, nil, err)
+	equal(t, []AuditSheet{{"North (2)", 1}, {"South", 1}}, audit.Sheets)
+}